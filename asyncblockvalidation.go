@@ -0,0 +1,48 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package leanhelix
+
+import (
+	"context"
+	"github.com/orbs-network/govnr"
+)
+
+// AsyncBlockValidation is the BlockValidationScheduler of the WorkerLoop when Config.AsyncBlockValidation is set.
+// Each validation runs on its own goroutine and its result is posted back on Results(), which the WorkerLoop
+// selects on alongside messages and elections, so a slow ValidateBlockProposal does not hold up a view change.
+type AsyncBlockValidation struct {
+	ctx     context.Context
+	errorer govnr.Errorer
+	results chan func()
+}
+
+// ctx bounds the lifetime of the WorkerLoop, results pending after it is done are discarded
+func NewAsyncBlockValidation(ctx context.Context, errorer govnr.Errorer) *AsyncBlockValidation {
+	return &AsyncBlockValidation{
+		ctx:     ctx,
+		errorer: errorer,
+		results: make(chan func()),
+	}
+}
+
+func (v *AsyncBlockValidation) ScheduleBlockValidation(ctx context.Context, validate func(ctx context.Context) error, onValidated func(err error)) {
+	govnr.GoOnce(v.errorer, func() {
+		err := validate(ctx)
+		if err == nil {
+			err = ctx.Err()
+		}
+		select {
+		case v.results <- func() { onValidated(err) }:
+		case <-v.ctx.Done():
+		}
+	})
+}
+
+// Each result must be called on the WorkerLoop
+func (v *AsyncBlockValidation) Results() <-chan func() {
+	return v.results
+}
@@ -0,0 +1,59 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package leanhelix
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/orbs-network/scribe/log"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestAsyncBlockValidationPostsTheResultBack(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		v := NewAsyncBlockValidation(ctx, GovnrErrorer(log.GetLogger()))
+		resume := make(chan struct{})
+		var result error
+		validated := false
+
+		v.ScheduleBlockValidation(ctx, func(ctx context.Context) error {
+			<-resume
+			return errors.New("invalid block")
+		}, func(err error) {
+			validated = true
+			result = err
+		})
+		require.False(t, validated)
+
+		close(resume)
+		onValidated := <-v.Results()
+		require.False(t, validated, "the result should only be handled when called by the worker loop")
+		onValidated()
+		require.True(t, validated)
+		require.EqualError(t, result, "invalid block")
+	})
+}
+
+func TestAsyncBlockValidationReportsACanceledValidation(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		v := NewAsyncBlockValidation(ctx, GovnrErrorer(log.GetLogger()))
+		validationCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		var result error
+
+		v.ScheduleBlockValidation(validationCtx, func(ctx context.Context) error {
+			return nil // ignores its context
+		}, func(err error) {
+			result = err
+		})
+
+		(<-v.Results())()
+		require.Equal(t, context.Canceled, result)
+	})
+}
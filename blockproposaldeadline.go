@@ -0,0 +1,138 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package leanhelix
+
+import (
+	"context"
+	"github.com/orbs-network/govnr"
+	"github.com/orbs-network/lean-helix-go/instrumentation/metrics"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"sync/atomic"
+	"time"
+)
+
+// BlockProposalDeadline wraps the BlockUtils when Config.ProposalTimeout is set.
+// If RequestNewBlockProposal does not return in time, its context is canceled and the empty block of the
+// wrapped EmptyBlockProposer is proposed instead, so the leader does not leave the view without a proposal.
+// The empty block gets a deadline of the same timeout; if it passes as well, nil is returned and nothing is proposed.
+// Without an EmptyBlockProposer the deadline is only counted and the request is awaited.
+// It wraps the BlockProposalPipeline, if any, and passes ProposeAhead through without a deadline, as no leader awaits those blocks yet.
+type BlockProposalDeadline struct {
+	interfaces.BlockUtils
+	emptyBlockProposer         interfaces.EmptyBlockProposer
+	timeout                    int64
+	errorer                    govnr.Errorer
+	requests                   uint64
+	timeouts                   uint64
+	emptyBlockFallbacks        uint64
+	emptyBlockFallbackTimeouts uint64
+}
+
+type blockProposal struct {
+	block     interfaces.Block
+	blockHash primitives.BlockHash
+}
+
+// pipelinedBlockProposalDeadline keeps the ProposeAhead of a wrapped PipelinedBlockUtils
+type pipelinedBlockProposalDeadline struct {
+	*BlockProposalDeadline
+	pipeline interfaces.PipelinedBlockUtils
+}
+
+func NewBlockProposalDeadline(blockUtils interfaces.BlockUtils, timeout time.Duration, errorer govnr.Errorer) *BlockProposalDeadline {
+	unwrapped := blockUtils
+	if pipeline, ok := blockUtils.(*BlockProposalPipeline); ok {
+		unwrapped = pipeline.BlockUtils
+	}
+	emptyBlockProposer, _ := unwrapped.(interfaces.EmptyBlockProposer)
+	return &BlockProposalDeadline{
+		BlockUtils:         blockUtils,
+		emptyBlockProposer: emptyBlockProposer,
+		timeout:            int64(timeout),
+		errorer:            errorer,
+	}
+}
+
+// The BlockUtils to configure, a PipelinedBlockUtils if the wrapped BlockUtils is one
+func (d *BlockProposalDeadline) blockUtils() interfaces.BlockUtils {
+	if pipeline, ok := d.BlockUtils.(interfaces.PipelinedBlockUtils); ok {
+		return &pipelinedBlockProposalDeadline{d, pipeline}
+	}
+	return d
+}
+
+func (d *pipelinedBlockProposalDeadline) ProposeAhead(ctx context.Context, blockHeight primitives.BlockHeight, memberId primitives.MemberId, prevBlock interfaces.Block, prevBlockHash primitives.BlockHash) {
+	d.pipeline.ProposeAhead(ctx, blockHeight, memberId, prevBlock, prevBlockHash)
+}
+
+func (d *BlockProposalDeadline) RequestNewBlockProposal(ctx context.Context, blockHeight primitives.BlockHeight, memberId primitives.MemberId, prevBlock interfaces.Block) (interfaces.Block, primitives.BlockHash) {
+	atomic.AddUint64(&d.requests, 1)
+	timeout := time.Duration(atomic.LoadInt64(&d.timeout))
+	proposalCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	proposals := make(chan *blockProposal, 1)
+	govnr.GoOnce(d.errorer, func() {
+		block, blockHash := d.BlockUtils.RequestNewBlockProposal(proposalCtx, blockHeight, memberId, prevBlock)
+		proposals <- &blockProposal{block, blockHash}
+	})
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case p := <-proposals:
+		return p.block, p.blockHash
+	case <-ctx.Done():
+		return nil, nil
+	case <-timer.C:
+	}
+
+	atomic.AddUint64(&d.timeouts, 1)
+	if d.emptyBlockProposer == nil {
+		select {
+		case p := <-proposals:
+			return p.block, p.blockHash
+		case <-ctx.Done():
+			return nil, nil
+		}
+	}
+
+	cancel()
+	atomic.AddUint64(&d.emptyBlockFallbacks, 1)
+	return d.requestEmptyBlockProposal(ctx, timeout, blockHeight, memberId, prevBlock)
+}
+
+func (d *BlockProposalDeadline) requestEmptyBlockProposal(ctx context.Context, timeout time.Duration, blockHeight primitives.BlockHeight, memberId primitives.MemberId, prevBlock interfaces.Block) (interfaces.Block, primitives.BlockHash) {
+	fallbackCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	proposals := make(chan *blockProposal, 1)
+	govnr.GoOnce(d.errorer, func() {
+		block, blockHash := d.emptyBlockProposer.RequestEmptyBlockProposal(fallbackCtx, blockHeight, memberId, prevBlock)
+		proposals <- &blockProposal{block, blockHash}
+	})
+
+	select {
+	case p := <-proposals:
+		return p.block, p.blockHash
+	case <-fallbackCtx.Done():
+		if ctx.Err() == nil {
+			atomic.AddUint64(&d.emptyBlockFallbackTimeouts, 1)
+		}
+		return nil, nil
+	}
+}
+
+// Takes effect from the next RequestNewBlockProposal
+func (d *BlockProposalDeadline) SetTimeout(timeout time.Duration) {
+	atomic.StoreInt64(&d.timeout, int64(timeout))
+}
+
+func (d *BlockProposalDeadline) Metrics() metrics.BlockProposalMetrics {
+	return metrics.NewBlockProposalMetrics(atomic.LoadUint64(&d.requests), atomic.LoadUint64(&d.timeouts), atomic.LoadUint64(&d.emptyBlockFallbacks), atomic.LoadUint64(&d.emptyBlockFallbackTimeouts))
+}
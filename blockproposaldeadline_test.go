@@ -0,0 +1,143 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package leanhelix
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/services/logger"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"github.com/orbs-network/scribe/log"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// hides RequestEmptyBlockProposal of the wrapped BlockUtils
+type blockUtilsWithoutEmptyBlocks struct {
+	interfaces.BlockUtils
+}
+
+// RequestEmptyBlockProposal returns once its context is done
+type blockUtilsWithStuckEmptyBlocks struct {
+	*mocks.PausableBlockUtils
+}
+
+func (b *blockUtilsWithStuckEmptyBlocks) RequestEmptyBlockProposal(ctx context.Context, blockHeight primitives.BlockHeight, memberId primitives.MemberId, prevBlock interfaces.Block) (interfaces.Block, primitives.BlockHash) {
+	<-ctx.Done()
+	return nil, nil
+}
+
+func aSlowBlockUtils(blockProductionDelay time.Duration, upcomingBlocks ...interfaces.Block) *mocks.PausableBlockUtils {
+	blockUtils := mocks.NewMockBlockUtils(primitives.MemberId("leader"), mocks.NewBlocksPool(upcomingBlocks), logger.NewSilentLogger())
+	blockUtils.BlockProductionDelay = blockProductionDelay
+	return blockUtils
+}
+
+func TestBlockProposalDeadlineReturnsABlockProducedInTime(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		block := mocks.ABlock(interfaces.GenesisBlock)
+		deadline := NewBlockProposalDeadline(aSlowBlockUtils(0, block), time.Second, GovnrErrorer(log.GetLogger()))
+
+		proposed, _ := deadline.RequestNewBlockProposal(ctx, 1, primitives.MemberId("leader"), interfaces.GenesisBlock)
+
+		require.Equal(t, block, proposed)
+		require.Equal(t, uint64(1), deadline.Metrics().Requests())
+		require.Equal(t, uint64(0), deadline.Metrics().Timeouts())
+		require.Equal(t, uint64(0), deadline.Metrics().EmptyBlockFallbacks())
+	})
+}
+
+func TestBlockProposalDeadlineProposesAnEmptyBlockWhenItPasses(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		deadline := NewBlockProposalDeadline(aSlowBlockUtils(time.Hour), 10*time.Millisecond, GovnrErrorer(log.GetLogger()))
+
+		proposed, blockHash := deadline.RequestNewBlockProposal(ctx, 1, primitives.MemberId("leader"), interfaces.GenesisBlock)
+
+		require.True(t, mocks.IsEmptyBlock(proposed))
+		require.Equal(t, primitives.BlockHeight(1), proposed.Height())
+		require.True(t, mocks.CalculateBlockHash(proposed).Equal(blockHash))
+		require.Equal(t, uint64(1), deadline.Metrics().Timeouts())
+		require.Equal(t, uint64(1), deadline.Metrics().EmptyBlockFallbacks())
+		require.Equal(t, uint64(0), deadline.Metrics().EmptyBlockFallbackTimeouts())
+	})
+}
+
+func TestBlockProposalDeadlineGivesUpOnAnEmptyBlockWhichIsNotProducedInTimeEither(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		blockUtils := &blockUtilsWithStuckEmptyBlocks{aSlowBlockUtils(time.Hour)}
+		deadline := NewBlockProposalDeadline(blockUtils, 10*time.Millisecond, GovnrErrorer(log.GetLogger()))
+
+		proposed, blockHash := deadline.RequestNewBlockProposal(ctx, 1, primitives.MemberId("leader"), interfaces.GenesisBlock)
+
+		require.Nil(t, proposed)
+		require.Nil(t, blockHash)
+		require.NoError(t, ctx.Err())
+		require.Equal(t, uint64(1), deadline.Metrics().Timeouts())
+		require.Equal(t, uint64(1), deadline.Metrics().EmptyBlockFallbacks())
+		require.Equal(t, uint64(1), deadline.Metrics().EmptyBlockFallbackTimeouts())
+	})
+}
+
+func TestBlockProposalDeadlineWaitsForTheBlockWithoutAnEmptyBlockProposer(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		block := mocks.ABlock(interfaces.GenesisBlock)
+		blockUtils := &blockUtilsWithoutEmptyBlocks{aSlowBlockUtils(50*time.Millisecond, block)}
+		deadline := NewBlockProposalDeadline(blockUtils, 10*time.Millisecond, GovnrErrorer(log.GetLogger()))
+
+		proposed, _ := deadline.RequestNewBlockProposal(ctx, 1, primitives.MemberId("leader"), interfaces.GenesisBlock)
+
+		require.Equal(t, block, proposed)
+		require.Equal(t, uint64(1), deadline.Metrics().Timeouts())
+		require.Equal(t, uint64(0), deadline.Metrics().EmptyBlockFallbacks())
+	})
+}
+
+func TestBlockProposalDeadlineDoesNotBoundBlocksBuiltAhead(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		memberId := primitives.MemberId("leader")
+		prevBlock := mocks.ABlock(interfaces.GenesisBlock)
+		block := mocks.ABlock(prevBlock)
+		config := aValidConfig()
+		config.BlockUtils = aSlowBlockUtils(50*time.Millisecond, block)
+		config.PipelinedProposals = true
+		config.ProposalTimeout = 20 * time.Millisecond
+		mainLoop := NewLeanHelix(config, nil, nil)
+		pipeline, ok := mainLoop.config.BlockUtils.(interfaces.PipelinedBlockUtils)
+		require.True(t, ok, "the deadline should keep ProposeAhead of the pipeline")
+
+		pipeline.ProposeAhead(ctx, 2, memberId, prevBlock, mocks.CalculateBlockHash(prevBlock))
+		time.Sleep(100 * time.Millisecond) // the block is built ahead, longer than the deadline, before the leader needs it
+		proposed, _ := pipeline.RequestNewBlockProposal(ctx, 2, memberId, prevBlock)
+
+		require.Equal(t, block, proposed, "should propose the block built ahead, not an empty block")
+		require.Equal(t, uint64(1), mainLoop.BlockProposalMetrics().Requests())
+		require.Equal(t, uint64(0), mainLoop.BlockProposalMetrics().Timeouts())
+		require.Equal(t, uint64(0), mainLoop.BlockProposalMetrics().EmptyBlockFallbacks())
+	})
+}
+
+func TestBlockProposalDeadlineProposesAnEmptyBlockWhenTheBlockBuiltAheadIsLate(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		memberId := primitives.MemberId("leader")
+		prevBlock := mocks.ABlock(interfaces.GenesisBlock)
+		config := aValidConfig()
+		config.BlockUtils = aSlowBlockUtils(time.Hour)
+		config.PipelinedProposals = true
+		config.ProposalTimeout = 20 * time.Millisecond
+		mainLoop := NewLeanHelix(config, nil, nil)
+		pipeline := mainLoop.config.BlockUtils.(interfaces.PipelinedBlockUtils)
+
+		pipeline.ProposeAhead(ctx, 2, memberId, prevBlock, mocks.CalculateBlockHash(prevBlock))
+		proposed, _ := pipeline.RequestNewBlockProposal(ctx, 2, memberId, prevBlock)
+
+		require.True(t, mocks.IsEmptyBlock(proposed))
+		require.Equal(t, uint64(1), mainLoop.BlockProposalMetrics().EmptyBlockFallbacks())
+	})
+}
@@ -0,0 +1,113 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package leanhelix
+
+import (
+	"context"
+	"github.com/orbs-network/govnr"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"sync"
+)
+
+// BlockProposalPipeline wraps the BlockUtils when Config.PipelinedProposals is set.
+// Once a height is prepared locally, the block of the next height is built on top of the prepared block in the background,
+// so a leader of H+1 has its proposal ready when H commits instead of producing it only after the commit.
+// The PREPREPARE of H+1 still waits for the commit of H: the committee of H+1 and its leader are ordered by the random seed
+// derived from the block proof of H, and no vote on H+1 is cast before H commits, so the safety of consensus is unchanged.
+// A block built on a prepared block which ends up not committed is discarded.
+// Every committee member builds ahead, unless the wrapped BlockUtils is a ProposeAheadFilter which skips it.
+type BlockProposalPipeline struct {
+	interfaces.BlockUtils
+	errorer     govnr.Errorer
+	lock        sync.Mutex
+	speculation *speculativeProposal
+}
+
+type speculativeProposal struct {
+	blockHeight   primitives.BlockHeight
+	memberId      primitives.MemberId
+	prevBlockHash primitives.BlockHash
+	cancel        context.CancelFunc
+	done          chan struct{}
+	block         interfaces.Block
+	blockHash     primitives.BlockHash
+}
+
+func NewBlockProposalPipeline(blockUtils interfaces.BlockUtils, errorer govnr.Errorer) *BlockProposalPipeline {
+	return &BlockProposalPipeline{
+		BlockUtils: blockUtils,
+		errorer:    errorer,
+	}
+}
+
+// Replaces the block being built ahead, if any, unless a ProposeAheadFilter skips it. Calls to the wrapped BlockUtils never overlap.
+func (p *BlockProposalPipeline) ProposeAhead(ctx context.Context, blockHeight primitives.BlockHeight, memberId primitives.MemberId, prevBlock interfaces.Block, prevBlockHash primitives.BlockHash) {
+	if filter, ok := p.BlockUtils.(interfaces.ProposeAheadFilter); ok && !filter.ShouldProposeAhead(blockHeight, memberId, prevBlock) {
+		return
+	}
+	speculationCtx, cancel := context.WithCancel(ctx)
+	s := &speculativeProposal{
+		blockHeight:   blockHeight,
+		memberId:      memberId,
+		prevBlockHash: prevBlockHash,
+		cancel:        cancel,
+		done:          make(chan struct{}),
+	}
+
+	previous := p.replaceSpeculation(s)
+	if previous != nil {
+		previous.cancel()
+	}
+
+	govnr.GoOnce(p.errorer, func() {
+		defer close(s.done)
+		defer cancel()
+		if previous != nil {
+			select {
+			case <-previous.done:
+			case <-speculationCtx.Done():
+				return
+			}
+		}
+		s.block, s.blockHash = p.BlockUtils.RequestNewBlockProposal(speculationCtx, blockHeight, memberId, prevBlock)
+	})
+}
+
+func (p *BlockProposalPipeline) RequestNewBlockProposal(ctx context.Context, blockHeight primitives.BlockHeight, memberId primitives.MemberId, prevBlock interfaces.Block) (interfaces.Block, primitives.BlockHash) {
+	s := p.replaceSpeculation(nil)
+	if s != nil {
+		if !p.isBuiltOn(s, blockHeight, memberId, prevBlock) {
+			s.cancel()
+		}
+		select {
+		case <-s.done:
+		case <-ctx.Done():
+			s.cancel()
+			return nil, nil
+		}
+		if s.block != nil && p.isBuiltOn(s, blockHeight, memberId, prevBlock) {
+			return s.block, s.blockHash
+		}
+	}
+	return p.BlockUtils.RequestNewBlockProposal(ctx, blockHeight, memberId, prevBlock)
+}
+
+func (p *BlockProposalPipeline) replaceSpeculation(s *speculativeProposal) *speculativeProposal {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	previous := p.speculation
+	p.speculation = s
+	return previous
+}
+
+func (p *BlockProposalPipeline) isBuiltOn(s *speculativeProposal, blockHeight primitives.BlockHeight, memberId primitives.MemberId, prevBlock interfaces.Block) bool {
+	return prevBlock != nil &&
+		s.blockHeight == blockHeight &&
+		s.memberId.Equal(memberId) &&
+		p.BlockUtils.ValidateBlockCommitment(blockHeight-1, prevBlock, s.prevBlockHash)
+}
@@ -0,0 +1,99 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package leanhelix
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/services/logger"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"github.com/orbs-network/scribe/log"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func aBlockProposalPipeline(memberId primitives.MemberId, upcomingBlocks ...interfaces.Block) *BlockProposalPipeline {
+	blockUtils := mocks.NewMockBlockUtils(memberId, mocks.NewBlocksPool(upcomingBlocks), logger.NewSilentLogger())
+	return NewBlockProposalPipeline(blockUtils, GovnrErrorer(log.GetLogger()))
+}
+
+func TestBlockProposalPipelineReturnsTheBlockBuiltAhead(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		memberId := primitives.MemberId("leader")
+		prevBlock := mocks.ABlock(interfaces.GenesisBlock)
+		builtAhead := mocks.ABlock(prevBlock)
+		notBuilt := mocks.ABlock(prevBlock)
+		pipeline := aBlockProposalPipeline(memberId, builtAhead, notBuilt)
+
+		pipeline.ProposeAhead(ctx, 2, memberId, prevBlock, mocks.CalculateBlockHash(prevBlock))
+		block, blockHash := pipeline.RequestNewBlockProposal(ctx, 2, memberId, prevBlock)
+
+		require.Equal(t, builtAhead, block)
+		require.Equal(t, mocks.CalculateBlockHash(builtAhead), blockHash)
+	})
+}
+
+func TestBlockProposalPipelineDiscardsABlockBuiltOnAnUncommittedBlock(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		memberId := primitives.MemberId("leader")
+		preparedBlock := mocks.ABlock(interfaces.GenesisBlock)
+		committedBlock := mocks.ABlock(interfaces.GenesisBlock)
+		builtAhead := mocks.ABlock(preparedBlock)
+		builtOnCommitted := mocks.ABlock(committedBlock)
+		pipeline := aBlockProposalPipeline(memberId, builtAhead, builtOnCommitted)
+
+		pipeline.ProposeAhead(ctx, 2, memberId, preparedBlock, mocks.CalculateBlockHash(preparedBlock))
+		block, _ := pipeline.RequestNewBlockProposal(ctx, 2, memberId, committedBlock)
+
+		require.Equal(t, builtOnCommitted, block)
+	})
+}
+
+func TestBlockProposalPipelineDiscardsABlockBuiltForAnotherHeight(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		memberId := primitives.MemberId("leader")
+		block1 := mocks.ABlock(interfaces.GenesisBlock)
+		block2 := mocks.ABlock(block1)
+		builtAhead := mocks.ABlock(block1)
+		builtOnBlock2 := mocks.ABlock(block2)
+		pipeline := aBlockProposalPipeline(memberId, builtAhead, builtOnBlock2)
+
+		pipeline.ProposeAhead(ctx, 2, memberId, block1, mocks.CalculateBlockHash(block1))
+		block, _ := pipeline.RequestNewBlockProposal(ctx, 3, memberId, block2)
+
+		require.Equal(t, builtOnBlock2, block)
+	})
+}
+
+// skips building ahead for all but the leader
+type leaderOnlyProposeAheadFilter struct {
+	interfaces.BlockUtils
+	leader primitives.MemberId
+}
+
+func (f *leaderOnlyProposeAheadFilter) ShouldProposeAhead(blockHeight primitives.BlockHeight, memberId primitives.MemberId, prevBlock interfaces.Block) bool {
+	return memberId.Equal(f.leader)
+}
+
+func TestBlockProposalPipelineDoesNotBuildAheadWhenFilteredOut(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		memberId := primitives.MemberId("not-leader")
+		prevBlock := mocks.ABlock(interfaces.GenesisBlock)
+		firstBlock := mocks.ABlock(prevBlock)
+		secondBlock := mocks.ABlock(prevBlock)
+		blockUtils := mocks.NewMockBlockUtils(memberId, mocks.NewBlocksPool([]interfaces.Block{firstBlock, secondBlock}), logger.NewSilentLogger())
+		pipeline := NewBlockProposalPipeline(&leaderOnlyProposeAheadFilter{blockUtils, primitives.MemberId("leader")}, GovnrErrorer(log.GetLogger()))
+
+		pipeline.ProposeAhead(ctx, 2, memberId, prevBlock, mocks.CalculateBlockHash(prevBlock))
+		require.Nil(t, pipeline.speculation, "should not build ahead on a member the filter skips")
+
+		block, _ := pipeline.RequestNewBlockProposal(ctx, 2, memberId, prevBlock)
+		require.Equal(t, firstBlock, block)
+	})
+}
@@ -0,0 +1,123 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package leanhelix
+
+import (
+	"github.com/orbs-network/lean-helix-go/services/blockheight"
+	"github.com/orbs-network/lean-helix-go/services/events"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/services/messagesfactory"
+	"github.com/orbs-network/lean-helix-go/services/randomseed"
+	"github.com/orbs-network/lean-helix-go/services/storage"
+	"github.com/orbs-network/lean-helix-go/services/termincommittee"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
+	"github.com/orbs-network/lean-helix-go/state"
+)
+
+// A member still sending messages of the height we last committed has missed its commit.
+// We hold that block and its proof, so we push them to the member instead of waiting for it to block sync.
+// Only the latest block is kept, so only members exactly one height behind are served, and only if we were
+// a member of that block's committee. The sender must be a member of that committee and
+// its PREPARE, COMMIT or VIEW_CHANGE must verify, otherwise a spoofed sender would make us send it a full block.
+// Each member is sent the certificate of a height at most once.
+func (lh *WorkerLoop) pushCommitCertificateIfLagging(message interfaces.ConsensusMessage) {
+	if lh.latestBlock == nil || len(lh.latestBlockProofBytes) == 0 {
+		return
+	}
+	height := lh.latestBlock.Height()
+	sender := message.SenderMemberId()
+	if message.BlockHeight() != height || message.InstanceId() != lh.config.InstanceId || sender.Equal(lh.config.Membership.MyMemberId()) {
+		return
+	}
+	if pushedHeight, ok := lh.commitCertificatePushes[storage.MemberIdStr(sender)]; ok && pushedHeight >= height {
+		return
+	}
+	if !isMember(lh.latestCommittee, sender) {
+		return
+	}
+	signedHeader, signature := signedHeaderOf(message)
+	if signature == nil || !signature.MemberId().Equal(sender) {
+		return
+	}
+	if err := lh.config.KeyManager.VerifyConsensusMessage(height, signedHeader, signature); err != nil {
+		lh.logger.Debug("LHMSG SEND COMMIT_CERTIFICATE IGNORE - verification of %s from %s failed: %s", message.MessageType(), termincommittee.Str(sender), err)
+		return
+	}
+	lh.commitCertificatePushes[storage.MemberIdStr(sender)] = height
+
+	messageFactory := messagesfactory.NewMessageFactoryWithRandomnessBeacon(lh.config.InstanceId, lh.config.KeyManager, randomseed.RandomnessBeaconOf(lh.config), lh.config.Membership.MyMemberId(), 0)
+	ccm := messageFactory.CreateCommitCertificateMessage(height, message.View(), lh.latestBlock, lh.latestBlockProofBytes)
+	lh.logger.Debug("LHMSG SEND COMMIT_CERTIFICATE for H=%d to lagging member %s", height, termincommittee.Str(sender))
+	ctx, err := lh.state.Contexts.For(lh.state.HeightView())
+	if err != nil {
+		lh.logger.Info("LHMSG SEND COMMIT_CERTIFICATE FAILED - %s", err)
+		return
+	}
+	if err := lh.config.Communication.SendConsensusMessage(ctx, []primitives.MemberId{sender}, ccm.ToConsensusRawMessage()); err != nil {
+		lh.logger.Info("LHMSG SEND COMMIT_CERTIFICATE FAILED - %s", err)
+	}
+}
+
+// Returns a nil signature for messages whose signature does not cover a header of their own
+func signedHeaderOf(message interfaces.ConsensusMessage) ([]byte, *protocol.SenderSignature) {
+	switch message := message.(type) {
+	case *interfaces.PrepareMessage:
+		return message.Content().SignedHeader().Raw(), message.Content().Sender()
+	case *interfaces.CommitMessage:
+		return message.Content().SignedHeader().Raw(), message.Content().Sender()
+	case *interfaces.ViewChangeMessage:
+		return message.Content().SignedHeader().Raw(), message.Content().Sender()
+	}
+	return nil, nil
+}
+
+func isMember(members []primitives.MemberId, memberId primitives.MemberId) bool {
+	for _, member := range members {
+		if member.Equal(memberId) {
+			return true
+		}
+	}
+	return false
+}
+
+// A certificate of the height we are working on is validated like a synced block, committed and then advances us through the UpdateState path
+func (lh *WorkerLoop) handleCommitCertificate(ccm *interfaces.CommitCertificateMessage) {
+	current := lh.state.HeightView()
+	if ccm.InstanceId() != lh.config.InstanceId || ccm.BlockHeight() != current.Height() || ccm.Block() == nil {
+		lh.logger.Debug("LHMSG RECEIVED COMMIT_CERTIFICATE IGNORE - H=%d while at %s", ccm.BlockHeight(), current)
+		return
+	}
+	// the signed header does not cover the block, which must be of the height the certificate was sent for
+	if ccm.Block().Height() != current.Height() {
+		lh.logger.Info("LHMSG RECEIVED COMMIT_CERTIFICATE IGNORE - block of H=%d while at %s", ccm.Block().Height(), current)
+		return
+	}
+
+	ctx, err := lh.state.Contexts.For(state.NewHeightView(current.Height(), termincommittee.MaxView)) // umbrella context for current term
+	if err != nil {
+		lh.logger.Info("LHMSG RECEIVED COMMIT_CERTIFICATE IGNORE - %s", err)
+		return
+	}
+
+	if err := lh.ValidateBlockConsensus(ctx, ccm.Block(), ccm.BlockProof(), lh.latestBlock, lh.latestBlockProofBytes, false); err != nil {
+		lh.logger.Info("LHMSG RECEIVED COMMIT_CERTIFICATE IGNORE - validation failed: %s", err)
+		return
+	}
+
+	lh.logger.Debug("LHFLOW LHMSG RECEIVED COMMIT_CERTIFICATE for H=%d from %s, committing", blockheight.GetBlockHeight(ccm.Block()), termincommittee.Str(ccm.SenderMemberId()))
+	if err := lh.onCommitCallback(ctx, ccm.Block(), ccm.BlockProof()); err != nil {
+		lh.logger.Info("LHFLOW onCommitCallback FAILED for COMMIT_CERTIFICATE - %s", err)
+		return
+	}
+	if lh.handleUpdateState(&blockWithProof{
+		block:               ccm.Block(),
+		prevBlockProofBytes: ccm.BlockProof(),
+	}) {
+		lh.publisher.Publish(&events.Event{Type: events.COMMIT_CERTIFICATE_APPLIED, Height: lh.state.Height()})
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package leanhelix
+
+import (
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"time"
+)
+
+type configUpdateRequest struct {
+	update *interfaces.ConfigUpdate
+	report chan *interfaces.ConfigUpdateReport
+}
+
+// Implemented by the TimerBasedElectionTrigger
+type electionTimeoutsSetter interface {
+	Timeouts() (minTimeout time.Duration, expBase float64)
+	SetTimeouts(minTimeout time.Duration, expBase float64)
+}
+
+// Called between heights, before the term of the new height starts
+func (lh *WorkerLoop) applyPendingConfigUpdates() {
+	for _, request := range lh.pendingConfigUpdates {
+		request.report <- lh.applyConfigUpdate(request.update)
+	}
+	lh.pendingConfigUpdates = nil
+}
+
+func (lh *WorkerLoop) applyConfigUpdate(update *interfaces.ConfigUpdate) *interfaces.ConfigUpdateReport {
+	report := &interfaces.ConfigUpdateReport{Skipped: make(map[string]string)}
+	applied := func(setting string) {
+		report.Applied = append(report.Applied, setting)
+	}
+
+	if update.ElectionTimeoutOnV0 != nil || update.ElectionTimeoutExpBase != nil {
+		if electionTrigger, ok := lh.electionTrigger.(electionTimeoutsSetter); ok {
+			minTimeout, expBase := electionTrigger.Timeouts()
+			if update.ElectionTimeoutOnV0 != nil {
+				minTimeout = *update.ElectionTimeoutOnV0
+				lh.config.ElectionTimeoutOnV0 = minTimeout
+				applied("ElectionTimeoutOnV0")
+			}
+			if update.ElectionTimeoutExpBase != nil {
+				expBase = *update.ElectionTimeoutExpBase
+				applied("ElectionTimeoutExpBase")
+			}
+			electionTrigger.SetTimeouts(minTimeout, expBase)
+		} else {
+			if update.ElectionTimeoutOnV0 != nil {
+				report.Skipped["ElectionTimeoutOnV0"] = "the configured election scheduler has fixed timeouts"
+			}
+			if update.ElectionTimeoutExpBase != nil {
+				report.Skipped["ElectionTimeoutExpBase"] = "the configured election scheduler has fixed timeouts"
+			}
+		}
+	}
+
+	if update.LogLevel != nil {
+		lh.logger.SetLevel(*update.LogLevel)
+		applied("LogLevel")
+	}
+
+	if update.RetransmissionInterval != nil {
+		lh.config.RetransmissionInterval = *update.RetransmissionInterval
+		lh.retransmissionTicker = resetTicker(lh.retransmissionTicker, lh.config.RetransmissionInterval)
+		applied("RetransmissionInterval")
+	}
+
+	if update.RebroadcastInterval != nil {
+		lh.config.RebroadcastInterval = *update.RebroadcastInterval
+		lh.rebroadcastTicker = resetTicker(lh.rebroadcastTicker, lh.config.RebroadcastInterval)
+		applied("RebroadcastInterval")
+	}
+
+	if update.VerificationCacheSize != nil {
+		switch {
+		case lh.verificationCache == nil:
+			report.Skipped["VerificationCacheSize"] = "signature verifications are not cached, enabling the cache requires a restart"
+		case *update.VerificationCacheSize == 0:
+			report.Skipped["VerificationCacheSize"] = "the cache cannot be disabled on a running node"
+		default:
+			lh.config.VerificationCacheSize = *update.VerificationCacheSize
+			lh.verificationCache.Resize(int(lh.config.VerificationCacheSize))
+			applied("VerificationCacheSize")
+		}
+	}
+
+	if update.ProposalTimeout != nil {
+		switch {
+		case lh.blockProposalDeadline == nil:
+			report.Skipped["ProposalTimeout"] = "block proposals have no deadline, setting one requires a restart"
+		case *update.ProposalTimeout == 0:
+			report.Skipped["ProposalTimeout"] = "the deadline cannot be removed on a running node"
+		default:
+			lh.config.ProposalTimeout = *update.ProposalTimeout
+			lh.blockProposalDeadline.SetTimeout(lh.config.ProposalTimeout)
+			applied("ProposalTimeout")
+		}
+	}
+
+	lh.logger.Info("LHFLOW CONFIG UPDATE applied=%v skipped=%v", report.Applied, report.Skipped)
+	return report
+}
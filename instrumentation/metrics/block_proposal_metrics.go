@@ -0,0 +1,46 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package metrics
+
+type BlockProposalMetrics interface {
+	Requests() uint64
+	Timeouts() uint64
+	EmptyBlockFallbacks() uint64
+	EmptyBlockFallbackTimeouts() uint64 // empty blocks not produced within the timeout either, in which case nothing was proposed
+}
+
+type blockProposalMetrics struct {
+	requests                   uint64
+	timeouts                   uint64
+	emptyBlockFallbacks        uint64
+	emptyBlockFallbackTimeouts uint64
+}
+
+func (m *blockProposalMetrics) Requests() uint64 {
+	return m.requests
+}
+
+func (m *blockProposalMetrics) Timeouts() uint64 {
+	return m.timeouts
+}
+
+func (m *blockProposalMetrics) EmptyBlockFallbacks() uint64 {
+	return m.emptyBlockFallbacks
+}
+
+func (m *blockProposalMetrics) EmptyBlockFallbackTimeouts() uint64 {
+	return m.emptyBlockFallbackTimeouts
+}
+
+func NewBlockProposalMetrics(requests uint64, timeouts uint64, emptyBlockFallbacks uint64, emptyBlockFallbackTimeouts uint64) BlockProposalMetrics {
+	return &blockProposalMetrics{
+		requests:                   requests,
+		timeouts:                   timeouts,
+		emptyBlockFallbacks:        emptyBlockFallbacks,
+		emptyBlockFallbackTimeouts: emptyBlockFallbackTimeouts,
+	}
+}
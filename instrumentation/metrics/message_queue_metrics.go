@@ -0,0 +1,50 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package metrics
+
+import "github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
+
+type MessageQueueMetrics interface {
+	Length() int
+	Capacity() int
+	Dropped() uint64
+	DroppedOf(messageType protocol.MessageType) uint64
+}
+
+type messageQueueMetrics struct {
+	length   int
+	capacity int
+	dropped  map[protocol.MessageType]uint64
+}
+
+func (m *messageQueueMetrics) Length() int {
+	return m.length
+}
+
+func (m *messageQueueMetrics) Capacity() int {
+	return m.capacity
+}
+
+func (m *messageQueueMetrics) Dropped() uint64 {
+	total := uint64(0)
+	for _, count := range m.dropped {
+		total += count
+	}
+	return total
+}
+
+func (m *messageQueueMetrics) DroppedOf(messageType protocol.MessageType) uint64 {
+	return m.dropped[messageType]
+}
+
+func NewMessageQueueMetrics(length int, capacity int, dropped map[protocol.MessageType]uint64) MessageQueueMetrics {
+	return &messageQueueMetrics{
+		length:   length,
+		capacity: capacity,
+		dropped:  dropped,
+	}
+}
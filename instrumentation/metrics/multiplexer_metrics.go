@@ -0,0 +1,50 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package metrics
+
+import "github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+
+type MultiplexerMetrics interface {
+	Instances() []primitives.InstanceId
+	Routed(instanceId primitives.InstanceId) uint64
+	Dropped(instanceId primitives.InstanceId) uint64 // dropped because the queue of the instance was full
+	UnknownInstance() uint64                         // dropped because no instance is registered under their instance id
+}
+
+type multiplexerMetrics struct {
+	routed          map[primitives.InstanceId]uint64
+	dropped         map[primitives.InstanceId]uint64
+	unknownInstance uint64
+}
+
+func (m *multiplexerMetrics) Instances() []primitives.InstanceId {
+	instances := make([]primitives.InstanceId, 0, len(m.routed))
+	for instanceId := range m.routed {
+		instances = append(instances, instanceId)
+	}
+	return instances
+}
+
+func (m *multiplexerMetrics) Routed(instanceId primitives.InstanceId) uint64 {
+	return m.routed[instanceId]
+}
+
+func (m *multiplexerMetrics) Dropped(instanceId primitives.InstanceId) uint64 {
+	return m.dropped[instanceId]
+}
+
+func (m *multiplexerMetrics) UnknownInstance() uint64 {
+	return m.unknownInstance
+}
+
+func NewMultiplexerMetrics(routed map[primitives.InstanceId]uint64, dropped map[primitives.InstanceId]uint64, unknownInstance uint64) MultiplexerMetrics {
+	return &multiplexerMetrics{
+		routed:          routed,
+		dropped:         dropped,
+		unknownInstance: unknownInstance,
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package metrics
+
+import "time"
+
+type ParticipationMetrics interface {
+	Paused() bool
+	Pauses() uint64
+	TimePaused() time.Duration // including the current pause
+}
+
+type participationMetrics struct {
+	paused     bool
+	pauses     uint64
+	timePaused time.Duration
+}
+
+func (m *participationMetrics) Paused() bool {
+	return m.paused
+}
+
+func (m *participationMetrics) Pauses() uint64 {
+	return m.pauses
+}
+
+func (m *participationMetrics) TimePaused() time.Duration {
+	return m.timePaused
+}
+
+func NewParticipationMetrics(paused bool, pauses uint64, timePaused time.Duration) ParticipationMetrics {
+	return &participationMetrics{
+		paused:     paused,
+		pauses:     pauses,
+		timePaused: timePaused,
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package metrics
+
+type VerificationCacheMetrics interface {
+	Hits() uint64
+	Misses() uint64
+	HitRate() float64
+	Size() int
+}
+
+type verificationCacheMetrics struct {
+	hits   uint64
+	misses uint64
+	size   int
+}
+
+func (m *verificationCacheMetrics) Hits() uint64 {
+	return m.hits
+}
+
+func (m *verificationCacheMetrics) Misses() uint64 {
+	return m.misses
+}
+
+func (m *verificationCacheMetrics) HitRate() float64 {
+	total := m.hits + m.misses
+	if total == 0 {
+		return 0
+	}
+	return float64(m.hits) / float64(total)
+}
+
+func (m *verificationCacheMetrics) Size() int {
+	return m.size
+}
+
+func NewVerificationCacheMetrics(hits uint64, misses uint64, size int) VerificationCacheMetrics {
+	return &verificationCacheMetrics{
+		hits:   hits,
+		misses: misses,
+		size:   size,
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package metrics
+
+import "github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
+
+type VerificationPipelineMetrics interface {
+	Workers() int
+	Dropped() uint64                                   // dropped because the shard of their sender was full
+	DroppedOf(messageType protocol.MessageType) uint64 // as Dropped, of a single message type
+}
+
+type verificationPipelineMetrics struct {
+	workers int
+	dropped map[protocol.MessageType]uint64
+}
+
+func (m *verificationPipelineMetrics) Workers() int {
+	return m.workers
+}
+
+func (m *verificationPipelineMetrics) Dropped() uint64 {
+	total := uint64(0)
+	for _, count := range m.dropped {
+		total += count
+	}
+	return total
+}
+
+func (m *verificationPipelineMetrics) DroppedOf(messageType protocol.MessageType) uint64 {
+	return m.dropped[messageType]
+}
+
+func NewVerificationPipelineMetrics(workers int, dropped map[protocol.MessageType]uint64) VerificationPipelineMetrics {
+	return &verificationPipelineMetrics{
+		workers: workers,
+		dropped: dropped,
+	}
+}
@@ -2,11 +2,17 @@ package leanhelix
 
 import (
 	"context"
+	"fmt"
 	"github.com/orbs-network/govnr"
+	"github.com/orbs-network/lean-helix-go/instrumentation/metrics"
+	"github.com/orbs-network/lean-helix-go/services/blockheight"
 	"github.com/orbs-network/lean-helix-go/services/electiontrigger"
+	"github.com/orbs-network/lean-helix-go/services/events"
 	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/services/leanhelixterm"
 	L "github.com/orbs-network/lean-helix-go/services/logger"
 	"github.com/orbs-network/lean-helix-go/services/termincommittee"
+	"github.com/orbs-network/lean-helix-go/services/verificationcache"
 	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
 	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
 	"github.com/orbs-network/lean-helix-go/state"
@@ -20,6 +26,7 @@ type MainLoop struct {
 	govnr.TreeSupervisor
 	messagesChannel             chan *interfaces.ConsensusRawMessage
 	mainUpdateStateChannel      chan *blockWithProof
+	viewChangeRequests          chan *state.HeightView
 	electionScheduler           interfaces.ElectionScheduler
 	config                      *interfaces.Config
 	logger                      L.LHLogger
@@ -27,6 +34,11 @@ type MainLoop struct {
 	onNewConsensusRoundCallback interfaces.OnNewConsensusRoundCallback
 	state                       *state.State
 	worker                      *WorkerLoop
+	verificationCache           *verificationcache.VerificationCache
+	verificationPipeline        *VerificationPipeline
+	blockProposalDeadline       *BlockProposalDeadline
+	participation               *participation
+	publisher                   *events.Publisher
 }
 
 type govnrErrorer struct {
@@ -41,8 +53,19 @@ func GovnrErrorer(logger log.Logger) govnr.Errorer {
 	return &govnrErrorer{logger}
 }
 
+// NewValidatedLeanHelix returns the *interfaces.ConfigError of an invalid config instead of panicking once it runs
+func NewValidatedLeanHelix(config *interfaces.Config, onCommitCallback interfaces.OnCommitCallback, onNewConsensusRoundCallback interfaces.OnNewConsensusRoundCallback) (*MainLoop, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return NewLeanHelix(config, onCommitCallback, onNewConsensusRoundCallback), nil
+}
+
 // TODO Pass logger from Orbs
-func NewLeanHelix(config *interfaces.Config, onCommitCallback interfaces.OnCommitCallback, onNewConsensusRoundCallback interfaces.OnNewConsensusRoundCallback) *MainLoop {
+// The caller's config is not modified, the MainLoop wraps KeyManager and BlockUtils in its own copy
+func NewLeanHelix(callerConfig *interfaces.Config, onCommitCallback interfaces.OnCommitCallback, onNewConsensusRoundCallback interfaces.OnNewConsensusRoundCallback) *MainLoop {
+	configCopy := *callerConfig
+	config := &configCopy
 
 	var electionTrigger interfaces.ElectionScheduler
 
@@ -52,6 +75,33 @@ func NewLeanHelix(config *interfaces.Config, onCommitCallback interfaces.OnCommi
 		electionTrigger = Electiontrigger.NewTimerBasedElectionTrigger(config.ElectionTimeoutOnV0, config.OnElectionCB)
 	}
 
+	var verificationCache *verificationcache.VerificationCache
+	if config.VerificationCacheSize > 0 || config.VerificationWorkers > 0 { // the verification pipeline marks messages as authenticated through the cache
+		if cache, ok := config.KeyManager.(*verificationcache.VerificationCache); ok {
+			verificationCache = cache
+		} else {
+			cacheSize := int(config.VerificationCacheSize)
+			if cacheSize == 0 {
+				cacheSize = verificationcache.DEFAULT_CAPACITY
+			}
+			verificationCache = verificationcache.NewVerificationCache(config.KeyManager, cacheSize)
+			config.KeyManager = verificationCache
+		}
+	}
+
+	if _, ok := config.BlockUtils.(interfaces.PipelinedBlockUtils); config.PipelinedProposals && !ok {
+		logger := log.GetLogger().WithTags(log.Node(config.InstanceId.String()), log.String("event_loop", "LHBlockProposalPipeline"))
+		config.BlockUtils = NewBlockProposalPipeline(config.BlockUtils, GovnrErrorer(logger))
+	}
+
+	var blockProposalDeadline *BlockProposalDeadline
+	if config.ProposalTimeout > 0 { // wraps the pipeline, so only a leader waiting for its proposal is bounded by the deadline, not blocks built ahead
+		logger := log.GetLogger().WithTags(log.Node(config.InstanceId.String()), log.String("event_loop", "LHBlockProposalDeadline"))
+		blockProposalDeadline = NewBlockProposalDeadline(config.BlockUtils, config.ProposalTimeout, GovnrErrorer(logger))
+		config.BlockUtils = blockProposalDeadline.blockUtils()
+	}
+
+	viewChangeRequests := make(chan *state.HeightView)
 	state := state.NewState()
 
 	return &MainLoop{
@@ -60,9 +110,14 @@ func NewLeanHelix(config *interfaces.Config, onCommitCallback interfaces.OnCommi
 		onNewConsensusRoundCallback: onNewConsensusRoundCallback,
 		messagesChannel:             make(chan *interfaces.ConsensusRawMessage),
 		mainUpdateStateChannel:      make(chan *blockWithProof),
+		viewChangeRequests:          viewChangeRequests,
 		electionScheduler:           electionTrigger,
 		state:                       state,
 		logger:                      L.NewLhLogger(config, state),
+		verificationCache:           verificationCache,
+		blockProposalDeadline:       blockProposalDeadline,
+		participation:               &participation{},
+		publisher:                   events.NewPublisher(),
 	}
 }
 
@@ -77,6 +132,16 @@ func (m *MainLoop) Run(ctx context.Context) govnr.ShutdownWaiter {
 		m.onCommitCallback,
 		m.onNewConsensusRoundCallback)
 
+	m.worker.verificationCache = m.verificationCache
+	m.worker.blockProposalDeadline = m.blockProposalDeadline
+	m.worker.publisher = m.publisher
+
+	if m.config.VerificationWorkers > 0 {
+		m.verificationPipeline = NewVerificationPipeline(int(m.config.VerificationWorkers), m.verificationCache, m.config, m.logger, m.worker.MessagesQueue)
+		m.worker.verificationPipeline = m.verificationPipeline
+		m.runVerificationPipeline(ctx)
+	}
+
 	m.Supervise(m.runMainLoop(ctx))
 
 	logger := log.GetLogger().WithTags(log.Node(m.config.InstanceId.String()), log.String("event_loop", "LHWorker"))
@@ -96,6 +161,16 @@ func (m *MainLoop) runMainLoop(ctx context.Context) *govnr.ForeverHandle {
 	})
 }
 
+func (m *MainLoop) runVerificationPipeline(ctx context.Context) {
+	logger := log.GetLogger().WithTags(log.Node(m.config.InstanceId.String()), log.String("event_loop", "LHVerification"))
+	for i := 0; i < m.verificationPipeline.Workers(); i++ {
+		shard := i
+		m.Supervise(govnr.Forever(ctx, fmt.Sprintf("lh-verification-%d", shard), GovnrErrorer(logger), func() {
+			m.verificationPipeline.runShard(ctx, shard)
+		}))
+	}
+}
+
 func (m *MainLoop) run(ctx context.Context) {
 	defer m.worker.interrupt()
 
@@ -109,32 +184,39 @@ func (m *MainLoop) run(ctx context.Context) {
 	var shutdown bool
 	for !shutdown {
 		m.state.GcOldContexts()
+		if m.verificationCache != nil {
+			m.verificationCache.ClearOlderThan(m.state.Height())
+		}
 		select {
 		case <-ctx.Done(): // system shutdown
 			shutdown = true
 
 		case message := <-m.messagesChannel:
 			parsedMessage := interfaces.ToConsensusMessage(message)
+			if parsedMessage == nil {
+				m.logger.Info("LHFLOW LHMSG MAINLOOP RECEIVED MALFORMED MESSAGE, DROPPING")
+				m.config.OnMessageRejectedCB.Reject(message, errors.Wrap(interfaces.ErrMalformedMessage, "unknown message type"))
+				continue
+			}
 
 			m.logger.Debug("LHFLOW LHMSG MAINLOOP RECEIVED %v from %v for H=%d V=%d", parsedMessage.MessageType(), parsedMessage.SenderMemberId(), parsedMessage.BlockHeight(), parsedMessage.View())
 
-			select {
-			default: // never block the main loop
-			case <-ctx.Done(): // here for uniformity, made redundant by default:
-			case m.worker.MessagesChannel <- message:
+			if m.verificationPipeline != nil {
+				m.verificationPipeline.Submit(ctx, message, parsedMessage.SenderMemberId())
+				continue
 			}
 
+			m.worker.MessagesQueue.Push(message) // never blocks the main loop
+
 		case trigger := <-m.electionScheduler.ElectionChannel():
-			targetHv := state.NewHeightView(trigger.Hv.Height(), trigger.Hv.View()+1)
-			m.state.Contexts.CancelOlderThan(targetHv)
-			_, err := m.state.Contexts.For(targetHv)
-			if err != nil {
-				m.logger.Debug("LHFLOW LHMSG MAINLOOP - IGNORING ELECTION TRIGGER WITH %e", err)
-				continue
-			}
+			m.handleElectionTrigger(ctx, trigger)
 
-			m.logger.Debug("LHFLOW ELECTION MAINLOOP - CANCELED WORKER CONTEXT (received election trigger with H=%d V=%d)", trigger.Hv.Height(), trigger.Hv.View())
-			m.sendElectionMessageNonBlocking(ctx, trigger)
+		case hv := <-m.viewChangeRequests:
+			m.logger.Info("LHFLOW ELECTION MAINLOOP - VIEW CHANGE REQUESTED for H=%d V=%d", hv.Height(), hv.View())
+			m.handleElectionTrigger(ctx, &interfaces.ElectionTrigger{
+				MoveToNextLeader: func() { m.worker.requestViewChange(hv) }, // executed by the worker loop
+				Hv:               hv,
+			})
 
 		case receivedBlockWithProof := <-m.mainUpdateStateChannel: // NodeSync
 			if receivedBlockWithProof == nil {
@@ -181,6 +263,19 @@ func (m *MainLoop) run(ctx context.Context) {
 	m.logger.Info("LHFLOW LHMSG MAINLOOP DONE STOPPED LISTENING, SHUTDOWN END")
 }
 
+func (m *MainLoop) handleElectionTrigger(ctx context.Context, trigger *interfaces.ElectionTrigger) {
+	targetHv := state.NewHeightView(trigger.Hv.Height(), trigger.Hv.View()+1)
+	m.state.Contexts.CancelOlderThan(targetHv)
+	_, err := m.state.Contexts.For(targetHv)
+	if err != nil {
+		m.logger.Debug("LHFLOW LHMSG MAINLOOP - IGNORING ELECTION TRIGGER WITH %e", err)
+		return
+	}
+
+	m.logger.Debug("LHFLOW ELECTION MAINLOOP - CANCELED WORKER CONTEXT (received election trigger with H=%d V=%d)", trigger.Hv.Height(), trigger.Hv.View())
+	m.sendElectionMessageNonBlocking(ctx, trigger)
+}
+
 func (m *MainLoop) sendElectionMessageNonBlocking(ctx context.Context, trigger *interfaces.ElectionTrigger) {
 	elChannel := m.worker.electionChannel
 	bufferSize := cap(elChannel)
@@ -245,7 +340,11 @@ func (m *MainLoop) ValidateBlockConsensus(ctx context.Context, block interfaces.
 }
 
 // Called from outside to indicate Node Sync
+// Returns an error wrapping interfaces.ErrStaleHeight for a block below the current height, which would be ignored
 func (m *MainLoop) UpdateState(ctx context.Context, prevBlock interfaces.Block, prevBlockProofBytes []byte) error {
+	if blockHeight, height := blockheight.GetBlockHeight(prevBlock), m.state.Height(); blockHeight < height {
+		return errors.Wrapf(interfaces.ErrStaleHeight, "UpdateState() block of H=%d while current H=%d", blockHeight, height)
+	}
 
 	select {
 	case <-ctx.Done():
@@ -276,3 +375,96 @@ func (m *MainLoop) HandleConsensusMessage(ctx context.Context, message *interfac
 func (m *MainLoop) State() *state.State {
 	return m.state
 }
+
+// AuditCommittee recomputes the random seed, ordered committee and leaders of the height committed by blockProofBytes
+func (m *MainLoop) AuditCommittee(ctx context.Context, blockProofBytes []byte, prevBlock interfaces.Block, prevBlockProofBytes []byte) (*leanhelixterm.CommitteeAudit, error) {
+	return leanhelixterm.AuditCommittee(ctx, m.config, blockProofBytes, prevBlock, prevBlockProofBytes)
+}
+
+// Returns nil when the verification cache is disabled
+func (m *MainLoop) VerificationCacheMetrics() metrics.VerificationCacheMetrics {
+	if m.verificationCache == nil {
+		return nil
+	}
+	return m.verificationCache.Metrics()
+}
+
+// Returns nil before Run, or when Config.VerificationWorkers is 0
+func (m *MainLoop) VerificationPipelineMetrics() metrics.VerificationPipelineMetrics {
+	if m.verificationPipeline == nil {
+		return nil
+	}
+	return m.verificationPipeline.Metrics()
+}
+
+// Returns nil when Config.ProposalTimeout is not set
+func (m *MainLoop) BlockProposalMetrics() metrics.BlockProposalMetrics {
+	if m.blockProposalDeadline == nil {
+		return nil
+	}
+	return m.blockProposalDeadline.Metrics()
+}
+
+// UpdateConfig applies update between heights, once the current height ends, and reports which settings took effect.
+// An invalid update is rejected as a whole. If ctx is done first, the update may still take effect later on.
+func (m *MainLoop) UpdateConfig(ctx context.Context, update *interfaces.ConfigUpdate) (*interfaces.ConfigUpdateReport, error) {
+	if err := update.Validate(); err != nil {
+		return nil, errors.Wrap(err, "UpdateConfig: invalid update")
+	}
+	if m.worker == nil {
+		return nil, errors.New("UpdateConfig: called before Run")
+	}
+
+	request := &configUpdateRequest{
+		update: update,
+		report: make(chan *interfaces.ConfigUpdateReport, 1),
+	}
+	select {
+	case m.worker.configUpdates <- request:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	select {
+	case report := <-request.report:
+		return report, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// RequestViewChange moves this node to the next view without waiting for the election timeout of height and view.
+// The request takes the path of an election trigger, so it is dropped if the node has left height and view by the time it is handled.
+// Other members move along once they receive enough VIEW_CHANGE votes, so an operator usually requests it on all of them.
+func (m *MainLoop) RequestViewChange(ctx context.Context, height primitives.BlockHeight, view primitives.View) error {
+	if m.worker == nil {
+		return errors.New("RequestViewChange: called before Run")
+	}
+	if current := m.state.HeightView(); current.Height() != height || current.View() != view {
+		return errors.Errorf("RequestViewChange: H=%d V=%d is stale, current is %s", height, view, current)
+	}
+
+	select {
+	case m.viewChangeRequests <- state.NewHeightView(height, view):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Subscribe returns a subscription to the lifecycle events of this node, an event which does not fit bufLen is dropped
+func (m *MainLoop) Subscribe(bufLen int) *events.Subscription {
+	return m.publisher.Subscribe(bufLen)
+}
+
+func (m *MainLoop) Unsubscribe(subscription *events.Subscription) {
+	m.publisher.Unsubscribe(subscription)
+}
+
+// Returns nil before Run
+func (m *MainLoop) MessageQueueMetrics() metrics.MessageQueueMetrics {
+	if m.worker == nil {
+		return nil
+	}
+	return m.worker.MessagesQueue.Metrics()
+}
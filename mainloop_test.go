@@ -2,7 +2,14 @@ package leanhelix
 
 import (
 	"context"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/services/storage"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/orbs-network/lean-helix-go/test/builders"
 	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
 	"testing"
 	"time"
 )
@@ -26,3 +33,106 @@ func TestRunEndsAfterGoroutinesEnd(t *testing.T) {
 		t.Fatalf("system did not shut down in a timely manner")
 	}
 }
+
+func aValidConfig() *interfaces.Config {
+	memberId := []byte{30, 30, 30}
+	config := mocks.NewMockConfig(nil, 123, mocks.NewFakeMembership(memberId, nil, nil, false), mocks.NewMockBlockUtils(memberId, nil, nil), mocks.NewMockKeyManager(memberId), nil, mocks.NewCommunication(memberId, nil, nil))
+	config.ElectionTimeoutOnV0 = time.Second
+	return config
+}
+
+func TestNewValidatedLeanHelixAcceptsAValidConfig(t *testing.T) {
+	mainLoop, err := NewValidatedLeanHelix(aValidConfig(), nil, nil)
+	require.NoError(t, err)
+	require.NotNil(t, mainLoop)
+}
+
+func TestNewValidatedLeanHelixNamesTheInvalidField(t *testing.T) {
+	var nilBlockUtils *mocks.PausableBlockUtils
+	var nilStorage *storage.InMemoryStorage
+
+	for field, invalidate := range map[string]func(config *interfaces.Config){
+		"InstanceId":          func(config *interfaces.Config) { config.InstanceId = 0 },
+		"Communication":       func(config *interfaces.Config) { config.Communication = nil },
+		"Membership":          func(config *interfaces.Config) { config.Membership = nil },
+		"BlockUtils":          func(config *interfaces.Config) { config.BlockUtils = nilBlockUtils },
+		"ElectionTimeoutOnV0": func(config *interfaces.Config) { config.ElectionTimeoutOnV0 = 0 },
+		"ProposalTimeout":     func(config *interfaces.Config) { config.ProposalTimeout = -time.Second },
+		"ElectionChanBufLen":  func(config *interfaces.Config) { config.ElectionChanBufLen = interfaces.MAX_CHAN_BUF_LEN + 1 },
+		"BlockCodec":          func(config *interfaces.Config) { config.BlockCodec = mocks.NewMockBlockCodec() },
+		"Storage":             func(config *interfaces.Config) { config.Storage = nilStorage },
+	} {
+		config := aValidConfig()
+		invalidate(config)
+		mainLoop, err := NewValidatedLeanHelix(config, nil, nil)
+		require.Nil(t, mainLoop, field)
+		configErr, ok := err.(*interfaces.ConfigError)
+		require.True(t, ok, "expected a ConfigError for %s, got %v", field, err)
+		require.Equal(t, field, configErr.Field)
+	}
+}
+
+func TestNewLeanHelixDoesNotWrapTheKeyManagerOfTheCallersConfig(t *testing.T) {
+	config := aValidConfig()
+	config.VerificationCacheSize = 10
+	keyManager := config.KeyManager
+
+	NewLeanHelix(config, nil, nil)
+	NewLeanHelix(config, nil, nil)
+
+	require.True(t, keyManager == config.KeyManager, "KeyManager should not be wrapped in the caller's config")
+}
+
+func TestNewLeanHelixDoesNotWrapTheBlockUtilsOfTheCallersConfigInAPipeline(t *testing.T) {
+	config := aValidConfig()
+	config.PipelinedProposals = true
+	blockUtils := config.BlockUtils
+
+	NewLeanHelix(config, nil, nil)
+	NewLeanHelix(config, nil, nil)
+
+	require.True(t, blockUtils == config.BlockUtils, "BlockUtils should not be wrapped in the caller's config")
+}
+
+func TestNewLeanHelixDoesNotWrapTheBlockUtilsOfTheCallersConfigInADeadline(t *testing.T) {
+	config := aValidConfig()
+	config.ProposalTimeout = time.Second
+	blockUtils := config.BlockUtils
+
+	NewLeanHelix(config, nil, nil)
+	NewLeanHelix(config, nil, nil)
+
+	require.True(t, blockUtils == config.BlockUtils, "BlockUtils should not be wrapped in the caller's config")
+}
+
+func TestRejectedMessagesAreReportedWithTheirReason(t *testing.T) {
+	test.WithContextWithTimeout(t, 5*time.Second, func(ctx context.Context) {
+		rejections := make(chan error, 10)
+		config := aValidConfig()
+		config.OnMessageRejectedCB = func(message *interfaces.ConsensusRawMessage, err error) {
+			rejections <- err
+		}
+		mainLoop := NewLeanHelix(config, nil, nil)
+		mainLoop.Run(ctx)
+
+		mainLoop.HandleConsensusMessage(ctx, &interfaces.ConsensusRawMessage{Content: []byte{1, 2, 3}})
+		require.Equal(t, interfaces.ErrMalformedMessage, errors.Cause(<-rejections))
+
+		sender := primitives.MemberId{40, 40, 40}
+		otherInstance := builders.APrepareMessage(config.InstanceId+1, mocks.NewMockKeyManager(sender), sender, 0, 0, mocks.ABlock(interfaces.GenesisBlock))
+		mainLoop.HandleConsensusMessage(ctx, otherInstance.ToConsensusRawMessage())
+		err := <-rejections
+		require.Equal(t, interfaces.ErrWrongInstance, errors.Cause(err))
+		require.False(t, interfaces.IsPeerMisbehavior(err), "messages of another instance may be sent by honest peers")
+	})
+}
+
+func TestUpdateStateRejectsABlockBelowTheCurrentHeight(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		mainLoop := NewLeanHelix(aValidConfig(), nil, nil)
+		mainLoop.state.SetHeightAndResetView(2)
+
+		err := mainLoop.UpdateState(ctx, mocks.ABlock(interfaces.GenesisBlock), nil)
+		require.Equal(t, interfaces.ErrStaleHeight, errors.Cause(err))
+	})
+}
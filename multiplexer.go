@@ -0,0 +1,153 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package leanhelix
+
+import (
+	"context"
+	"fmt"
+	"github.com/orbs-network/govnr"
+	"github.com/orbs-network/lean-helix-go/instrumentation/metrics"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/services/messagequeue"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/scribe/log"
+	"github.com/pkg/errors"
+	"sync"
+)
+
+// Multiplexer routes the messages of one transport to the MainLoop of their instance id, so several virtual chains
+// run in one process. The instances may share their Communication, KeyManager and OnElectionCB, each keeps its own
+// Membership, BlockUtils and Storage.
+// Each instance has a queue of Config.MsgChanBufLen messages, drained into its MainLoop by a goroutine of its own,
+// so an instance which stalls drops its own messages instead of blocking the transport of the others.
+type Multiplexer struct {
+	govnr.TreeSupervisor
+	sync.RWMutex
+	instances       map[primitives.InstanceId]*instanceRoute
+	routed          map[primitives.InstanceId]uint64
+	dropped         map[primitives.InstanceId]uint64
+	unknownInstance uint64
+}
+
+type instanceRoute struct {
+	mainLoop *MainLoop
+	queue    chan *interfaces.ConsensusRawMessage
+	cancel   context.CancelFunc
+}
+
+func NewMultiplexer() *Multiplexer {
+	return &Multiplexer{
+		instances: make(map[primitives.InstanceId]*instanceRoute),
+		routed:    make(map[primitives.InstanceId]uint64),
+		dropped:   make(map[primitives.InstanceId]uint64),
+	}
+}
+
+// Add registers mainLoop under its Config.InstanceId and delivers its messages until ctx is done or it is removed,
+// running mainLoop is left to the caller
+func (mux *Multiplexer) Add(ctx context.Context, mainLoop *MainLoop) error {
+	mux.Lock()
+	defer mux.Unlock()
+
+	instanceId := mainLoop.config.InstanceId
+	if _, ok := mux.instances[instanceId]; ok {
+		return errors.Errorf("Multiplexer: instance id %s is already registered", instanceId)
+	}
+
+	queueLen := mainLoop.config.MsgChanBufLen
+	if queueLen == 0 {
+		queueLen = messagequeue.DEFAULT_CAPACITY
+	}
+	routeCtx, cancel := context.WithCancel(ctx)
+	route := &instanceRoute{
+		mainLoop: mainLoop,
+		queue:    make(chan *interfaces.ConsensusRawMessage, queueLen),
+		cancel:   cancel,
+	}
+	mux.instances[instanceId] = route
+	mux.routed[instanceId] = 0
+	mux.dropped[instanceId] = 0
+
+	logger := log.GetLogger().WithTags(log.Node(instanceId.String()), log.String("event_loop", "LHMultiplexer"))
+	mux.Supervise(govnr.Forever(routeCtx, fmt.Sprintf("lh-multiplexer-%s", instanceId), GovnrErrorer(logger), func() {
+		route.deliver(routeCtx)
+	}))
+	return nil
+}
+
+// Remove stops routing to an instance, its queued and later messages are dropped
+func (mux *Multiplexer) Remove(instanceId primitives.InstanceId) {
+	mux.Lock()
+	defer mux.Unlock()
+
+	if route, ok := mux.instances[instanceId]; ok {
+		route.cancel()
+	}
+	delete(mux.instances, instanceId)
+	delete(mux.routed, instanceId)
+	delete(mux.dropped, instanceId)
+}
+
+// Returns nil when no instance is registered under instanceId
+func (mux *Multiplexer) Instance(instanceId primitives.InstanceId) *MainLoop {
+	mux.RLock()
+	defer mux.RUnlock()
+
+	if route, ok := mux.instances[instanceId]; ok {
+		return route.mainLoop
+	}
+	return nil
+}
+
+// HandleConsensusMessage is registered with the shared transport in place of MainLoop.HandleConsensusMessage.
+// It never blocks, a message is dropped when the queue of its instance is full.
+func (mux *Multiplexer) HandleConsensusMessage(ctx context.Context, message *interfaces.ConsensusRawMessage) {
+	parsedMessage := interfaces.ToConsensusMessage(message)
+	var instanceId primitives.InstanceId
+	if parsedMessage != nil { // a malformed message is counted with those of unknown instances
+		instanceId = parsedMessage.InstanceId()
+	}
+
+	mux.Lock()
+	defer mux.Unlock()
+
+	route, ok := mux.instances[instanceId]
+	if !ok {
+		mux.unknownInstance++
+		return
+	}
+
+	select {
+	case route.queue <- message:
+		mux.routed[instanceId]++
+	default:
+		mux.dropped[instanceId]++
+	}
+}
+
+func (mux *Multiplexer) Metrics() metrics.MultiplexerMetrics {
+	mux.RLock()
+	defer mux.RUnlock()
+
+	routed := make(map[primitives.InstanceId]uint64, len(mux.routed))
+	for instanceId, count := range mux.routed {
+		routed[instanceId] = count
+	}
+	dropped := make(map[primitives.InstanceId]uint64, len(mux.dropped))
+	for instanceId, count := range mux.dropped {
+		dropped[instanceId] = count
+	}
+	return metrics.NewMultiplexerMetrics(routed, dropped, mux.unknownInstance)
+}
+
+func (route *instanceRoute) deliver(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+	case message := <-route.queue:
+		route.mainLoop.HandleConsensusMessage(ctx, message)
+	}
+}
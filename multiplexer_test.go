@@ -0,0 +1,86 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package leanhelix
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/orbs-network/lean-helix-go/test/builders"
+	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func aMainLoopOfInstance(instanceId primitives.InstanceId) *MainLoop {
+	config := aValidConfig()
+	config.InstanceId = instanceId
+	return NewLeanHelix(config, nil, nil)
+}
+
+func aPrepareOfInstance(instanceId primitives.InstanceId) *interfaces.ConsensusRawMessage {
+	sender := primitives.MemberId("sender")
+	return builders.APrepareMessage(instanceId, mocks.NewMockKeyManager(sender), sender, 1, 0, mocks.ABlock(interfaces.GenesisBlock)).ToConsensusRawMessage()
+}
+
+func TestMultiplexerRoutesMessagesByInstanceId(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		mux := NewMultiplexer()
+		for _, instanceId := range []primitives.InstanceId{1, 2} {
+			mainLoop := aMainLoopOfInstance(instanceId)
+			mainLoop.Run(ctx)
+			require.NoError(t, mux.Add(ctx, mainLoop))
+		}
+
+		mux.HandleConsensusMessage(ctx, aPrepareOfInstance(1))
+		mux.HandleConsensusMessage(ctx, aPrepareOfInstance(2))
+		mux.HandleConsensusMessage(ctx, aPrepareOfInstance(2))
+		mux.HandleConsensusMessage(ctx, aPrepareOfInstance(3))
+
+		metrics := mux.Metrics()
+		require.ElementsMatch(t, []primitives.InstanceId{1, 2}, metrics.Instances())
+		require.EqualValues(t, 1, metrics.Routed(1))
+		require.EqualValues(t, 2, metrics.Routed(2))
+		require.EqualValues(t, 1, metrics.UnknownInstance())
+	})
+}
+
+func TestMultiplexerRejectsADuplicateInstanceId(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		mux := NewMultiplexer()
+		require.NoError(t, mux.Add(ctx, aMainLoopOfInstance(1)))
+		require.Error(t, mux.Add(ctx, aMainLoopOfInstance(1)))
+
+		mux.Remove(1)
+		require.Nil(t, mux.Instance(1))
+		require.NoError(t, mux.Add(ctx, aMainLoopOfInstance(1)))
+	})
+}
+
+func TestMultiplexerDropsTheMessagesOfAStalledInstanceWithoutBlocking(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		mux := NewMultiplexer()
+		stalled := aMainLoopOfInstance(1) // never run, so it never drains its queue
+		stalled.config.MsgChanBufLen = 2
+		require.NoError(t, mux.Add(ctx, stalled))
+		running := aMainLoopOfInstance(2)
+		running.Run(ctx)
+		require.NoError(t, mux.Add(ctx, running))
+
+		for i := 0; i < 10; i++ {
+			mux.HandleConsensusMessage(ctx, aPrepareOfInstance(1))
+		}
+		mux.HandleConsensusMessage(ctx, aPrepareOfInstance(2))
+
+		metrics := mux.Metrics()
+		require.EqualValues(t, 10, metrics.Routed(1)+metrics.Dropped(1))
+		require.True(t, metrics.Dropped(1) >= 7, "at most one message is being delivered and two are queued")
+		require.EqualValues(t, 1, metrics.Routed(2))
+		require.Zero(t, metrics.Dropped(2))
+	})
+}
@@ -0,0 +1,76 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package leanhelix
+
+import (
+	"github.com/orbs-network/lean-helix-go/instrumentation/metrics"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"sync"
+	"time"
+)
+
+type Status struct {
+	Height primitives.BlockHeight
+	View   primitives.View
+	Paused bool
+}
+
+type participation struct {
+	sync.Mutex
+	pauses     uint64
+	pausedAt   time.Time
+	timePaused time.Duration
+}
+
+// Pause stops this node from signing and proposing, it keeps following heights from the messages of the other members.
+// Peers see a member which does not vote rather than a crashed node, so a paused leader's views end by election.
+func (m *MainLoop) Pause() {
+	m.participation.Lock()
+	defer m.participation.Unlock()
+
+	if m.state.Paused() {
+		return
+	}
+	m.state.SetPaused(true)
+	m.participation.pauses++
+	m.participation.pausedAt = time.Now()
+	m.logger.Info("LHFLOW PAUSE at %s, no longer signing or proposing", m.state.HeightView())
+}
+
+// Resume rejoins consensus at the current height, the node votes from its next message on
+func (m *MainLoop) Resume() {
+	m.participation.Lock()
+	defer m.participation.Unlock()
+
+	if !m.state.Paused() {
+		return
+	}
+	m.state.SetPaused(false)
+	m.participation.timePaused += time.Since(m.participation.pausedAt)
+	m.logger.Info("LHFLOW RESUME at %s", m.state.HeightView())
+}
+
+func (m *MainLoop) Status() *Status {
+	hv := m.state.HeightView()
+	return &Status{
+		Height: hv.Height(),
+		View:   hv.View(),
+		Paused: m.state.Paused(),
+	}
+}
+
+func (m *MainLoop) ParticipationMetrics() metrics.ParticipationMetrics {
+	m.participation.Lock()
+	defer m.participation.Unlock()
+
+	paused := m.state.Paused()
+	timePaused := m.participation.timePaused
+	if paused {
+		timePaused += time.Since(m.participation.pausedAt)
+	}
+	return metrics.NewParticipationMetrics(paused, m.participation.pauses, timePaused)
+}
@@ -0,0 +1,85 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package blockdissemination
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Number of chunks needed to rebuild a block in a committee of the given size.
+// An honest replica receives its own chunk from the leader and the chunks forwarded by the other members, which are at
+// least N-1-f when f members stay silent, so f+1 chunks rebuild the block without any help from faulty members.
+func DataChunksFor(committeeSize int) int {
+	f := (committeeSize - 1) / 3
+	return f + 1
+}
+
+type BlockChunks struct {
+	Root       []byte
+	Chunks     [][]byte
+	Proofs     [][][]byte
+	DataChunks int
+	Size       int
+}
+
+// Erasure codes an encoded block into one chunk per committee member, each with its proof against the chunks root
+func SplitBlock(encodedBlock []byte, committeeSize int) (*BlockChunks, error) {
+	dataChunks := DataChunksFor(committeeSize)
+	chunks, err := EncodeChunks(encodedBlock, dataChunks, committeeSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "SplitBlock")
+	}
+
+	proofs := make([][][]byte, len(chunks))
+	for i := range chunks {
+		proofs[i] = MerkleProof(chunks, i)
+	}
+	return &BlockChunks{
+		Root:       MerkleRoot(chunks),
+		Chunks:     chunks,
+		Proofs:     proofs,
+		DataChunks: dataChunks,
+		Size:       len(encodedBlock),
+	}, nil
+}
+
+// Collects verified chunks of one block until there are enough of them to rebuild it
+type ChunksCollector struct {
+	dataChunks int
+	size       int
+	chunks     [][]byte
+	count      int
+	done       bool
+}
+
+func NewChunksCollector(dataChunks int, totalChunks int, size int) *ChunksCollector {
+	return &ChunksCollector{
+		dataChunks: dataChunks,
+		size:       size,
+		chunks:     make([][]byte, totalChunks),
+	}
+}
+
+// Returns whether the chunk was added, duplicates and chunks arriving after the block was rebuilt are not
+func (c *ChunksCollector) Add(index int, chunk []byte) bool {
+	if c.done || index < 0 || index >= len(c.chunks) || c.chunks[index] != nil {
+		return false
+	}
+	c.chunks[index] = chunk
+	c.count++
+	return true
+}
+
+func (c *ChunksCollector) CanRebuild() bool {
+	return !c.done && c.count >= c.dataChunks
+}
+
+// Rebuilds the encoded block, the collector is done afterwards whether or not it succeeded
+func (c *ChunksCollector) Rebuild() ([]byte, error) {
+	c.done = true
+	return DecodeChunks(c.chunks, c.dataChunks, c.size)
+}
@@ -0,0 +1,101 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package blockdissemination
+
+import (
+	"bytes"
+	"crypto/sha256"
+)
+
+// Leaves and inner nodes are hashed with different prefixes, so an inner node cannot pose as a leaf
+const leafPrefix = 0
+const nodePrefix = 1
+
+func hashLeaf(leaf []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{leafPrefix})
+	h.Write(leaf)
+	return h.Sum(nil)
+}
+
+func hashNode(left []byte, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{nodePrefix})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+// Hashes one level of the tree into the next, a node without a sibling is promoted as is
+func nextLevel(level [][]byte) [][]byte {
+	next := make([][]byte, 0, (len(level)+1)/2)
+	for i := 0; i < len(level); i += 2 {
+		if i+1 < len(level) {
+			next = append(next, hashNode(level[i], level[i+1]))
+		} else {
+			next = append(next, level[i])
+		}
+	}
+	return next
+}
+
+func leavesLevel(leaves [][]byte) [][]byte {
+	level := make([][]byte, len(leaves))
+	for i, leaf := range leaves {
+		level[i] = hashLeaf(leaf)
+	}
+	return level
+}
+
+func MerkleRoot(leaves [][]byte) []byte {
+	if len(leaves) == 0 {
+		return nil
+	}
+	level := leavesLevel(leaves)
+	for len(level) > 1 {
+		level = nextLevel(level)
+	}
+	return level[0]
+}
+
+// Sibling hashes from the leaf at index up to the root
+func MerkleProof(leaves [][]byte, index int) [][]byte {
+	var proof [][]byte
+	level := leavesLevel(leaves)
+	for len(level) > 1 {
+		sibling := index ^ 1
+		if sibling < len(level) {
+			proof = append(proof, level[sibling])
+		}
+		level = nextLevel(level)
+		index /= 2
+	}
+	return proof
+}
+
+func VerifyMerkleProof(root []byte, leaf []byte, index int, totalLeaves int, proof [][]byte) bool {
+	if index < 0 || index >= totalLeaves {
+		return false
+	}
+	hash := hashLeaf(leaf)
+	for levelSize := totalLeaves; levelSize > 1; levelSize = (levelSize + 1) / 2 {
+		sibling := index ^ 1
+		if sibling < levelSize {
+			if len(proof) == 0 {
+				return false
+			}
+			if index%2 == 0 {
+				hash = hashNode(hash, proof[0])
+			} else {
+				hash = hashNode(proof[0], hash)
+			}
+			proof = proof[1:]
+		}
+		index /= 2
+	}
+	return len(proof) == 0 && bytes.Equal(hash, root)
+}
@@ -0,0 +1,179 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package blockdissemination
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Chunks are indexed by field elements of GF(256)
+const MaxTotalChunks = 256
+
+// GF(256) with the primitive polynomial x^8+x^4+x^3+x^2+1
+var gfExp [2 * MaxTotalChunks]byte
+var gfLog [MaxTotalChunks]byte
+
+func init() {
+	x := 1
+	for i := 0; i < MaxTotalChunks-1; i++ {
+		gfExp[i] = byte(x)
+		gfLog[x] = byte(i)
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11d
+		}
+	}
+	for i := MaxTotalChunks - 1; i < len(gfExp); i++ {
+		gfExp[i] = gfExp[i-(MaxTotalChunks-1)]
+	}
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+func gfInv(a byte) byte {
+	return gfExp[MaxTotalChunks-1-int(gfLog[a])]
+}
+
+// Row of the systematic generator matrix [I; C] for the chunk at index.
+// C is a Cauchy matrix, so any dataChunks rows of the generator are linearly independent.
+func generatorRow(index int, dataChunks int) []byte {
+	row := make([]byte, dataChunks)
+	if index < dataChunks {
+		row[index] = 1
+		return row
+	}
+	for j := range row {
+		row[j] = gfInv(byte(index) ^ byte(j))
+	}
+	return row
+}
+
+// Encodes data into totalChunks chunks of equal size, the first dataChunks of them hold the data itself.
+// Any dataChunks of the chunks are enough to decode the data.
+func EncodeChunks(data []byte, dataChunks int, totalChunks int) ([][]byte, error) {
+	if dataChunks < 1 || dataChunks > totalChunks || totalChunks > MaxTotalChunks {
+		return nil, errors.Errorf("cannot encode into %d data chunks out of %d", dataChunks, totalChunks)
+	}
+
+	chunkSize := (len(data) + dataChunks - 1) / dataChunks
+	if chunkSize == 0 {
+		chunkSize = 1
+	}
+	padded := make([]byte, chunkSize*dataChunks)
+	copy(padded, data)
+
+	chunks := make([][]byte, totalChunks)
+	for i := 0; i < dataChunks; i++ {
+		chunks[i] = padded[i*chunkSize : (i+1)*chunkSize]
+	}
+	for i := dataChunks; i < totalChunks; i++ {
+		chunks[i] = combine(generatorRow(i, dataChunks), chunks[:dataChunks], chunkSize)
+	}
+	return chunks, nil
+}
+
+// Decodes the data of the given size from chunks, in which missing chunks are nil
+func DecodeChunks(chunks [][]byte, dataChunks int, size int) ([]byte, error) {
+	indices := make([]int, 0, dataChunks)
+	for i, chunk := range chunks {
+		if chunk != nil {
+			indices = append(indices, i)
+		}
+		if len(indices) == dataChunks {
+			break
+		}
+	}
+	if len(indices) < dataChunks {
+		return nil, errors.Errorf("got %d chunks but %d are needed", len(indices), dataChunks)
+	}
+
+	chunkSize := len(chunks[indices[0]])
+	if size > chunkSize*dataChunks {
+		return nil, errors.Errorf("size %d is larger than %d data chunks of %d bytes", size, dataChunks, chunkSize)
+	}
+	matrix := make([][]byte, dataChunks)
+	present := make([][]byte, dataChunks)
+	for r, index := range indices {
+		if len(chunks[index]) != chunkSize {
+			return nil, errors.Errorf("chunk %d has %d bytes while chunk %d has %d", index, len(chunks[index]), indices[0], chunkSize)
+		}
+		matrix[r] = generatorRow(index, dataChunks)
+		present[r] = chunks[index]
+	}
+
+	inverse, err := invert(matrix)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 0, chunkSize*dataChunks)
+	for j := 0; j < dataChunks; j++ {
+		data = append(data, combine(inverse[j], present, chunkSize)...)
+	}
+	return data[:size], nil
+}
+
+// Linear combination of chunks with the given coefficients
+func combine(coefficients []byte, chunks [][]byte, chunkSize int) []byte {
+	res := make([]byte, chunkSize)
+	for j, coefficient := range coefficients {
+		if coefficient == 0 {
+			continue
+		}
+		for b, value := range chunks[j] {
+			res[b] ^= gfMul(coefficient, value)
+		}
+	}
+	return res
+}
+
+// Gauss-Jordan elimination over GF(256)
+func invert(matrix [][]byte) ([][]byte, error) {
+	n := len(matrix)
+	work := make([][]byte, n)
+	for i := range matrix {
+		work[i] = make([]byte, 2*n)
+		copy(work[i], matrix[i])
+		work[i][n+i] = 1
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for pivot < n && work[pivot][col] == 0 {
+			pivot++
+		}
+		if pivot == n {
+			return nil, errors.New("chunks generator matrix is singular")
+		}
+		work[col], work[pivot] = work[pivot], work[col]
+
+		scale := gfInv(work[col][col])
+		for c := range work[col] {
+			work[col][c] = gfMul(work[col][c], scale)
+		}
+		for r := 0; r < n; r++ {
+			if r == col || work[r][col] == 0 {
+				continue
+			}
+			factor := work[r][col]
+			for c := range work[r] {
+				work[r][c] ^= gfMul(factor, work[col][c])
+			}
+		}
+	}
+
+	inverse := make([][]byte, n)
+	for i := range work {
+		inverse[i] = work[i][n:]
+	}
+	return inverse, nil
+}
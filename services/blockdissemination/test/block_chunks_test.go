@@ -0,0 +1,93 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package test
+
+import (
+	"bytes"
+	"github.com/orbs-network/lean-helix-go/services/blockdissemination"
+	"github.com/stretchr/testify/require"
+	"math/rand"
+	"testing"
+)
+
+func randomBytes(size int) []byte {
+	data := make([]byte, size)
+	rand.Read(data)
+	return data
+}
+
+func TestDataChunksAreFPlusOne(t *testing.T) {
+	require.Equal(t, 1, blockdissemination.DataChunksFor(1))
+	require.Equal(t, 1, blockdissemination.DataChunksFor(2))
+	require.Equal(t, 1, blockdissemination.DataChunksFor(3))
+	require.Equal(t, 2, blockdissemination.DataChunksFor(4))
+	require.Equal(t, 3, blockdissemination.DataChunksFor(7))
+	require.Equal(t, 8, blockdissemination.DataChunksFor(22))
+}
+
+func TestAReplicaGetsEnoughChunksWhenFMembersAreSilent(t *testing.T) {
+	for committeeSize := 2; committeeSize <= 100; committeeSize++ {
+		f := (committeeSize - 1) / 3
+		received := committeeSize - 1 - f // its own chunk from the leader and those forwarded by the other members
+		require.True(t, received >= blockdissemination.DataChunksFor(committeeSize), "committee of %d", committeeSize)
+	}
+}
+
+func TestBlockIsRebuiltFromAnyDataChunksSubset(t *testing.T) {
+	for _, committeeSize := range []int{2, 4, 7, 22} {
+		for _, size := range []int{0, 1, 100, 4099} {
+			encodedBlock := randomBytes(size)
+			blockChunks, err := blockdissemination.SplitBlock(encodedBlock, committeeSize)
+			require.NoError(t, err)
+			require.Len(t, blockChunks.Chunks, committeeSize)
+
+			for attempt := 0; attempt < 5; attempt++ {
+				collector := blockdissemination.NewChunksCollector(blockChunks.DataChunks, committeeSize, blockChunks.Size)
+				for _, index := range rand.Perm(committeeSize)[:blockChunks.DataChunks] {
+					require.False(t, collector.CanRebuild())
+					require.True(t, collector.Add(index, blockChunks.Chunks[index]))
+				}
+				require.True(t, collector.CanRebuild())
+
+				rebuilt, err := collector.Rebuild()
+				require.NoError(t, err)
+				require.Equal(t, encodedBlock, rebuilt, "committee of %d, block of %d bytes", committeeSize, size)
+				require.False(t, collector.Add(0, blockChunks.Chunks[0]), "should not collect chunks after rebuilding")
+			}
+		}
+	}
+}
+
+func TestBlockIsNotRebuiltFromTooFewChunks(t *testing.T) {
+	blockChunks, err := blockdissemination.SplitBlock(randomBytes(1000), 7)
+	require.NoError(t, err)
+
+	chunks := make([][]byte, 7)
+	for i := 0; i < blockChunks.DataChunks-1; i++ {
+		chunks[i+2] = blockChunks.Chunks[i+2]
+	}
+	_, err = blockdissemination.DecodeChunks(chunks, blockChunks.DataChunks, blockChunks.Size)
+	require.Error(t, err)
+}
+
+func TestChunksAreProvenAgainstTheirRoot(t *testing.T) {
+	for _, committeeSize := range []int{1, 2, 3, 4, 5, 7, 22} {
+		blockChunks, err := blockdissemination.SplitBlock(randomBytes(500), committeeSize)
+		require.NoError(t, err)
+
+		for i, chunk := range blockChunks.Chunks {
+			require.True(t, blockdissemination.VerifyMerkleProof(blockChunks.Root, chunk, i, committeeSize, blockChunks.Proofs[i]), "chunk %d of %d", i, committeeSize)
+			other := (i + 1) % committeeSize
+			if !bytes.Equal(chunk, blockChunks.Chunks[other]) { // with a single data chunk all chunks are copies of the block
+				require.False(t, blockdissemination.VerifyMerkleProof(blockChunks.Root, chunk, other, committeeSize, blockChunks.Proofs[i]), "chunk %d should not be proven at another index", i)
+			}
+			tampered := append([]byte{}, chunk...)
+			tampered[0] ^= 1
+			require.False(t, blockdissemination.VerifyMerkleProof(blockChunks.Root, tampered, i, committeeSize, blockChunks.Proofs[i]), "a tampered chunk should not be proven")
+		}
+	}
+}
@@ -13,7 +13,7 @@ import (
 )
 
 // assume commit messages are valid and still hold
-func GenerateLeanHelixBlockProof(keyManager interfaces.KeyManager, commitMessages []*interfaces.CommitMessage) *protocol.BlockProof {
+func GenerateLeanHelixBlockProof(beacon interfaces.RandomnessBeacon, randomSeed uint64, commitMessages []*interfaces.CommitMessage) *protocol.BlockProof {
 	blockHeight := commitMessages[0].BlockHeight()
 	blockRefBuilder := &protocol.BlockRefBuilder{
 		MessageType: protocol.LEAN_HELIX_COMMIT,
@@ -38,7 +38,7 @@ func GenerateLeanHelixBlockProof(keyManager interfaces.KeyManager, commitMessage
 		}).Build())
 	}
 
-	randomSeedSignature := keyManager.AggregateRandomSeed(blockHeight, cShares)
+	randomSeedSignature := beacon.AggregateShares(blockHeight, randomSeed, cShares)
 	return (&protocol.BlockProofBuilder{
 		BlockRef:            blockRefBuilder,
 		Nodes:               cSendersBuilders,
@@ -10,6 +10,7 @@ import (
 	"context"
 	"github.com/orbs-network/lean-helix-go/services/blockproof"
 	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/services/randomseed"
 	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
 	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
 	"github.com/orbs-network/lean-helix-go/test"
@@ -47,7 +48,7 @@ func TestGeneratingBlockProof(t *testing.T) {
 
 	commitMessages := []*interfaces.CommitMessage{cm0, cm1, cm2, cm3}
 
-	blockProof := blockproof.GenerateLeanHelixBlockProof(node1KeyManager, commitMessages)
+	blockProof := blockproof.GenerateLeanHelixBlockProof(randomseed.NewKeyManagerRandomnessBeacon(node1KeyManager), 0, commitMessages)
 
 	// BlockRef
 	blockRef := blockProof.BlockRef()
@@ -95,7 +96,7 @@ func genBlockProofMessages(instanceId primitives.InstanceId, block interfaces.Bl
 		commitMessages = append(commitMessages, cm)
 	}
 
-	return blockproof.GenerateLeanHelixBlockProof(nodes[0].KeyManager, commitMessages)
+	return blockproof.GenerateLeanHelixBlockProof(randomseed.NewKeyManagerRandomnessBeacon(nodes[0].KeyManager), randomSeed, commitMessages)
 }
 
 func TestAValidBlockProof(t *testing.T) {
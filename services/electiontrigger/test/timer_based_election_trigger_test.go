@@ -223,3 +223,15 @@ func TestElectionTrigger_StuckOnTimerTimeout_GetsReleasedByStop(t *testing.T) {
 		}
 	})
 }
+
+func TestSetTimeoutsChangesTheTimeoutOfTheNextViews(t *testing.T) {
+	et := Electiontrigger.NewTimerBasedElectionTrigger(100*time.Millisecond, nil)
+	require.Equal(t, 100*time.Millisecond, et.CalcTimeout(0))
+
+	et.SetTimeouts(time.Second, 3)
+	minTimeout, expBase := et.Timeouts()
+	require.Equal(t, time.Second, minTimeout)
+	require.Equal(t, 3.0, expBase)
+	require.Equal(t, time.Second, et.CalcTimeout(0))
+	require.Equal(t, 9*time.Second, et.CalcTimeout(2))
+}
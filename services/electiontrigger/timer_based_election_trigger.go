@@ -19,7 +19,6 @@ var TIMEOUT_EXP_BASE = 2.0
 
 type TimerBasedElectionTrigger struct {
 	electionChannel  chan *interfaces.ElectionTrigger
-	minTimeout       time.Duration
 	electionHandler  func(blockHeight primitives.BlockHeight, view primitives.View, onElectionCB interfaces.OnElectionCallback)
 	callbackFromOrbs interfaces.OnElectionCallback
 	timer            *time.Timer
@@ -29,12 +28,17 @@ type TimerBasedElectionTrigger struct {
 	blockHeight      primitives.BlockHeight
 	view             primitives.View
 	triggerCancelled chan struct{}
+
+	timeoutsLock sync.Mutex
+	minTimeout   time.Duration
+	expBase      float64
 }
 
 func NewTimerBasedElectionTrigger(minTimeout time.Duration, callbackFromOrbs interfaces.OnElectionCallback) *TimerBasedElectionTrigger {
 	return &TimerBasedElectionTrigger{
 		electionChannel:  make(chan *interfaces.ElectionTrigger), // Caution - keep 0 to make election channel blocking
 		minTimeout:       minTimeout,
+		expBase:          TIMEOUT_EXP_BASE,
 		callbackFromOrbs: callbackFromOrbs,
 	}
 }
@@ -86,10 +90,26 @@ func (t *TimerBasedElectionTrigger) Stop() {
 }
 
 func (t *TimerBasedElectionTrigger) CalcTimeout(view primitives.View) time.Duration {
-	timeoutMultiplier := time.Duration(int64(math.Pow(TIMEOUT_EXP_BASE, float64(view))))
+	t.timeoutsLock.Lock()
+	defer t.timeoutsLock.Unlock()
+	timeoutMultiplier := time.Duration(int64(math.Pow(t.expBase, float64(view))))
 	return timeoutMultiplier * t.minTimeout
 }
 
+// Takes effect from the next registered view
+func (t *TimerBasedElectionTrigger) SetTimeouts(minTimeout time.Duration, expBase float64) {
+	t.timeoutsLock.Lock()
+	defer t.timeoutsLock.Unlock()
+	t.minTimeout = minTimeout
+	t.expBase = expBase
+}
+
+func (t *TimerBasedElectionTrigger) Timeouts() (minTimeout time.Duration, expBase float64) {
+	t.timeoutsLock.Lock()
+	defer t.timeoutsLock.Unlock()
+	return t.minTimeout, t.expBase
+}
+
 func triggerElections(electionChannel chan *interfaces.ElectionTrigger, height primitives.BlockHeight, view primitives.View, triggerCancelled chan struct{}, electionsFunc func()) {
 	select {
 	case <-triggerCancelled:
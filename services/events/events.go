@@ -0,0 +1,141 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package events
+
+import (
+	"fmt"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"sync"
+)
+
+type EventType int
+
+const (
+	TERM_STARTED      EventType = iota + 1 // with the committee of the term
+	OUT_OF_COMMITTEE                       // with the committee of the term
+	PROPOSAL_SENT                          // PREPREPARE or NEW_VIEW, as the leader of the view
+	PROPOSAL_RECEIVED                      // a PREPREPARE which passed its checks, before its block is validated
+	PREPARED_LOCALLY
+	COMMITTED
+	VIEW_CHANGE_SENT
+	ELECTED_AS_LEADER
+	NEW_VIEW_ACCEPTED
+	SYNCED                     // a block passed to MainLoop.UpdateState started the next height
+	COMMIT_CERTIFICATE_APPLIED // a COMMIT_CERTIFICATE of the current height committed its block and started the next height
+)
+
+func (t EventType) String() string {
+	switch t {
+	case TERM_STARTED:
+		return "TERM_STARTED"
+	case OUT_OF_COMMITTEE:
+		return "OUT_OF_COMMITTEE"
+	case PROPOSAL_SENT:
+		return "PROPOSAL_SENT"
+	case PROPOSAL_RECEIVED:
+		return "PROPOSAL_RECEIVED"
+	case PREPARED_LOCALLY:
+		return "PREPARED_LOCALLY"
+	case COMMITTED:
+		return "COMMITTED"
+	case VIEW_CHANGE_SENT:
+		return "VIEW_CHANGE_SENT"
+	case ELECTED_AS_LEADER:
+		return "ELECTED_AS_LEADER"
+	case NEW_VIEW_ACCEPTED:
+		return "NEW_VIEW_ACCEPTED"
+	case SYNCED:
+		return "SYNCED"
+	case COMMIT_CERTIFICATE_APPLIED:
+		return "COMMIT_CERTIFICATE_APPLIED"
+	}
+	return fmt.Sprintf("EventType(%d)", int(t))
+}
+
+// Event is published on the worker loop, BlockHash and Committee are only set where they apply
+type Event struct {
+	Type      EventType
+	Height    primitives.BlockHeight
+	View      primitives.View
+	BlockHash primitives.BlockHash
+	Committee []primitives.MemberId
+}
+
+type Subscription struct {
+	events  chan *Event
+	lock    sync.Mutex
+	dropped uint64
+}
+
+// Events is closed by Unsubscribe
+func (s *Subscription) Events() <-chan *Event {
+	return s.events
+}
+
+// Dropped counts the events which did not fit the buffer of the subscription
+func (s *Subscription) Dropped() uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.dropped
+}
+
+// Publisher never blocks on a slow subscriber, an event which does not fit the subscriber's buffer is dropped.
+// A nil *Publisher publishes nothing.
+type Publisher struct {
+	sync.RWMutex
+	subscriptions map[*Subscription]bool
+}
+
+func NewPublisher() *Publisher {
+	return &Publisher{
+		subscriptions: make(map[*Subscription]bool),
+	}
+}
+
+func (p *Publisher) Subscribe(bufLen int) *Subscription {
+	if bufLen < 1 {
+		bufLen = 1
+	}
+	s := &Subscription{
+		events: make(chan *Event, bufLen),
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	p.subscriptions[s] = true
+	return s
+}
+
+func (p *Publisher) Unsubscribe(s *Subscription) {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.subscriptions[s] {
+		delete(p.subscriptions, s)
+		close(s.events)
+	}
+}
+
+func (p *Publisher) Publish(event *Event) {
+	if p == nil {
+		return
+	}
+
+	p.RLock()
+	defer p.RUnlock()
+
+	for s := range p.subscriptions {
+		select {
+		case s.events <- event:
+		default:
+			s.lock.Lock()
+			s.dropped++
+			s.lock.Unlock()
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package test
+
+import (
+	"github.com/orbs-network/lean-helix-go/services/events"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestEverySubscriberReceivesThePublishedEvents(t *testing.T) {
+	p := events.NewPublisher()
+	s1 := p.Subscribe(10)
+	s2 := p.Subscribe(10)
+
+	p.Publish(&events.Event{Type: events.TERM_STARTED, Height: 1})
+	p.Publish(&events.Event{Type: events.COMMITTED, Height: 1})
+
+	for _, s := range []*events.Subscription{s1, s2} {
+		require.Equal(t, events.TERM_STARTED, (<-s.Events()).Type)
+		require.Equal(t, events.COMMITTED, (<-s.Events()).Type)
+	}
+}
+
+func TestEventsWhichDoNotFitTheBufferAreDropped(t *testing.T) {
+	p := events.NewPublisher()
+	slow := p.Subscribe(1)
+
+	p.Publish(&events.Event{Type: events.TERM_STARTED, Height: 1})
+	p.Publish(&events.Event{Type: events.COMMITTED, Height: 1})
+
+	require.EqualValues(t, 1, slow.Dropped())
+	require.Equal(t, events.TERM_STARTED, (<-slow.Events()).Type)
+}
+
+func TestUnsubscribeClosesTheEvents(t *testing.T) {
+	p := events.NewPublisher()
+	s := p.Subscribe(1)
+
+	p.Unsubscribe(s)
+	p.Unsubscribe(s)
+	p.Publish(&events.Event{Type: events.TERM_STARTED, Height: 1})
+
+	_, ok := <-s.Events()
+	require.False(t, ok)
+}
+
+func TestANilPublisherPublishesNothing(t *testing.T) {
+	var p *events.Publisher
+	require.NotPanics(t, func() {
+		p.Publish(&events.Event{Type: events.TERM_STARTED, Height: 1})
+	})
+}
@@ -0,0 +1,52 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package interfaces
+
+import (
+	"github.com/pkg/errors"
+	"time"
+)
+
+// ConfigUpdate holds the settings which can be changed on a running node, nil fields keep their current value.
+// An update takes effect when the next height starts, see MainLoop.UpdateConfig.
+type ConfigUpdate struct {
+	ElectionTimeoutOnV0    *time.Duration
+	ElectionTimeoutExpBase *float64 // backoff of the election timeout, which is ElectionTimeoutOnV0 * ElectionTimeoutExpBase^view
+	LogLevel               *int     // one of logger.LOG_LEVEL_DEBUG, LOG_LEVEL_INFO or LOG_LEVEL_ERROR
+	RetransmissionInterval *time.Duration
+	RebroadcastInterval    *time.Duration
+	VerificationCacheSize  *uint64
+	ProposalTimeout        *time.Duration
+}
+
+// ConfigUpdateReport names the settings of a ConfigUpdate which took effect, and why the others did not
+type ConfigUpdateReport struct {
+	Applied []string
+	Skipped map[string]string
+}
+
+func (u *ConfigUpdate) Validate() error {
+	if u.ElectionTimeoutOnV0 != nil && *u.ElectionTimeoutOnV0 <= 0 {
+		return errors.Errorf("ElectionTimeoutOnV0 must be positive, got %s", *u.ElectionTimeoutOnV0)
+	}
+	if u.ElectionTimeoutExpBase != nil && *u.ElectionTimeoutExpBase < 1 {
+		return errors.Errorf("ElectionTimeoutExpBase must be at least 1, got %f", *u.ElectionTimeoutExpBase)
+	}
+	if u.LogLevel != nil && (*u.LogLevel < 1 || *u.LogLevel > 3) {
+		return errors.Errorf("LogLevel must be between 1 and 3, got %d", *u.LogLevel)
+	}
+	if u.RetransmissionInterval != nil && *u.RetransmissionInterval < 0 {
+		return errors.Errorf("RetransmissionInterval must not be negative, got %s", *u.RetransmissionInterval)
+	}
+	if u.RebroadcastInterval != nil && *u.RebroadcastInterval < 0 {
+		return errors.Errorf("RebroadcastInterval must not be negative, got %s", *u.RebroadcastInterval)
+	}
+	if u.ProposalTimeout != nil && *u.ProposalTimeout < 0 {
+		return errors.Errorf("ProposalTimeout must not be negative, got %s", *u.ProposalTimeout)
+	}
+	return nil
+}
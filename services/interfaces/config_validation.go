@@ -0,0 +1,118 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package interfaces
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Upper bound of MsgChanBufLen, UpdateStateChanBufLen and ElectionChanBufLen, their buffers are allocated up front
+const MAX_CHAN_BUF_LEN = 1 << 20
+
+// ConfigError names the Config field which is invalid and why
+type ConfigError struct {
+	Field  string
+	Reason string
+}
+
+func (e *ConfigError) Error() string {
+	return fmt.Sprintf("invalid Config.%s: %s", e.Field, e.Reason)
+}
+
+// Validate returns a *ConfigError for the first field which would otherwise fail Lean Helix once it runs
+func (c *Config) Validate() error {
+	if c.InstanceId == 0 {
+		return &ConfigError{"InstanceId", "must be set, messages of other virtual chains are told apart by it"}
+	}
+
+	required := []struct {
+		field string
+		value interface{}
+	}{
+		{"Communication", c.Communication},
+		{"Membership", c.Membership},
+		{"BlockUtils", c.BlockUtils},
+		{"KeyManager", c.KeyManager},
+	}
+	for _, r := range required {
+		if isNil(r.value) {
+			return &ConfigError{r.field, "is required"}
+		}
+	}
+	if len(c.Membership.MyMemberId()) == 0 {
+		return &ConfigError{"Membership", "MyMemberId() is empty"}
+	}
+
+	optional := []struct {
+		field string
+		value interface{}
+	}{
+		{"Storage", c.Storage},
+		{"Logger", c.Logger},
+		{"OverrideElectionTrigger", c.OverrideElectionTrigger},
+		{"RandomnessBeacon", c.RandomnessBeacon},
+		{"BlockCodec", c.BlockCodec},
+	}
+	for _, o := range optional {
+		if o.value != nil && isNil(o.value) {
+			return &ConfigError{o.field, "holds a nil pointer, leave it unset to use the default"}
+		}
+	}
+
+	if c.OverrideElectionTrigger == nil && c.ElectionTimeoutOnV0 <= 0 {
+		return &ConfigError{"ElectionTimeoutOnV0", fmt.Sprintf("must be positive, got %s", c.ElectionTimeoutOnV0)}
+	}
+
+	intervals := []struct {
+		field string
+		value time.Duration
+	}{
+		{"RetransmissionInterval", c.RetransmissionInterval},
+		{"RebroadcastInterval", c.RebroadcastInterval},
+		{"ProposalTimeout", c.ProposalTimeout},
+	}
+	for _, i := range intervals {
+		if i.value < 0 {
+			return &ConfigError{i.field, fmt.Sprintf("must not be negative, got %s", i.value)}
+		}
+	}
+
+	bufLens := []struct {
+		field string
+		value uint64
+	}{
+		{"MsgChanBufLen", c.MsgChanBufLen},
+		{"UpdateStateChanBufLen", c.UpdateStateChanBufLen},
+		{"ElectionChanBufLen", c.ElectionChanBufLen},
+	}
+	for _, b := range bufLens {
+		if b.value > MAX_CHAN_BUF_LEN {
+			return &ConfigError{b.field, fmt.Sprintf("must be at most %d, got %d", MAX_CHAN_BUF_LEN, b.value)}
+		}
+	}
+
+	if c.BlockCodec != nil && !c.BlockDisseminationByHash {
+		return &ConfigError{"BlockCodec", "is only used with BlockDisseminationByHash"}
+	}
+
+	return nil
+}
+
+// A typed nil pointer in an interface field is not caught by comparing to nil
+func isNil(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
@@ -21,19 +21,33 @@ type OnNewConsensusRoundCallback func(ctx context.Context, newHeight primitives.
 type OnElectionCallback func(m metrics.ElectionMetrics)
 
 type Config struct {
-	InstanceId              primitives.InstanceId
-	Communication           Communication
-	Membership              Membership
-	BlockUtils              BlockUtils
-	KeyManager              KeyManager
-	ElectionTimeoutOnV0     time.Duration
-	OnElectionCB            OnElectionCallback
-	Storage                 Storage // optional
-	Logger                  Logger  // optional
-	MsgChanBufLen           uint64
-	UpdateStateChanBufLen   uint64
-	ElectionChanBufLen      uint64
-	OverrideElectionTrigger ElectionScheduler
+	InstanceId               primitives.InstanceId
+	Communication            Communication
+	Membership               Membership
+	BlockUtils               BlockUtils
+	KeyManager               KeyManager
+	ElectionTimeoutOnV0      time.Duration
+	OnElectionCB             OnElectionCallback
+	Storage                  Storage // optional
+	Logger                   Logger  // optional
+	MsgChanBufLen            uint64  // optional, capacity of the worker's message queue, defaults to 1000
+	UpdateStateChanBufLen    uint64  // optional, defaults to 1
+	ElectionChanBufLen       uint64  // optional, defaults to 1
+	OverrideElectionTrigger  ElectionScheduler
+	VerificationCacheSize    uint64                    // optional, 0 disables caching of signature verifications
+	VerificationWorkers      uint64                    // optional, 0 verifies signatures on the worker loop
+	RandomnessBeacon         RandomnessBeacon          // optional, defaults to the KeyManager's random seed signatures
+	RetransmissionInterval   time.Duration             // optional, 0 disables requesting missing PREPARE/COMMIT messages from peers
+	RebroadcastInterval      time.Duration             // optional, 0 disables rebroadcasting our own latest message of an uncommitted view
+	BroadcastViewChange      bool                      // optional, sends VIEW_CHANGE to the whole committee instead of only to the next leader, O(n²) messages per view change; enables view synchronization
+	StoreFutureViewChanges   bool                      // optional, also stores VIEW_CHANGE of future views led by other members, not only those we lead
+	BlockDisseminationByHash bool                      // optional, PREPREPARE and NEW_VIEW carry only the block hash and replicas fetch the block from peers
+	BlockCodec               BlockCodec                // optional, with BlockDisseminationByHash the leader sends erasure coded chunks of the block instead
+	CompactNewView           bool                      // optional, NEW_VIEW carries a single prepared proof and only the signed prepared view of each VIEW_CHANGE
+	PipelinedProposals       bool                      // optional, builds the block of H+1 once H is prepared, so a leader of H+1 can propose as soon as H commits; every member builds ahead unless its BlockUtils is a ProposeAheadFilter
+	ProposalTimeout          time.Duration             // optional, 0 waits for RequestNewBlockProposal indefinitely, otherwise an EmptyBlockProposer is asked once it passes, with the same timeout after which nothing is proposed
+	AsyncBlockValidation     bool                      // optional, runs ValidateBlockProposal in the background so the worker loop keeps handling messages and elections
+	OnMessageRejectedCB      OnMessageRejectedCallback // optional, reports dropped messages with the reason, e.g. for scoring peers
 }
 
 type ConsensusRawMessage struct {
@@ -62,6 +76,38 @@ type BlockUtils interface {
 	ValidateBlockCommitment(blockHeight primitives.BlockHeight, block Block, blockHash primitives.BlockHash) bool
 }
 
+// PipelinedBlockUtils starts building the block of the next height on top of a block that is prepared but not yet committed.
+// A later RequestNewBlockProposal for that height, member and previous block returns the block built ahead.
+type PipelinedBlockUtils interface {
+	BlockUtils
+	ProposeAhead(ctx context.Context, blockHeight primitives.BlockHeight, memberId primitives.MemberId, prevBlock Block, prevBlockHash primitives.BlockHash)
+}
+
+// ProposeAheadFilter is optionally implemented by BlockUtils with Config.PipelinedProposals.
+// The leader of H+1 is only known once H commits, as the committee of H+1 is ordered by a random seed taken from the block proof of H,
+// so every committee member calls RequestNewBlockProposal for H+1 although at most one of them proposes it.
+// Returning false skips building ahead on a member, which then builds its block only once it is the leader.
+type ProposeAheadFilter interface {
+	ShouldProposeAhead(blockHeight primitives.BlockHeight, memberId primitives.MemberId, prevBlock Block) bool
+}
+
+// EmptyBlockProposer is optionally implemented by BlockUtils, its empty block is proposed when RequestNewBlockProposal
+// does not return within Config.ProposalTimeout, so a slow transaction pool does not waste the view.
+type EmptyBlockProposer interface {
+	RequestEmptyBlockProposal(ctx context.Context, blockHeight primitives.BlockHeight, memberId primitives.MemberId, prevBlock Block) (Block, primitives.BlockHash)
+}
+
+// BlockValidationScheduler runs validate in the background and calls onValidated on the worker loop once it returns,
+// with ctx.Err() if ctx was done meanwhile.
+type BlockValidationScheduler interface {
+	ScheduleBlockValidation(ctx context.Context, validate func(ctx context.Context) error, onValidated func(err error))
+}
+
+type BlockCodec interface {
+	EncodeBlock(block Block) ([]byte, error)
+	DecodeBlock(encoded []byte) (Block, error)
+}
+
 type KeyManager interface {
 	SignConsensusMessage(ctx context.Context, blockHeight primitives.BlockHeight, content []byte) primitives.Signature
 	VerifyConsensusMessage(blockHeight primitives.BlockHeight, content []byte, sender *protocol.SenderSignature) error
@@ -70,6 +116,17 @@ type KeyManager interface {
 	AggregateRandomSeed(blockHeight primitives.BlockHeight, randomSeedShares []*protocol.SenderSignature) primitives.RandomSeedSignature
 }
 
+// RandomnessBeacon provides the per-height random seed which orders the committee.
+// Every COMMIT carries a share on the seed of its height, and a quorum of shares is aggregated into the block proof,
+// from which the seed of the next height is derived.
+type RandomnessBeacon interface {
+	DeriveRandomSeed(blockHeight primitives.BlockHeight, prevBlockProof *protocol.BlockProof) uint64
+	ProduceShare(ctx context.Context, blockHeight primitives.BlockHeight, randomSeed uint64) primitives.RandomSeedSignature
+	VerifyShare(blockHeight primitives.BlockHeight, randomSeed uint64, share *protocol.SenderSignature) error
+	AggregateShares(blockHeight primitives.BlockHeight, randomSeed uint64, shares []*protocol.SenderSignature) primitives.RandomSeedSignature
+	VerifyAggregate(blockHeight primitives.BlockHeight, randomSeed uint64, aggregate primitives.RandomSeedSignature) error
+}
+
 type ElectionTrigger struct {
 	MoveToNextLeader func()
 	Hv               *state.HeightView
@@ -0,0 +1,51 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package interfaces
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Errors reported to Config.OnMessageRejectedCB and returned by UpdateState wrap one of these, compare errors.Cause(err) to them
+var (
+	ErrMalformedMessage    = errors.New("malformed message")
+	ErrWrongInstance       = errors.New("message of another instance")
+	ErrStaleHeight         = errors.New("stale height")
+	ErrFutureMessageCached = errors.New("message of a future height cached")
+	ErrInvalidSignature    = errors.New("invalid signature")
+	ErrNotACommitteeMember = errors.New("sender is not a committee member")
+	ErrOutOfCommittee      = errors.New("this node is out of the committee of the message's height")
+	ErrQueueFull           = errors.New("no room to queue the message")
+)
+
+// Called with the rejected message and an error wrapping one of the Err* sentinels.
+// For a message retransmitted inside a MESSAGES_RESPONSE, message is the retransmitted one.
+// It may be called concurrently from the worker loop and the verification pipeline and must not block.
+type OnMessageRejectedCallback func(message *ConsensusRawMessage, err error)
+
+func (cb OnMessageRejectedCallback) Reject(message *ConsensusRawMessage, err error) {
+	if cb != nil {
+		cb(message, err)
+	}
+}
+
+// RejectMessage re-serializes message only if a callback is set
+func (cb OnMessageRejectedCallback) RejectMessage(message ConsensusMessage, err error) {
+	if cb != nil {
+		cb(message.ToConsensusRawMessage(), err)
+	}
+}
+
+// IsPeerMisbehavior tells apart errors which can only be caused by a faulty or malicious sender
+// from those which an honest peer at a different height or committee also causes
+func IsPeerMisbehavior(err error) bool {
+	switch errors.Cause(err) {
+	case ErrMalformedMessage, ErrInvalidSignature, ErrNotACommitteeMember:
+		return true
+	}
+	return false
+}
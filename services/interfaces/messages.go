@@ -71,6 +71,44 @@ func CreateConsensusRawMessage(message ConsensusMessage) *ConsensusRawMessage {
 		}
 		block = message.block
 
+	case *MessagesRequestMessage:
+		content = &protocol.LeanhelixContentBuilder{
+			Message:         protocol.LEANHELIX_CONTENT_MESSAGE_MESSAGES_REQUEST,
+			MessagesRequest: protocol.MessagesRequestContentBuilderFromRaw(message.content.Raw()),
+		}
+
+	case *MessagesResponseMessage:
+		content = &protocol.LeanhelixContentBuilder{
+			Message:          protocol.LEANHELIX_CONTENT_MESSAGE_MESSAGES_RESPONSE,
+			MessagesResponse: protocol.MessagesResponseContentBuilderFromRaw(message.content.Raw()),
+		}
+
+	case *CommitCertificateMessage:
+		content = &protocol.LeanhelixContentBuilder{
+			Message:           protocol.LEANHELIX_CONTENT_MESSAGE_COMMIT_CERTIFICATE,
+			CommitCertificate: protocol.CommitCertificateContentBuilderFromRaw(message.content.Raw()),
+		}
+		block = message.block
+
+	case *BlockRequestMessage:
+		content = &protocol.LeanhelixContentBuilder{
+			Message:      protocol.LEANHELIX_CONTENT_MESSAGE_BLOCK_REQUEST,
+			BlockRequest: protocol.BlockRequestContentBuilderFromRaw(message.content.Raw()),
+		}
+
+	case *BlockResponseMessage:
+		content = &protocol.LeanhelixContentBuilder{
+			Message:       protocol.LEANHELIX_CONTENT_MESSAGE_BLOCK_RESPONSE,
+			BlockResponse: protocol.BlockResponseContentBuilderFromRaw(message.content.Raw()),
+		}
+		block = message.block
+
+	case *BlockChunkMessage:
+		content = &protocol.LeanhelixContentBuilder{
+			Message:    protocol.LEANHELIX_CONTENT_MESSAGE_BLOCK_CHUNK,
+			BlockChunk: protocol.BlockChunkContentBuilderFromRaw(message.content.Raw()),
+		}
+
 	default:
 		panic(fmt.Sprintf("unknown message type: %T", message))
 	}
@@ -119,6 +157,44 @@ func ToConsensusMessage(consensusMessage *ConsensusRawMessage) ConsensusMessage
 			block:   consensusMessage.Block,
 		}
 	}
+
+	if lhContentReader.IsMessageMessagesRequest() {
+		message = &MessagesRequestMessage{
+			content: lhContentReader.MessagesRequest(),
+		}
+	}
+
+	if lhContentReader.IsMessageMessagesResponse() {
+		message = &MessagesResponseMessage{
+			content: lhContentReader.MessagesResponse(),
+		}
+	}
+
+	if lhContentReader.IsMessageCommitCertificate() {
+		message = &CommitCertificateMessage{
+			content: lhContentReader.CommitCertificate(),
+			block:   consensusMessage.Block,
+		}
+	}
+
+	if lhContentReader.IsMessageBlockRequest() {
+		message = &BlockRequestMessage{
+			content: lhContentReader.BlockRequest(),
+		}
+	}
+
+	if lhContentReader.IsMessageBlockResponse() {
+		message = &BlockResponseMessage{
+			content: lhContentReader.BlockResponse(),
+			block:   consensusMessage.Block,
+		}
+	}
+
+	if lhContentReader.IsMessageBlockChunk() {
+		message = &BlockChunkMessage{
+			content: lhContentReader.BlockChunk(),
+		}
+	}
 	return message // handle with error
 }
 
@@ -383,6 +459,355 @@ func NewNewViewMessage(content *protocol.NewViewMessageContent, block Block) *Ne
 	}
 }
 
+/***************************************************/
+/*              RETRANSMISSION MESSAGES            */
+/***************************************************/
+
+//------------------
+// Messages Request
+//------------------
+type MessagesRequestMessage struct {
+	content *protocol.MessagesRequestContent
+}
+
+func (mrm *MessagesRequestMessage) InstanceId() primitives.InstanceId {
+	return mrm.content.SignedHeader().InstanceId()
+}
+
+func (mrm *MessagesRequestMessage) MessageType() protocol.MessageType {
+	return mrm.content.SignedHeader().MessageType()
+}
+
+func (mrm *MessagesRequestMessage) Content() *protocol.MessagesRequestContent {
+	return mrm.content
+}
+
+func (mrm *MessagesRequestMessage) Raw() []byte {
+	return mrm.content.Raw()
+}
+
+func (mrm *MessagesRequestMessage) String() string {
+	return mrm.content.String()
+}
+
+func (mrm *MessagesRequestMessage) SenderMemberId() primitives.MemberId {
+	return mrm.content.Sender().MemberId()
+}
+
+func (mrm *MessagesRequestMessage) BlockHeight() primitives.BlockHeight {
+	return mrm.content.SignedHeader().BlockHeight()
+}
+
+func (mrm *MessagesRequestMessage) View() primitives.View {
+	return mrm.content.SignedHeader().View()
+}
+
+func (mrm *MessagesRequestMessage) ToConsensusRawMessage() *ConsensusRawMessage {
+	return CreateConsensusRawMessage(mrm)
+}
+
+func NewMessagesRequestMessage(content *protocol.MessagesRequestContent) *MessagesRequestMessage {
+	return &MessagesRequestMessage{content: content}
+}
+
+//-------------------
+// Messages Response
+//-------------------
+type MessagesResponseMessage struct {
+	content *protocol.MessagesResponseContent
+}
+
+func (mrm *MessagesResponseMessage) InstanceId() primitives.InstanceId {
+	return mrm.content.SignedHeader().InstanceId()
+}
+
+func (mrm *MessagesResponseMessage) MessageType() protocol.MessageType {
+	return mrm.content.SignedHeader().MessageType()
+}
+
+func (mrm *MessagesResponseMessage) Content() *protocol.MessagesResponseContent {
+	return mrm.content
+}
+
+func (mrm *MessagesResponseMessage) Raw() []byte {
+	return mrm.content.Raw()
+}
+
+func (mrm *MessagesResponseMessage) String() string {
+	return mrm.content.String()
+}
+
+func (mrm *MessagesResponseMessage) SenderMemberId() primitives.MemberId {
+	return mrm.content.Sender().MemberId()
+}
+
+func (mrm *MessagesResponseMessage) BlockHeight() primitives.BlockHeight {
+	return mrm.content.SignedHeader().BlockHeight()
+}
+
+func (mrm *MessagesResponseMessage) View() primitives.View {
+	return mrm.content.SignedHeader().View()
+}
+
+// The retransmitted messages, to be handled as if received from their original senders
+func (mrm *MessagesResponseMessage) PrepareMessages() []*PrepareMessage {
+	var result []*PrepareMessage
+	iter := mrm.content.PrepareMessagesIterator()
+	for iter.HasNext() {
+		result = append(result, NewPrepareMessage(iter.NextPrepareMessages()))
+	}
+	return result
+}
+
+func (mrm *MessagesResponseMessage) CommitMessages() []*CommitMessage {
+	var result []*CommitMessage
+	iter := mrm.content.CommitMessagesIterator()
+	for iter.HasNext() {
+		result = append(result, NewCommitMessage(iter.NextCommitMessages()))
+	}
+	return result
+}
+
+func (mrm *MessagesResponseMessage) ToConsensusRawMessage() *ConsensusRawMessage {
+	return CreateConsensusRawMessage(mrm)
+}
+
+func NewMessagesResponseMessage(content *protocol.MessagesResponseContent) *MessagesResponseMessage {
+	return &MessagesResponseMessage{content: content}
+}
+
+//--------------------
+// Commit Certificate
+//--------------------
+type CommitCertificateMessage struct {
+	content *protocol.CommitCertificateContent
+	block   Block
+}
+
+func (ccm *CommitCertificateMessage) InstanceId() primitives.InstanceId {
+	return ccm.content.SignedHeader().InstanceId()
+}
+
+func (ccm *CommitCertificateMessage) MessageType() protocol.MessageType {
+	return ccm.content.SignedHeader().MessageType()
+}
+
+func (ccm *CommitCertificateMessage) Content() *protocol.CommitCertificateContent {
+	return ccm.content
+}
+
+func (ccm *CommitCertificateMessage) Raw() []byte {
+	return ccm.content.Raw()
+}
+
+func (ccm *CommitCertificateMessage) String() string {
+	return ccm.content.String()
+}
+
+func (ccm *CommitCertificateMessage) SenderMemberId() primitives.MemberId {
+	return ccm.content.Sender().MemberId()
+}
+
+func (ccm *CommitCertificateMessage) BlockHeight() primitives.BlockHeight {
+	return ccm.content.SignedHeader().BlockHeight()
+}
+
+func (ccm *CommitCertificateMessage) View() primitives.View {
+	return ccm.content.SignedHeader().View()
+}
+
+func (ccm *CommitCertificateMessage) Block() Block {
+	return ccm.block
+}
+
+func (ccm *CommitCertificateMessage) BlockProof() []byte {
+	return ccm.content.BlockProof()
+}
+
+func (ccm *CommitCertificateMessage) ToConsensusRawMessage() *ConsensusRawMessage {
+	return CreateConsensusRawMessage(ccm)
+}
+
+func NewCommitCertificateMessage(content *protocol.CommitCertificateContent, block Block) *CommitCertificateMessage {
+	return &CommitCertificateMessage{
+		content: content,
+		block:   block,
+	}
+}
+
+//---------------
+// Block Request
+//---------------
+type BlockRequestMessage struct {
+	content *protocol.BlockRequestContent
+}
+
+func (brm *BlockRequestMessage) InstanceId() primitives.InstanceId {
+	return brm.content.SignedHeader().InstanceId()
+}
+
+func (brm *BlockRequestMessage) MessageType() protocol.MessageType {
+	return brm.content.SignedHeader().MessageType()
+}
+
+func (brm *BlockRequestMessage) Content() *protocol.BlockRequestContent {
+	return brm.content
+}
+
+func (brm *BlockRequestMessage) Raw() []byte {
+	return brm.content.Raw()
+}
+
+func (brm *BlockRequestMessage) String() string {
+	return brm.content.String()
+}
+
+func (brm *BlockRequestMessage) SenderMemberId() primitives.MemberId {
+	return brm.content.Sender().MemberId()
+}
+
+func (brm *BlockRequestMessage) BlockHeight() primitives.BlockHeight {
+	return brm.content.SignedHeader().BlockHeight()
+}
+
+func (brm *BlockRequestMessage) View() primitives.View {
+	return brm.content.SignedHeader().View()
+}
+
+func (brm *BlockRequestMessage) BlockHash() primitives.BlockHash {
+	return brm.content.SignedHeader().BlockHash()
+}
+
+func (brm *BlockRequestMessage) ToConsensusRawMessage() *ConsensusRawMessage {
+	return CreateConsensusRawMessage(brm)
+}
+
+func NewBlockRequestMessage(content *protocol.BlockRequestContent) *BlockRequestMessage {
+	return &BlockRequestMessage{content: content}
+}
+
+//----------------
+// Block Response
+//----------------
+type BlockResponseMessage struct {
+	content *protocol.BlockResponseContent
+	block   Block
+}
+
+func (brm *BlockResponseMessage) InstanceId() primitives.InstanceId {
+	return brm.content.SignedHeader().InstanceId()
+}
+
+func (brm *BlockResponseMessage) MessageType() protocol.MessageType {
+	return brm.content.SignedHeader().MessageType()
+}
+
+func (brm *BlockResponseMessage) Content() *protocol.BlockResponseContent {
+	return brm.content
+}
+
+func (brm *BlockResponseMessage) Raw() []byte {
+	return brm.content.Raw()
+}
+
+func (brm *BlockResponseMessage) String() string {
+	return brm.content.String()
+}
+
+func (brm *BlockResponseMessage) SenderMemberId() primitives.MemberId {
+	return brm.content.Sender().MemberId()
+}
+
+func (brm *BlockResponseMessage) BlockHeight() primitives.BlockHeight {
+	return brm.content.SignedHeader().BlockHeight()
+}
+
+func (brm *BlockResponseMessage) View() primitives.View {
+	return brm.content.SignedHeader().View()
+}
+
+func (brm *BlockResponseMessage) BlockHash() primitives.BlockHash {
+	return brm.content.SignedHeader().BlockHash()
+}
+
+func (brm *BlockResponseMessage) Block() Block {
+	return brm.block
+}
+
+func (brm *BlockResponseMessage) ToConsensusRawMessage() *ConsensusRawMessage {
+	return CreateConsensusRawMessage(brm)
+}
+
+func NewBlockResponseMessage(content *protocol.BlockResponseContent, block Block) *BlockResponseMessage {
+	return &BlockResponseMessage{
+		content: content,
+		block:   block,
+	}
+}
+
+//-------------
+// Block Chunk
+//-------------
+type BlockChunkMessage struct {
+	content *protocol.BlockChunkContent
+}
+
+func (bcm *BlockChunkMessage) InstanceId() primitives.InstanceId {
+	return bcm.content.SignedHeader().InstanceId()
+}
+
+func (bcm *BlockChunkMessage) MessageType() protocol.MessageType {
+	return bcm.content.SignedHeader().MessageType()
+}
+
+func (bcm *BlockChunkMessage) Content() *protocol.BlockChunkContent {
+	return bcm.content
+}
+
+func (bcm *BlockChunkMessage) Raw() []byte {
+	return bcm.content.Raw()
+}
+
+func (bcm *BlockChunkMessage) String() string {
+	return bcm.content.String()
+}
+
+func (bcm *BlockChunkMessage) SenderMemberId() primitives.MemberId {
+	return bcm.content.Sender().MemberId()
+}
+
+func (bcm *BlockChunkMessage) BlockHeight() primitives.BlockHeight {
+	return bcm.content.SignedHeader().BlockHeight()
+}
+
+func (bcm *BlockChunkMessage) View() primitives.View {
+	return bcm.content.SignedHeader().View()
+}
+
+func (bcm *BlockChunkMessage) Index() int {
+	return int(bcm.content.Index())
+}
+
+func (bcm *BlockChunkMessage) Chunk() []byte {
+	return bcm.content.Chunk()
+}
+
+func (bcm *BlockChunkMessage) MerkleProof() [][]byte {
+	var proof [][]byte
+	for i := bcm.content.MerkleProofIterator(); i.HasNext(); {
+		proof = append(proof, i.NextMerkleProof())
+	}
+	return proof
+}
+
+func (bcm *BlockChunkMessage) ToConsensusRawMessage() *ConsensusRawMessage {
+	return CreateConsensusRawMessage(bcm)
+}
+
+func NewBlockChunkMessage(content *protocol.BlockChunkContent) *BlockChunkMessage {
+	return &BlockChunkMessage{content: content}
+}
+
 func ExtractConfirmationsFromViewChangeMessages(vcms []*ViewChangeMessage) []*protocol.ViewChangeMessageContentBuilder {
 	if len(vcms) == 0 {
 		return nil
@@ -458,3 +883,59 @@ func ExtractConfirmationsFromViewChangeMessages(vcms []*ViewChangeMessage) []*pr
 	//	viewChangeMessages.map(vc =>
 	//		({ signedHeader: vc.content.signedHeader, sender: vc.content.sender }));
 }
+
+// What a VIEW_CHANGE sender signs besides its header, so that a compact NEW_VIEW can attest to its vote without its prepared proof
+func ViewChangeSummaryHeaderFor(header *protocol.ViewChangeHeader) *protocol.ViewChangeSummaryHeaderBuilder {
+	summary := &protocol.ViewChangeSummaryHeaderBuilder{
+		InstanceId:  header.InstanceId(),
+		MessageType: protocol.LEAN_HELIX_VIEW_CHANGE_SUMMARY,
+		BlockHeight: header.BlockHeight(),
+		View:        header.View(),
+	}
+	if proof := header.PreparedProof(); proof != nil && len(proof.Raw()) > 0 {
+		summary.PreparedView = proof.PreprepareBlockRef().View()
+		summary.PreparedBlockHash = proof.PreprepareBlockRef().BlockHash()
+	}
+	return summary
+}
+
+// Returns nil if any of the VIEW_CHANGE messages was sent without a summary signature
+func ExtractCompactConfirmationsFromViewChangeMessages(vcms []*ViewChangeMessage) []*protocol.ViewChangeSummaryContentBuilder {
+	if len(vcms) == 0 {
+		return nil
+	}
+
+	res := make([]*protocol.ViewChangeSummaryContentBuilder, 0, len(vcms))
+	for _, vcm := range vcms {
+		summarySender := vcm.content.SummarySender()
+		if summarySender == nil || len(summarySender.Signature()) == 0 {
+			return nil
+		}
+		res = append(res, &protocol.ViewChangeSummaryContentBuilder{
+			SignedHeader: ViewChangeSummaryHeaderFor(vcm.content.SignedHeader()),
+			Sender: &protocol.SenderSignatureBuilder{
+				MemberId:  summarySender.MemberId(),
+				Signature: summarySender.Signature(),
+			},
+		})
+	}
+	return res
+}
+
+// The prepared proof of the latest prepared view among the VIEW_CHANGE messages, nil if none of them is prepared
+func ExtractHighestPreparedProofFromViewChangeMessages(vcms []*ViewChangeMessage) *protocol.PreparedProofBuilder {
+	var highest *protocol.PreparedProof
+	for _, vcm := range vcms {
+		proof := vcm.content.SignedHeader().PreparedProof()
+		if proof == nil || len(proof.Raw()) == 0 {
+			continue
+		}
+		if highest == nil || proof.PreprepareBlockRef().View() > highest.PreprepareBlockRef().View() {
+			highest = proof
+		}
+	}
+	if highest == nil {
+		return nil
+	}
+	return protocol.PreparedProofBuilderFromRaw(highest.Raw())
+}
@@ -0,0 +1,148 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package keymanager
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/binary"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
+	"github.com/pkg/errors"
+	"math/big"
+	"sort"
+)
+
+var consensusMessageDomain = []byte("LH_CONSENSUS_MESSAGE")
+
+// Ed25519BLSKeyManager signs consensus messages with the member's ed25519 key,
+// and the random seed with its share of a threshold BLS key, see README.md.
+//
+// A random seed share is the member's BLS signature followed by the seed itself, since AggregateRandomSeed is not given it.
+// The aggregate is the group key's BLS signature, which can only be computed from the shares of
+// PublicKeyDirectory.RandomSeedThreshold() members and is the same whichever shares were used.
+type Ed25519BLSKeyManager struct {
+	privateKey      ed25519.PrivateKey
+	randomSeedIndex uint32
+	randomSeedKey   *big.Int
+	directory       *PublicKeyDirectory
+}
+
+func NewEd25519BLSKeyManager(privateKey ed25519.PrivateKey, randomSeedKeyShare *RandomSeedKeyShare, directory *PublicKeyDirectory) (*Ed25519BLSKeyManager, error) {
+	if len(privateKey) != ed25519.PrivateKeySize {
+		return nil, errors.Errorf("NewEd25519BLSKeyManager: private key must be %d bytes, got %d", ed25519.PrivateKeySize, len(privateKey))
+	}
+	randomSeedKey, err := parseRandomSeedKeyShare(randomSeedKeyShare)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewEd25519BLSKeyManager")
+	}
+	return &Ed25519BLSKeyManager{
+		privateKey:      privateKey,
+		randomSeedIndex: randomSeedKeyShare.Index,
+		randomSeedKey:   randomSeedKey,
+		directory:       directory,
+	}, nil
+}
+
+func signedBytes(domain []byte, blockHeight primitives.BlockHeight, content []byte) []byte {
+	buf := make([]byte, 0, len(domain)+8+len(content))
+	buf = append(buf, domain...)
+	buf = append(buf, make([]byte, 8)...)
+	binary.BigEndian.PutUint64(buf[len(domain):], uint64(blockHeight))
+	return append(buf, content...)
+}
+
+func (km *Ed25519BLSKeyManager) SignConsensusMessage(ctx context.Context, blockHeight primitives.BlockHeight, content []byte) primitives.Signature {
+	return ed25519.Sign(km.privateKey, signedBytes(consensusMessageDomain, blockHeight, content))
+}
+
+func (km *Ed25519BLSKeyManager) VerifyConsensusMessage(blockHeight primitives.BlockHeight, content []byte, sender *protocol.SenderSignature) error {
+	member, err := km.directory.memberOf(sender.MemberId())
+	if err != nil {
+		return errors.Wrap(err, "VerifyConsensusMessage")
+	}
+	if !ed25519.Verify(member.consensusPublicKey, signedBytes(consensusMessageDomain, blockHeight, content), sender.Signature()) {
+		return errors.Errorf("VerifyConsensusMessage: invalid signature of memberId %s", sender.MemberId())
+	}
+	return nil
+}
+
+// Returns nil if the share cannot be computed, which only happens if hashing the content to the curve fails
+func (km *Ed25519BLSKeyManager) SignRandomSeed(ctx context.Context, blockHeight primitives.BlockHeight, content []byte) primitives.RandomSeedSignature {
+	signature, err := blsSign(km.randomSeedKey, blockHeight, content)
+	if err != nil {
+		return nil
+	}
+	return append(signature, content...)
+}
+
+// Verifies a member's share against its public share, or the aggregated random seed signature against the group public key
+// when the sender has no MemberId
+func (km *Ed25519BLSKeyManager) VerifyRandomSeed(blockHeight primitives.BlockHeight, content []byte, sender *protocol.SenderSignature) error {
+	if len(sender.MemberId()) == 0 {
+		if err := blsVerify(km.directory.randomSeedPublicKey, blockHeight, content, sender.Signature()); err != nil {
+			return errors.Wrap(err, "VerifyRandomSeed: invalid aggregated random seed signature")
+		}
+		return nil
+	}
+
+	share := sender.Signature()
+	if len(share) < blsSignatureSize || !bytes.Equal(share[blsSignatureSize:], content) {
+		return errors.Errorf("VerifyRandomSeed: share of memberId %s is not on the expected random seed", sender.MemberId())
+	}
+	member, err := km.directory.memberOf(sender.MemberId())
+	if err != nil {
+		return errors.Wrap(err, "VerifyRandomSeed")
+	}
+	if err := blsVerify(member.randomSeedPublicKey, blockHeight, content, share[:blsSignatureSize]); err != nil {
+		return errors.Wrapf(err, "VerifyRandomSeed: invalid share of memberId %s", sender.MemberId())
+	}
+	return nil
+}
+
+// Returns nil if fewer than the directory's random seed threshold of distinct members provided valid shares on the same seed
+func (km *Ed25519BLSKeyManager) AggregateRandomSeed(blockHeight primitives.BlockHeight, randomSeedShares []*protocol.SenderSignature) primitives.RandomSeedSignature {
+	validSharesBySeed := make(map[string]map[uint32][]byte)
+	for _, share := range randomSeedShares {
+		if len(share.Signature()) < blsSignatureSize {
+			continue
+		}
+		content := share.Signature()[blsSignatureSize:]
+		if km.VerifyRandomSeed(blockHeight, content, share) != nil {
+			continue
+		}
+		member, _ := km.directory.memberOf(share.MemberId())
+		shares, ok := validSharesBySeed[string(content)]
+		if !ok {
+			shares = make(map[uint32][]byte)
+			validSharesBySeed[string(content)] = shares
+		}
+		shares[member.randomSeedShareIndex] = share.Signature()[:blsSignatureSize]
+		if len(shares) >= km.directory.randomSeedThreshold {
+			return aggregate(shares)
+		}
+	}
+	return nil
+}
+
+func aggregate(shares map[uint32][]byte) primitives.RandomSeedSignature {
+	indices := make([]uint32, 0, len(shares))
+	for index := range shares {
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	signatures := make([][]byte, len(indices))
+	for i, index := range indices {
+		signatures[i] = shares[index]
+	}
+	signature, err := blsInterpolate(indices, signatures)
+	if err != nil {
+		return nil
+	}
+	return signature
+}
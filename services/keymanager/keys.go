@@ -0,0 +1,190 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package keymanager
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	bls "github.com/kilic/bls12-381"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/pkg/errors"
+	"io"
+)
+
+type KeyPair struct {
+	PublicKey  ed25519.PublicKey
+	PrivateKey ed25519.PrivateKey
+}
+
+// The MemberId of a committee member holding this key pair, when member ids are public keys
+func (k *KeyPair) MemberId() primitives.MemberId {
+	return primitives.MemberId(k.PublicKey)
+}
+
+func GenerateKeyPair() (*KeyPair, error) {
+	return generateKeyPair(rand.Reader)
+}
+
+// Derives a key pair from a 32 byte seed; the same seed always yields the same keys
+func KeyPairFromSeed(seed []byte) (*KeyPair, error) {
+	if len(seed) != ed25519.SeedSize {
+		return nil, errors.Errorf("KeyPairFromSeed: seed must be %d bytes, got %d", ed25519.SeedSize, len(seed))
+	}
+	privateKey := ed25519.NewKeyFromSeed(seed)
+	return &KeyPair{
+		PublicKey:  privateKey.Public().(ed25519.PublicKey),
+		PrivateKey: privateKey,
+	}, nil
+}
+
+func generateKeyPair(random io.Reader) (*KeyPair, error) {
+	publicKey, privateKey, err := ed25519.GenerateKey(random)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate ed25519 key pair")
+	}
+	return &KeyPair{
+		PublicKey:  publicKey,
+		PrivateKey: privateKey,
+	}, nil
+}
+
+type memberPublicKeys struct {
+	consensusPublicKey   ed25519.PublicKey
+	randomSeedShareIndex uint32
+	randomSeedPublicKey  *bls.PointG2
+}
+
+// PublicKeyDirectory holds the public keys of a committee: each member's ed25519 consensus key and random seed public share,
+// and the random seed group public key with the number of shares needed to sign with it.
+// It is read only once built, and may be shared by the key managers of all members of a process.
+type PublicKeyDirectory struct {
+	members             map[string]*memberPublicKeys
+	randomSeedPublicKey *bls.PointG2
+	randomSeedThreshold int
+}
+
+func NewPublicKeyDirectory(randomSeedPublicKey []byte, randomSeedThreshold int) (*PublicKeyDirectory, error) {
+	if randomSeedThreshold < 1 {
+		return nil, errors.Errorf("NewPublicKeyDirectory: random seed threshold must be at least 1, got %d", randomSeedThreshold)
+	}
+	groupPublicKey, err := parseBLSPublicKey(randomSeedPublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewPublicKeyDirectory: random seed public key")
+	}
+	return &PublicKeyDirectory{
+		members:             make(map[string]*memberPublicKeys),
+		randomSeedPublicKey: groupPublicKey,
+		randomSeedThreshold: randomSeedThreshold,
+	}, nil
+}
+
+func (d *PublicKeyDirectory) Add(memberId primitives.MemberId, consensusPublicKey ed25519.PublicKey, randomSeedPublicShare *RandomSeedPublicShare) error {
+	if len(consensusPublicKey) != ed25519.PublicKeySize {
+		return errors.Errorf("Add: consensus public key of memberId %s must be %d bytes, got %d", memberId, ed25519.PublicKeySize, len(consensusPublicKey))
+	}
+	if randomSeedPublicShare == nil || randomSeedPublicShare.Index == 0 {
+		return errors.Errorf("Add: random seed public share of memberId %s must have an index starting at 1", memberId)
+	}
+	for id, member := range d.members {
+		if member.randomSeedShareIndex == randomSeedPublicShare.Index && id != memberId.KeyForMap() {
+			return errors.Errorf("Add: random seed share index %d of memberId %s is already used by another member", randomSeedPublicShare.Index, memberId)
+		}
+	}
+	publicShare, err := parseBLSPublicKey(randomSeedPublicShare.PublicKey)
+	if err != nil {
+		return errors.Wrapf(err, "Add: random seed public share of memberId %s", memberId)
+	}
+	d.members[memberId.KeyForMap()] = &memberPublicKeys{
+		consensusPublicKey:   consensusPublicKey,
+		randomSeedShareIndex: randomSeedPublicShare.Index,
+		randomSeedPublicKey:  publicShare,
+	}
+	return nil
+}
+
+func (d *PublicKeyDirectory) RandomSeedThreshold() int {
+	return d.randomSeedThreshold
+}
+
+// The compressed random seed group public key, to distribute the directory to members
+func (d *PublicKeyDirectory) RandomSeedPublicKey() []byte {
+	return bls.NewG2().ToCompressed(new(bls.PointG2).Set(d.randomSeedPublicKey))
+}
+
+func (d *PublicKeyDirectory) RandomSeedPublicShareOf(memberId primitives.MemberId) (*RandomSeedPublicShare, error) {
+	member, err := d.memberOf(memberId)
+	if err != nil {
+		return nil, err
+	}
+	return &RandomSeedPublicShare{
+		Index:     member.randomSeedShareIndex,
+		PublicKey: bls.NewG2().ToCompressed(new(bls.PointG2).Set(member.randomSeedPublicKey)),
+	}, nil
+}
+
+func (d *PublicKeyDirectory) memberOf(memberId primitives.MemberId) (*memberPublicKeys, error) {
+	if member, ok := d.members[memberId.KeyForMap()]; ok {
+		return member, nil
+	}
+	return nil, errors.Errorf("no public keys for memberId %s", memberId)
+}
+
+// MemberKeys holds the secret keys of one committee member
+type MemberKeys struct {
+	MemberId           primitives.MemberId
+	ConsensusKey       *KeyPair
+	RandomSeedKeyShare *RandomSeedKeyShare
+}
+
+// CommitteeKeys holds everything needed to configure the key managers of a committee
+type CommitteeKeys struct {
+	Members   []*MemberKeys
+	Directory *PublicKeyDirectory
+}
+
+// GenerateCommitteeKeys creates an ed25519 key pair per member and deals the random seed key shares, any randomSeedThreshold
+// of which can sign the random seed. It is a trusted dealer: use it for a setup ceremony or tests, and hand each member
+// only its own MemberKeys.
+// If memberIds is empty the members are identified by their ed25519 public keys.
+func GenerateCommitteeKeys(count int, randomSeedThreshold int, memberIds ...primitives.MemberId) (*CommitteeKeys, error) {
+	if len(memberIds) > 0 && len(memberIds) != count {
+		return nil, errors.Errorf("GenerateCommitteeKeys: got %d memberIds for %d members", len(memberIds), count)
+	}
+
+	groupPublicKey, shares, publicShares, err := DealRandomSeedKeyShares(count, randomSeedThreshold)
+	if err != nil {
+		return nil, err
+	}
+	directory, err := NewPublicKeyDirectory(groupPublicKey, randomSeedThreshold)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := &CommitteeKeys{
+		Members:   make([]*MemberKeys, count),
+		Directory: directory,
+	}
+	for i := 0; i < count; i++ {
+		keyPair, err := GenerateKeyPair()
+		if err != nil {
+			return nil, err
+		}
+		memberId := keyPair.MemberId()
+		if len(memberIds) > 0 {
+			memberId = memberIds[i]
+		}
+		if err := directory.Add(memberId, keyPair.PublicKey, publicShares[i]); err != nil {
+			return nil, err
+		}
+		keys.Members[i] = &MemberKeys{
+			MemberId:           memberId,
+			ConsensusKey:       keyPair,
+			RandomSeedKeyShare: shares[i],
+		}
+	}
+	return keys, nil
+}
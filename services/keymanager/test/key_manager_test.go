@@ -0,0 +1,175 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package test
+
+import (
+	"bytes"
+	"context"
+	"github.com/orbs-network/lean-helix-go/services/keymanager"
+	"github.com/orbs-network/lean-helix-go/services/randomseed"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func aCommittee(t *testing.T, count int, threshold int) ([]*keymanager.Ed25519BLSKeyManager, *keymanager.CommitteeKeys) {
+	keys, err := keymanager.GenerateCommitteeKeys(count, threshold)
+	require.NoError(t, err)
+	return keyManagersOf(t, keys.Members, keys.Directory), keys
+}
+
+func keyManagersOf(t *testing.T, members []*keymanager.MemberKeys, directory *keymanager.PublicKeyDirectory) []*keymanager.Ed25519BLSKeyManager {
+	keyManagers := make([]*keymanager.Ed25519BLSKeyManager, len(members))
+	for i, member := range members {
+		km, err := keymanager.NewEd25519BLSKeyManager(member.ConsensusKey.PrivateKey, member.RandomSeedKeyShare, directory)
+		require.NoError(t, err)
+		keyManagers[i] = km
+	}
+	return keyManagers
+}
+
+func senderSignature(memberId primitives.MemberId, signature []byte) *protocol.SenderSignature {
+	return (&protocol.SenderSignatureBuilder{
+		MemberId:  memberId,
+		Signature: signature,
+	}).Build()
+}
+
+func sharesOf(kms []*keymanager.Ed25519BLSKeyManager, members []*keymanager.MemberKeys, blockHeight primitives.BlockHeight, seed []byte) []*protocol.SenderSignature {
+	shares := make([]*protocol.SenderSignature, len(kms))
+	for i, km := range kms {
+		shares[i] = senderSignature(members[i].MemberId, km.SignRandomSeed(context.Background(), blockHeight, seed))
+	}
+	return shares
+}
+
+func TestConsensusMessageSignatureVerification(t *testing.T) {
+	kms, keys := aCommittee(t, 4, 3)
+	content := []byte{1, 2, 3}
+	signer := keys.Members[0].MemberId
+	signature := kms[0].SignConsensusMessage(context.Background(), 10, content)
+
+	require.NoError(t, kms[1].VerifyConsensusMessage(10, content, senderSignature(signer, signature)))
+	require.Error(t, kms[1].VerifyConsensusMessage(11, content, senderSignature(signer, signature)), "signature should be bound to the height")
+	require.Error(t, kms[1].VerifyConsensusMessage(10, []byte{6, 6, 6}, senderSignature(signer, signature)))
+	require.Error(t, kms[1].VerifyConsensusMessage(10, content, senderSignature(keys.Members[2].MemberId, signature)))
+	require.Error(t, kms[1].VerifyConsensusMessage(10, content, senderSignature(primitives.MemberId("unknown"), signature)))
+}
+
+func TestDirectoryMapsMemberIdsToPublicKeys(t *testing.T) {
+	keys, err := keymanager.GenerateCommitteeKeys(2, 1, primitives.MemberId("alice"), primitives.MemberId("bob"))
+	require.NoError(t, err)
+	kms := keyManagersOf(t, keys.Members, keys.Directory)
+
+	signature := kms[0].SignConsensusMessage(context.Background(), 1, []byte("hello"))
+	require.NoError(t, kms[1].VerifyConsensusMessage(1, []byte("hello"), senderSignature(primitives.MemberId("alice"), signature)))
+	require.Error(t, kms[1].VerifyConsensusMessage(1, []byte("hello"), senderSignature(primitives.MemberId("bob"), signature)))
+
+	_, err = keymanager.GenerateCommitteeKeys(3, 2, primitives.MemberId("alice"))
+	require.Error(t, err)
+}
+
+func TestCommitteeKeysRequireAThresholdWithinTheCommittee(t *testing.T) {
+	_, err := keymanager.GenerateCommitteeKeys(4, 0)
+	require.Error(t, err)
+	_, err = keymanager.GenerateCommitteeKeys(4, 5)
+	require.Error(t, err)
+}
+
+func TestKeyPairFromSeedIsDeterministic(t *testing.T) {
+	seed := bytes.Repeat([]byte{7}, 32)
+	k1, err := keymanager.KeyPairFromSeed(seed)
+	require.NoError(t, err)
+	k2, err := keymanager.KeyPairFromSeed(seed)
+	require.NoError(t, err)
+	require.Equal(t, k1.PublicKey, k2.PublicKey)
+
+	_, err = keymanager.KeyPairFromSeed([]byte{1})
+	require.Error(t, err)
+}
+
+func TestRandomSeedShareVerification(t *testing.T) {
+	kms, keys := aCommittee(t, 4, 3)
+	seed := randomseed.RandomSeedToBytes(12345)
+	signer := keys.Members[0].MemberId
+	share := kms[0].SignRandomSeed(context.Background(), 5, seed)
+
+	require.NoError(t, kms[1].VerifyRandomSeed(5, seed, senderSignature(signer, share)))
+	require.Error(t, kms[1].VerifyRandomSeed(5, randomseed.RandomSeedToBytes(54321), senderSignature(signer, share)))
+	require.Error(t, kms[1].VerifyRandomSeed(6, seed, senderSignature(signer, share)))
+	require.Error(t, kms[1].VerifyRandomSeed(5, seed, senderSignature(keys.Members[1].MemberId, share)))
+}
+
+func TestAggregatedRandomSeedRequiresThresholdOfValidShares(t *testing.T) {
+	kms, keys := aCommittee(t, 4, 3)
+	seed := randomseed.RandomSeedToBytes(12345)
+	shares := sharesOf(kms, keys.Members, 5, seed)
+	forged := senderSignature(keys.Members[3].MemberId, kms[0].SignRandomSeed(context.Background(), 5, seed))
+
+	require.Nil(t, kms[0].AggregateRandomSeed(5, shares[:2]))
+	require.Nil(t, kms[0].AggregateRandomSeed(5, []*protocol.SenderSignature{shares[0], shares[1], shares[1]}), "duplicate shares should count once")
+	require.Nil(t, kms[0].AggregateRandomSeed(5, []*protocol.SenderSignature{shares[0], shares[1], forged}), "invalid shares should not count")
+
+	aggregate := kms[0].AggregateRandomSeed(5, shares[:3])
+	require.NotNil(t, aggregate)
+	require.Equal(t, aggregate, kms[2].AggregateRandomSeed(5, shares[1:]), "every subset of shares should yield the same aggregate")
+	require.Equal(t, aggregate, kms[1].AggregateRandomSeed(5, []*protocol.SenderSignature{shares[3], shares[0], shares[2]}))
+
+	master := senderSignature(nil, aggregate)
+	require.NoError(t, kms[3].VerifyRandomSeed(5, seed, master))
+	require.Error(t, kms[3].VerifyRandomSeed(5, randomseed.RandomSeedToBytes(1), master))
+	require.Error(t, kms[3].VerifyRandomSeed(6, seed, master))
+}
+
+func TestAMemberCannotForgeTheAggregatedRandomSeed(t *testing.T) {
+	kms, keys := aCommittee(t, 4, 3)
+	seed := randomseed.RandomSeedToBytes(12345)
+	share := kms[0].SignRandomSeed(context.Background(), 5, seed)
+
+	require.Error(t, kms[1].VerifyRandomSeed(5, seed, senderSignature(nil, share[:48])), "a share should not verify as the aggregate")
+	require.Error(t, kms[1].VerifyRandomSeed(5, seed, senderSignature(nil, share)))
+
+	// members who ignore the threshold and interpolate fewer shares do not get the group key's signature
+	colludingDirectory, err := keymanager.NewPublicKeyDirectory(keys.Directory.RandomSeedPublicKey(), 2)
+	require.NoError(t, err)
+	for _, member := range keys.Members[:2] {
+		require.NoError(t, colludingDirectory.Add(member.MemberId, member.ConsensusKey.PublicKey, publicShareOf(t, keys, member)))
+	}
+	colluders := keyManagersOf(t, keys.Members[:2], colludingDirectory)
+	underThreshold := colluders[0].AggregateRandomSeed(5, sharesOf(colluders, keys.Members[:2], 5, seed))
+	require.NotNil(t, underThreshold)
+	require.Error(t, kms[3].VerifyRandomSeed(5, seed, senderSignature(nil, underThreshold)))
+}
+
+func TestDealtKeySharesSignForTheGroupPublicKey(t *testing.T) {
+	groupPublicKey, shares, publicShares, err := keymanager.DealRandomSeedKeyShares(5, 3)
+	require.NoError(t, err)
+	directory, err := keymanager.NewPublicKeyDirectory(groupPublicKey, 3)
+	require.NoError(t, err)
+
+	members := make([]*keymanager.MemberKeys, 5)
+	for i := range members {
+		keyPair, err := keymanager.GenerateKeyPair()
+		require.NoError(t, err)
+		members[i] = &keymanager.MemberKeys{MemberId: keyPair.MemberId(), ConsensusKey: keyPair, RandomSeedKeyShare: shares[i]}
+		require.NoError(t, directory.Add(members[i].MemberId, keyPair.PublicKey, publicShares[i]))
+	}
+	require.Error(t, directory.Add(primitives.MemberId("other"), members[0].ConsensusKey.PublicKey, publicShares[0]), "share indices should be unique")
+
+	kms := keyManagersOf(t, members, directory)
+	seed := randomseed.RandomSeedToBytes(7)
+	allShares := sharesOf(kms, members, 9, seed)
+	aggregate := kms[4].AggregateRandomSeed(9, allShares[2:])
+	require.NoError(t, kms[0].VerifyRandomSeed(9, seed, senderSignature(nil, aggregate)))
+}
+
+func publicShareOf(t *testing.T, keys *keymanager.CommitteeKeys, member *keymanager.MemberKeys) *keymanager.RandomSeedPublicShare {
+	publicShare, err := keys.Directory.RandomSeedPublicShareOf(member.MemberId)
+	require.NoError(t, err)
+	return publicShare
+}
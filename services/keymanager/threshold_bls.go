@@ -0,0 +1,205 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package keymanager
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	bls "github.com/kilic/bls12-381"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/pkg/errors"
+	"io"
+	"math/big"
+)
+
+// Threshold BLS signatures on BLS12-381 for the random seed: signatures are in G1, public keys in G2.
+// The signing key is split with Shamir secret sharing, a member signs with its share and any randomSeedThreshold
+// share signatures on the same content are interpolated (Lagrange, in the exponent) into the signature of the group key.
+// BLS signatures are unique, so every subset of shares yields the same aggregate, and fewer shares than the threshold
+// reveal nothing about it.
+
+const (
+	blsSignatureSize = 48 // compressed G1
+	blsPublicKeySize = 96 // compressed G2
+	blsSecretSize    = 32
+)
+
+var randomSeedHashToCurveDomain = []byte("LH_RANDOM_SEED_BLS12381G1_XMD:SHA-256_SSWU_RO_")
+
+// A member's share of the random seed signing key
+type RandomSeedKeyShare struct {
+	Index  uint32 // the point at which the sharing polynomial was evaluated, starting at 1
+	Secret []byte // 32 bytes big endian scalar
+}
+
+// The public key matching a RandomSeedKeyShare, used to verify the member's random seed shares
+type RandomSeedPublicShare struct {
+	Index     uint32
+	PublicKey []byte // 96 bytes compressed G2 point
+}
+
+// DealRandomSeedKeyShares splits a fresh random seed signing key into count shares, any threshold of which can sign.
+// Returns the group public key, the secret shares and their public keys; the group secret key is not kept.
+// The dealer sees every share, run it in a trusted setup and hand each member only its own share.
+func DealRandomSeedKeyShares(count int, threshold int) (groupPublicKey []byte, shares []*RandomSeedKeyShare, publicShares []*RandomSeedPublicShare, err error) {
+	return dealRandomSeedKeyShares(rand.Reader, count, threshold)
+}
+
+func dealRandomSeedKeyShares(random io.Reader, count int, threshold int) ([]byte, []*RandomSeedKeyShare, []*RandomSeedPublicShare, error) {
+	if threshold < 1 || threshold > count {
+		return nil, nil, nil, errors.Errorf("DealRandomSeedKeyShares: threshold must be between 1 and %d, got %d", count, threshold)
+	}
+	order := bls.NewG1().Q()
+
+	coefficients := make([]*big.Int, threshold)
+	for i := range coefficients {
+		coefficient, err := rand.Int(random, order)
+		if err != nil {
+			return nil, nil, nil, errors.Wrap(err, "DealRandomSeedKeyShares: failed to generate a polynomial coefficient")
+		}
+		coefficients[i] = coefficient
+	}
+	if coefficients[0].Sign() == 0 {
+		return nil, nil, nil, errors.New("DealRandomSeedKeyShares: generated a zero secret key")
+	}
+
+	g2 := bls.NewG2()
+	shares := make([]*RandomSeedKeyShare, count)
+	publicShares := make([]*RandomSeedPublicShare, count)
+	for i := 0; i < count; i++ {
+		index := uint32(i + 1)
+		secret := evaluatePolynomial(coefficients, index, order)
+		shares[i] = &RandomSeedKeyShare{Index: index, Secret: scalarToBytes(secret)}
+		publicShares[i] = &RandomSeedPublicShare{Index: index, PublicKey: g2.ToCompressed(g2.MulScalarBig(g2.New(), g2.One(), secret))}
+	}
+	groupPublicKey := g2.ToCompressed(g2.MulScalarBig(g2.New(), g2.One(), coefficients[0]))
+	return groupPublicKey, shares, publicShares, nil
+}
+
+func evaluatePolynomial(coefficients []*big.Int, x uint32, order *big.Int) *big.Int {
+	bigX := new(big.Int).SetUint64(uint64(x))
+	result := new(big.Int)
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		result.Mul(result, bigX)
+		result.Add(result, coefficients[i])
+		result.Mod(result, order)
+	}
+	return result
+}
+
+func scalarToBytes(scalar *big.Int) []byte {
+	out := make([]byte, blsSecretSize)
+	bytes := scalar.Bytes()
+	copy(out[blsSecretSize-len(bytes):], bytes)
+	return out
+}
+
+func parseRandomSeedKeyShare(share *RandomSeedKeyShare) (*big.Int, error) {
+	if share == nil || share.Index == 0 {
+		return nil, errors.New("random seed key share must have an index starting at 1")
+	}
+	if len(share.Secret) != blsSecretSize {
+		return nil, errors.Errorf("random seed key share secret must be %d bytes, got %d", blsSecretSize, len(share.Secret))
+	}
+	secret := new(big.Int).SetBytes(share.Secret)
+	if secret.Sign() == 0 || secret.Cmp(bls.NewG1().Q()) >= 0 {
+		return nil, errors.New("random seed key share secret is not a valid scalar")
+	}
+	return secret, nil
+}
+
+func parseBLSPublicKey(publicKey []byte) (*bls.PointG2, error) {
+	g2 := bls.NewG2()
+	point, err := g2.FromCompressed(publicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid BLS public key")
+	}
+	if g2.IsZero(point) {
+		return nil, errors.New("invalid BLS public key: point at infinity")
+	}
+	return point, nil
+}
+
+func parseBLSSignature(g1 *bls.G1, signature []byte) (*bls.PointG1, error) {
+	point, err := g1.FromCompressed(signature)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid BLS signature")
+	}
+	if g1.IsZero(point) {
+		return nil, errors.New("invalid BLS signature: point at infinity")
+	}
+	return point, nil
+}
+
+func randomSeedMessage(g1 *bls.G1, blockHeight primitives.BlockHeight, content []byte) (*bls.PointG1, error) {
+	message := make([]byte, 8, 8+len(content))
+	binary.BigEndian.PutUint64(message, uint64(blockHeight))
+	return g1.HashToCurve(append(message, content...), randomSeedHashToCurveDomain)
+}
+
+func blsSign(secret *big.Int, blockHeight primitives.BlockHeight, content []byte) ([]byte, error) {
+	g1 := bls.NewG1()
+	message, err := randomSeedMessage(g1, blockHeight, content)
+	if err != nil {
+		return nil, err
+	}
+	return g1.ToCompressed(g1.MulScalarBig(g1.New(), message, secret)), nil
+}
+
+// Checks e(signature, g2) == e(H(height, content), publicKey).
+// The points are copied since the pairing engine normalizes its inputs in place and publicKey may be shared.
+func blsVerify(publicKey *bls.PointG2, blockHeight primitives.BlockHeight, content []byte, signature []byte) error {
+	engine := bls.NewEngine()
+	point, err := parseBLSSignature(engine.G1, signature)
+	if err != nil {
+		return err
+	}
+	message, err := randomSeedMessage(engine.G1, blockHeight, content)
+	if err != nil {
+		return err
+	}
+	engine.AddPairInv(point, engine.G2.One())
+	engine.AddPair(message, new(bls.PointG2).Set(publicKey))
+	if !engine.Check() {
+		return errors.New("BLS signature does not match the public key")
+	}
+	return nil
+}
+
+// Interpolates the share signatures at 0; the indices must be distinct and non zero.
+// The result is the group key's signature when len(signatures) is at least the threshold.
+func blsInterpolate(indices []uint32, signatures [][]byte) ([]byte, error) {
+	g1 := bls.NewG1()
+	order := g1.Q()
+	result := g1.Zero()
+	for i := range indices {
+		point, err := parseBLSSignature(g1, signatures[i])
+		if err != nil {
+			return nil, err
+		}
+		g1.Add(result, result, g1.MulScalarBig(g1.New(), point, lagrangeCoefficientAtZero(indices, i, order)))
+	}
+	return g1.ToCompressed(result), nil
+}
+
+// λ_i = Π_{j≠i} x_j / (x_j - x_i) mod order
+func lagrangeCoefficientAtZero(indices []uint32, i int, order *big.Int) *big.Int {
+	numerator := big.NewInt(1)
+	denominator := big.NewInt(1)
+	xi := new(big.Int).SetUint64(uint64(indices[i]))
+	for j, index := range indices {
+		if j == i {
+			continue
+		}
+		xj := new(big.Int).SetUint64(uint64(index))
+		numerator.Mul(numerator, xj)
+		numerator.Mod(numerator, order)
+		denominator.Mul(denominator, new(big.Int).Sub(xj, xi))
+		denominator.Mod(denominator, order)
+	}
+	return numerator.Mul(numerator, denominator.ModInverse(denominator, order)).Mod(numerator, order)
+}
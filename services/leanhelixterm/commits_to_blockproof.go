@@ -15,9 +15,9 @@ import (
 	"strings"
 )
 
-func CommitsToProof(log logger.LHLogger, keyManager interfaces.KeyManager, onCommit interfaces.OnCommitCallback) termincommittee.OnInCommitteeCommitCallback {
+func CommitsToProof(log logger.LHLogger, beacon interfaces.RandomnessBeacon, randomSeed uint64, onCommit interfaces.OnCommitCallback) termincommittee.OnInCommitteeCommitCallback {
 	return func(ctx context.Context, block interfaces.Block, commitMessages []*interfaces.CommitMessage) {
-		proof := blockproof.GenerateLeanHelixBlockProof(keyManager, commitMessages)
+		proof := blockproof.GenerateLeanHelixBlockProof(beacon, randomSeed, commitMessages)
 		committeeStr := commitMessagesToCommitteeMemberIdsStr(commitMessages)
 		height := block.Height()
 		log.Debug("Generated block proof for H=%d with committee-size=%d, committee-members=%s", height, len(commitMessages), committeeStr)
@@ -0,0 +1,92 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package leanhelixterm
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/services/blockheight"
+	"github.com/orbs-network/lean-helix-go/services/blockreferencetime"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/services/randomseed"
+	"github.com/orbs-network/lean-helix-go/services/termincommittee"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
+	"github.com/pkg/errors"
+)
+
+// CommitteeAudit explains who could lead a committed height: the random seed derived from the previous proof,
+// the committee ordered by that seed, and the leader of every view up to the view the block was committed at
+type CommitteeAudit struct {
+	BlockHeight    primitives.BlockHeight
+	RandomSeed     uint64
+	Committee      []interfaces.CommitteeMember
+	CommittedView  primitives.View
+	NextRandomSeed uint64 // derived from the audited proof, orders the committee of the next height
+}
+
+func (a *CommitteeAudit) LeaderOfView(view primitives.View) primitives.MemberId {
+	return termincommittee.CalcLeaderOfViewAndCommittee(view, a.Committee)
+}
+
+func (a *CommitteeAudit) CommittedLeader() primitives.MemberId {
+	return a.LeaderOfView(a.CommittedView)
+}
+
+// Leaders returns the leader of each view, indexed by view, up to and including CommittedView
+func (a *CommitteeAudit) Leaders() []primitives.MemberId {
+	leaders := make([]primitives.MemberId, 0, int(a.CommittedView)+1)
+	for view := primitives.View(0); view <= a.CommittedView; view++ {
+		leaders = append(leaders, a.LeaderOfView(view))
+	}
+	return leaders
+}
+
+// Derives the random seed of the term following prevBlock, exactly as consensus does
+func termRandomSeed(beacon interfaces.RandomnessBeacon, prevBlock interfaces.Block, prevBlockProof *protocol.BlockProof) (primitives.BlockHeight, uint64) {
+	blockHeight := blockheight.GetBlockHeight(prevBlock) + 1
+	return blockHeight, beacon.DeriveRandomSeed(blockHeight, prevBlockProof)
+}
+
+// AuditCommittee recomputes the committee ordering of the height committed by blockProofBytes.
+// The block proof's random seed signature is validated against the seed, so a manipulated seed chain is detected.
+func AuditCommittee(ctx context.Context, config *interfaces.Config, blockProofBytes []byte, prevBlock interfaces.Block, prevBlockProofBytes []byte) (*CommitteeAudit, error) {
+	if len(blockProofBytes) == 0 {
+		return nil, errors.New("AuditCommittee: nil blockProof")
+	}
+	blockProof := protocol.BlockProofReader(blockProofBytes)
+	blockRef := blockProof.BlockRef()
+	if blockRef.MessageType() != protocol.LEAN_HELIX_COMMIT {
+		return nil, errors.Errorf("AuditCommittee: blockProof is not of COMMIT, it is %v", blockRef.MessageType())
+	}
+
+	beacon := randomseed.RandomnessBeaconOf(config)
+	prevBlockProof := protocol.BlockProofReader(prevBlockProofBytes)
+	blockHeight, randomSeed := termRandomSeed(beacon, prevBlock, prevBlockProof)
+	if blockRef.BlockHeight() != blockHeight {
+		return nil, errors.Errorf("AuditCommittee: blockProof is of H=%d but prevBlock is of H=%d", blockRef.BlockHeight(), blockHeight-1)
+	}
+
+	if err := randomseed.ValidateRandomSeed(beacon, blockHeight, blockProof, prevBlockProof); err != nil {
+		return nil, errors.Wrapf(err, "AuditCommittee: random seed signature of H=%d does not match the seed derived from the previous proof", blockHeight)
+	}
+
+	committee, err := config.Membership.RequestOrderedCommittee(ctx, blockHeight, randomSeed, blockreferencetime.GetBlockReferenceTime(prevBlock))
+	if err != nil {
+		return nil, errors.Wrapf(err, "AuditCommittee: failed to get ordered committee of H=%d", blockHeight)
+	}
+	if len(committee) == 0 {
+		return nil, errors.Errorf("AuditCommittee: empty committee for H=%d", blockHeight)
+	}
+
+	return &CommitteeAudit{
+		BlockHeight:    blockHeight,
+		RandomSeed:     randomSeed,
+		Committee:      committee,
+		CommittedView:  blockRef.View(),
+		NextRandomSeed: beacon.DeriveRandomSeed(blockHeight+1, blockProof),
+	}, nil
+}
@@ -9,7 +9,6 @@ package leanhelixterm
 import (
 	"fmt"
 	"github.com/orbs-network/lean-helix-go/services/interfaces"
-	"github.com/orbs-network/lean-helix-go/services/randomseed"
 	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
 	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
 	"github.com/pkg/errors"
@@ -17,17 +16,17 @@ import (
 
 type ConsensusMessagesFilter struct {
 	handler    TermMessagesHandler
-	keyManager interfaces.KeyManager
+	beacon     interfaces.RandomnessBeacon
 	randomSeed uint64
 }
 
-func NewConsensusMessagesFilter(handler TermMessagesHandler, keyManager interfaces.KeyManager, randomSeed uint64) *ConsensusMessagesFilter {
-	return &ConsensusMessagesFilter{handler, keyManager, randomSeed}
+func NewConsensusMessagesFilter(handler TermMessagesHandler, beacon interfaces.RandomnessBeacon, randomSeed uint64) *ConsensusMessagesFilter {
+	return &ConsensusMessagesFilter{handler, beacon, randomSeed}
 }
 
 func (mp *ConsensusMessagesFilter) HandleConsensusMessage(message interfaces.ConsensusMessage) error {
 	if mp.handler == nil {
-		return errors.Errorf("Out of committee - ignoring message %s H=%d V=%d", message.MessageType(), message.BlockHeight(), message.View())
+		return errors.Wrapf(interfaces.ErrOutOfCommittee, "ignoring message %s H=%d V=%d", message.MessageType(), message.BlockHeight(), message.View())
 	}
 
 	switch message := message.(type) {
@@ -43,9 +42,8 @@ func (mp *ConsensusMessagesFilter) HandleConsensusMessage(message interfaces.Con
 			Signature: primitives.Signature(message.Content().Share()),
 		}).Build()
 
-		randomSeedBytes := randomseed.RandomSeedToBytes(mp.randomSeed)
-		if err := mp.keyManager.VerifyRandomSeed(message.BlockHeight(), randomSeedBytes, senderSignature); err != nil {
-			return errors.Wrapf(err, "Failed in VerifyRandomSeed()")
+		if err := mp.beacon.VerifyShare(message.BlockHeight(), mp.randomSeed, senderSignature); err != nil {
+			return errors.Wrapf(interfaces.ErrInvalidSignature, "Failed in VerifyRandomSeed(): %s", err)
 		}
 		mp.handler.HandleCommit(message)
 
@@ -55,9 +53,41 @@ func (mp *ConsensusMessagesFilter) HandleConsensusMessage(message interfaces.Con
 	case *interfaces.NewViewMessage:
 		mp.handler.HandleNewView(message)
 
+	case *interfaces.MessagesRequestMessage:
+		mp.handler.HandleMessagesRequest(message)
+
+	case *interfaces.MessagesResponseMessage:
+		// retransmitted messages are authenticated by their original signatures and handled as if received from their senders
+		for _, pm := range message.PrepareMessages() {
+			if err := mp.handleRetransmittedMessage(message, pm); err != nil {
+				return err
+			}
+		}
+		for _, cm := range message.CommitMessages() {
+			if err := mp.handleRetransmittedMessage(message, cm); err != nil {
+				return err
+			}
+		}
+
+	case *interfaces.BlockRequestMessage:
+		mp.handler.HandleBlockRequest(message)
+
+	case *interfaces.BlockResponseMessage:
+		mp.handler.HandleBlockResponse(message)
+
+	case *interfaces.BlockChunkMessage:
+		mp.handler.HandleBlockChunk(message)
+
 	default:
 		panic(fmt.Sprintf("unknown message type: %T", message))
 	}
 
 	return nil
 }
+
+func (mp *ConsensusMessagesFilter) handleRetransmittedMessage(response *interfaces.MessagesResponseMessage, message interfaces.ConsensusMessage) error {
+	if message.InstanceId() != response.InstanceId() || message.BlockHeight() != response.BlockHeight() {
+		return errors.Wrapf(interfaces.ErrMalformedMessage, "MESSAGES_RESPONSE for H=%d contains %s of H=%d", response.BlockHeight(), message.MessageType(), message.BlockHeight())
+	}
+	return mp.HandleConsensusMessage(message)
+}
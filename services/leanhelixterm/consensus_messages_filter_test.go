@@ -9,6 +9,8 @@ package leanhelixterm
 import (
 	"context"
 	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/services/messagesfactory"
+	"github.com/orbs-network/lean-helix-go/services/randomseed"
 	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
 	"github.com/orbs-network/lean-helix-go/test"
 	"github.com/orbs-network/lean-helix-go/test/builders"
@@ -58,7 +60,7 @@ func TestProcessingAMessage(t *testing.T) {
 		instanceId := primitives.InstanceId(rand.Uint64())
 		messagesHandler := mocks.NewTermMessagesHandlerMock()
 		keyManager := mocks.NewMockKeyManager(primitives.MemberId("My ID"))
-		consensusMessagesFilter := NewConsensusMessagesFilter(messagesHandler, keyManager, 99)
+		consensusMessagesFilter := NewConsensusMessagesFilter(messagesHandler, randomseed.NewKeyManagerRandomnessBeacon(keyManager), 99)
 
 		ppm := GeneratePreprepareMessage(instanceId, 10, 20, "Sender MemberId")
 		pm := GeneratePrepareMessage(instanceId, 10, 20, "Sender MemberId")
@@ -97,7 +99,7 @@ func TestFilteringACommitWithBadSeed(t *testing.T) {
 		instanceId := primitives.InstanceId(rand.Uint64())
 		messagesHandler := mocks.NewTermMessagesHandlerMock()
 		keyManager := mocks.NewMockKeyManager(primitives.MemberId("My ID"))
-		consensusMessagesFilter := NewConsensusMessagesFilter(messagesHandler, keyManager, 99)
+		consensusMessagesFilter := NewConsensusMessagesFilter(messagesHandler, randomseed.NewKeyManagerRandomnessBeacon(keyManager), 99)
 
 		goodCommit := GenerateCommitMessage(instanceId, 10, 20, "Sender MemberId", 99)
 		badCommit := GenerateCommitMessage(instanceId, 10, 20, "Sender MemberId", 666)
@@ -116,7 +118,7 @@ func TestNotSendingMessagesWhenTheHandlerWasNotSet(t *testing.T) {
 	test.WithContext(func(ctx context.Context) {
 		instanceId := primitives.InstanceId(rand.Uint64())
 		keyManager := mocks.NewMockKeyManager(primitives.MemberId("My ID"))
-		consensusMessagesFilter := NewConsensusMessagesFilter(nil, keyManager, 99)
+		consensusMessagesFilter := NewConsensusMessagesFilter(nil, randomseed.NewKeyManagerRandomnessBeacon(keyManager), 99)
 
 		ppm := GeneratePreprepareMessage(instanceId, 10, 20, "Sender MemberId")
 		pm := GeneratePrepareMessage(instanceId, 10, 20, "Sender MemberId")
@@ -133,3 +135,54 @@ func TestNotSendingMessagesWhenTheHandlerWasNotSet(t *testing.T) {
 		// expect that we don't panic
 	})
 }
+
+func TestMessagesResponseIsUnpackedIntoRetransmittedMessages(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		instanceId := primitives.InstanceId(rand.Uint64())
+		messagesHandler := mocks.NewTermMessagesHandlerMock()
+		keyManager := mocks.NewMockKeyManager(primitives.MemberId("My ID"))
+		consensusMessagesFilter := NewConsensusMessagesFilter(messagesHandler, randomseed.NewKeyManagerRandomnessBeacon(keyManager), 99)
+
+		pm1 := interfaces.ToConsensusMessage(GeneratePrepareMessage(instanceId, 10, 20, "Sender 1")).(*interfaces.PrepareMessage)
+		pm2 := interfaces.ToConsensusMessage(GeneratePrepareMessage(instanceId, 10, 20, "Sender 2")).(*interfaces.PrepareMessage)
+		cm := interfaces.ToConsensusMessage(GenerateCommitMessage(instanceId, 10, 20, "Sender 1", 99)).(*interfaces.CommitMessage)
+		responder := messagesfactory.NewMessageFactory(instanceId, mocks.NewMockKeyManager(primitives.MemberId("Responder")), primitives.MemberId("Responder"), 99)
+		response := responder.CreateMessagesResponseMessage(10, 20, []*interfaces.PrepareMessage{pm1, pm2}, []*interfaces.CommitMessage{cm})
+
+		err := consensusMessagesFilter.HandleConsensusMessage(interfaces.ToConsensusMessage(response.ToConsensusRawMessage()))
+		require.NoError(t, err)
+		require.Len(t, messagesHandler.HistoryP, 2)
+		require.Equal(t, primitives.MemberId("Sender 2"), messagesHandler.HistoryP[1].SenderMemberId())
+		require.Len(t, messagesHandler.HistoryC, 1)
+
+		request := responder.CreateMessagesRequestMessage(10, 20)
+		require.NoError(t, consensusMessagesFilter.HandleConsensusMessage(interfaces.ToConsensusMessage(request.ToConsensusRawMessage())))
+		require.Len(t, messagesHandler.HistoryMR, 1)
+
+		otherHeight := interfaces.ToConsensusMessage(GeneratePrepareMessage(instanceId, 11, 20, "Sender 1")).(*interfaces.PrepareMessage)
+		mismatched := responder.CreateMessagesResponseMessage(10, 20, []*interfaces.PrepareMessage{otherHeight}, nil)
+		require.Error(t, consensusMessagesFilter.HandleConsensusMessage(mismatched), "retransmitted messages must be of the requested height")
+		require.Len(t, messagesHandler.HistoryP, 2)
+	})
+}
+
+func TestBlockRequestAndResponseAreRoutedToHandler(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		instanceId := primitives.InstanceId(rand.Uint64())
+		messagesHandler := mocks.NewTermMessagesHandlerMock()
+		keyManager := mocks.NewMockKeyManager(primitives.MemberId("My ID"))
+		consensusMessagesFilter := NewConsensusMessagesFilter(messagesHandler, randomseed.NewKeyManagerRandomnessBeacon(keyManager), 99)
+
+		block := mocks.ABlock(interfaces.GenesisBlock)
+		peer := messagesfactory.NewMessageFactory(instanceId, mocks.NewMockKeyManager(primitives.MemberId("Peer")), primitives.MemberId("Peer"), 99)
+		request := peer.CreateBlockRequestMessage(10, 20, mocks.CalculateBlockHash(block))
+		response := peer.CreateBlockResponseMessage(10, 20, mocks.CalculateBlockHash(block), block)
+
+		require.NoError(t, consensusMessagesFilter.HandleConsensusMessage(interfaces.ToConsensusMessage(request.ToConsensusRawMessage())))
+		require.NoError(t, consensusMessagesFilter.HandleConsensusMessage(interfaces.ToConsensusMessage(response.ToConsensusRawMessage())))
+
+		require.Len(t, messagesHandler.HistoryBRQ, 1)
+		require.Len(t, messagesHandler.HistoryBRS, 1)
+		require.Equal(t, block, messagesHandler.HistoryBRS[0].Block(), "the block should travel alongside the response")
+	})
+}
@@ -10,8 +10,8 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
-	"github.com/orbs-network/lean-helix-go/services/blockheight"
 	"github.com/orbs-network/lean-helix-go/services/blockreferencetime"
+	"github.com/orbs-network/lean-helix-go/services/events"
 	"github.com/orbs-network/lean-helix-go/services/interfaces"
 	"github.com/orbs-network/lean-helix-go/services/logger"
 	"github.com/orbs-network/lean-helix-go/services/messagesfactory"
@@ -33,13 +33,13 @@ type LeanHelixTerm struct {
 	termInCommittee *termincommittee.TermInCommittee
 }
 
-func NewLeanHelixTerm(ctx context.Context, logger logger.LHLogger, config *interfaces.Config, state *state.State, electionTrigger interfaces.ElectionScheduler, onCommit interfaces.OnCommitCallback, prevBlock interfaces.Block, prevBlockProofBytes []byte, canBeFirstLeader bool) *LeanHelixTerm {
+func NewLeanHelixTerm(ctx context.Context, logger logger.LHLogger, config *interfaces.Config, state *state.State, electionTrigger interfaces.ElectionScheduler, onCommit interfaces.OnCommitCallback, prevBlock interfaces.Block, prevBlockProofBytes []byte, canBeFirstLeader bool, blockValidationScheduler interfaces.BlockValidationScheduler, publisher *events.Publisher) *LeanHelixTerm {
 	prevBlockProof := protocol.BlockProofReader(prevBlockProofBytes)
-	randomSeed := randomseed.CalculateRandomSeed(prevBlockProof.RandomSeedSignature())
-	blockHeight := blockheight.GetBlockHeight(prevBlock) + 1
+	beacon := randomseed.RandomnessBeaconOf(config)
+	blockHeight, randomSeed := termRandomSeed(beacon, prevBlock, prevBlockProof)
 	prevBlockRefTime := blockreferencetime.GetBlockReferenceTime(prevBlock)
 	myMemberId := config.Membership.MyMemberId()
-	messageFactory := messagesfactory.NewMessageFactory(config.InstanceId, config.KeyManager, myMemberId, randomSeed)
+	messageFactory := messagesfactory.NewMessageFactoryWithRandomnessBeacon(config.InstanceId, config.KeyManager, beacon, myMemberId, randomSeed)
 
 	committeeMembers, err := requestOrderedCommitteePersist(state, blockHeight, randomSeed, prevBlockRefTime, config, logger)
 	if err != nil {
@@ -49,16 +49,20 @@ func NewLeanHelixTerm(ctx context.Context, logger logger.LHLogger, config *inter
 	isParticipating := isParticipatingInTerm(myMemberId, committeeMembers)
 
 	if !isParticipating {
+		if ctx.Err() == nil {
+			publisher.Publish(&events.Event{Type: events.OUT_OF_COMMITTEE, Height: blockHeight, Committee: termincommittee.GetMemberIds(committeeMembers)})
+		}
 		logger.Debug("OUT OF COMMITTEE: H=%d, prevBlockProof=%s, randomSeed=%d, members=%s, isParticipating=%t", blockHeight, printShortBlockProofBytes(prevBlockProofBytes), randomSeed, termincommittee.ToCommitteeMembersStr(committeeMembers), isParticipating)
-		return termNotInCommittee(randomSeed, config)
+		return termNotInCommittee(beacon, randomSeed)
 	}
 
 	logger.Debug("RECEIVED COMMITTEE: H=%d, prevBlockProof=%s, randomSeed=%d, refTime=%d, members=%s, isParticipating=%t", blockHeight, printShortBlockProofBytes(prevBlockProofBytes), randomSeed, prevBlockRefTime, termincommittee.ToCommitteeMembersStr(committeeMembers), isParticipating)
 	logger.ConsensusTrace("got committee for the current consensus round", nil, log.StringableSlice("committee", termincommittee.GetMemberIds(committeeMembers)))
 
-	termInCommittee := termincommittee.NewTermInCommittee(logger, config, state, messageFactory, electionTrigger, committeeMembers, prevBlock, canBeFirstLeader, CommitsToProof(logger, config.KeyManager, onCommit))
+	publisher.Publish(&events.Event{Type: events.TERM_STARTED, Height: blockHeight, Committee: termincommittee.GetMemberIds(committeeMembers)})
+	termInCommittee := termincommittee.NewTermInCommittee(logger, config, state, messageFactory, electionTrigger, committeeMembers, prevBlock, canBeFirstLeader, CommitsToProof(logger, beacon, randomSeed, onCommit), blockValidationScheduler, publisher)
 	return &LeanHelixTerm{
-		ConsensusMessagesFilter: NewConsensusMessagesFilter(termInCommittee, config.KeyManager, randomSeed),
+		ConsensusMessagesFilter: NewConsensusMessagesFilter(termInCommittee, beacon, randomSeed),
 		termInCommittee:         termInCommittee,
 	}
 }
@@ -100,9 +104,9 @@ func requestOrderedCommitteePersist(s *state.State, blockHeight primitives.Block
 	}
 }
 
-func termNotInCommittee(randomSeed uint64, config *interfaces.Config) *LeanHelixTerm {
+func termNotInCommittee(beacon interfaces.RandomnessBeacon, randomSeed uint64) *LeanHelixTerm {
 	return &LeanHelixTerm{
-		ConsensusMessagesFilter: NewConsensusMessagesFilter(nil, config.KeyManager, randomSeed),
+		ConsensusMessagesFilter: NewConsensusMessagesFilter(nil, beacon, randomSeed),
 		termInCommittee:         nil,
 	}
 }
@@ -114,6 +118,32 @@ func (lht *LeanHelixTerm) Dispose() {
 	}
 }
 
+// Returns nil when this node is not a member of the term's committee
+func (lht *LeanHelixTerm) CommitteeMemberIds() []primitives.MemberId {
+	if lht.termInCommittee == nil {
+		return nil
+	}
+	return lht.termInCommittee.CommitteeMemberIds()
+}
+
+func (lht *LeanHelixTerm) RequestMissingMessages() {
+	if lht.termInCommittee != nil {
+		lht.termInCommittee.RequestMissingMessages()
+	}
+}
+
+func (lht *LeanHelixTerm) RequestViewChange(height primitives.BlockHeight, view primitives.View, updateMetrics interfaces.OnElectionCallback) {
+	if lht.termInCommittee != nil {
+		lht.termInCommittee.RequestViewChange(height, view, updateMetrics)
+	}
+}
+
+func (lht *LeanHelixTerm) RebroadcastOwnMessages(baseInterval time.Duration, now time.Time) {
+	if lht.termInCommittee != nil {
+		lht.termInCommittee.RebroadcastOwnMessages(baseInterval, now)
+	}
+}
+
 func isParticipatingInTerm(myMemberId primitives.MemberId, committeeMembers []interfaces.CommitteeMember) bool {
 	for _, committeeMember := range committeeMembers {
 		if myMemberId.Equal(committeeMember.Id) {
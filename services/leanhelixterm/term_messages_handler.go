@@ -16,4 +16,8 @@ type TermMessagesHandler interface {
 	HandleViewChange(vcm *interfaces.ViewChangeMessage)
 	HandleCommit(cm *interfaces.CommitMessage)
 	HandleNewView(nvm *interfaces.NewViewMessage)
+	HandleMessagesRequest(mrm *interfaces.MessagesRequestMessage)
+	HandleBlockRequest(brq *interfaces.BlockRequestMessage)
+	HandleBlockResponse(brs *interfaces.BlockResponseMessage)
+	HandleBlockChunk(bcm *interfaces.BlockChunkMessage)
 }
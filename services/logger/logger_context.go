@@ -15,6 +15,7 @@ import (
 	"github.com/orbs-network/lean-helix-go/state"
 	"github.com/orbs-network/scribe/log"
 	"math"
+	"sync/atomic"
 	"time"
 )
 
@@ -53,6 +54,7 @@ type lhLogger struct {
 	config         *interfaces.Config
 	state          *state.State
 	externalLogger interfaces.Logger
+	level          int32
 }
 
 func (l *lhLogger) ExternalLogger() interfaces.Logger {
@@ -74,7 +76,15 @@ const (
 	LOG_LEVEL_ERROR int = 3
 )
 
+// Messages below level are dropped, ConsensusTrace is not affected
+func (l *lhLogger) SetLevel(level int) {
+	atomic.StoreInt32(&l.level, int32(level))
+}
+
 func (l *lhLogger) log(level int, format string, args ...interface{}) {
+	if level < int(atomic.LoadInt32(&l.level)) {
+		return
+	}
 	var f func(format string, args ...interface{})
 	switch level {
 	case LOG_LEVEL_INFO:
@@ -145,6 +155,7 @@ type LHLogger interface {
 	Error(format string, args ...interface{})
 	ExternalLogger() interfaces.Logger
 	ConsensusTrace(msg string, err error, fields ...*log.Field)
+	SetLevel(level int)
 }
 
 func LC(h primitives.BlockHeight, v primitives.View, id primitives.MemberId) *_LC {
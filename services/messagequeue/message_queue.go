@@ -0,0 +1,203 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package messagequeue
+
+import (
+	"container/list"
+	"github.com/orbs-network/lean-helix-go/instrumentation/metrics"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
+	"sort"
+	"sync"
+)
+
+// Capacity used when Config.MsgChanBufLen is not set
+const DEFAULT_CAPACITY = 1000
+
+type priority int
+
+const (
+	stalePriority         priority = iota // below the current height, the filter drops most of these anyway
+	futurePriority                        // cached by the filter until their height starts
+	currentHeightPriority                 // PREPREPARE, PREPARE, VIEW_CHANGE and requests of the current height
+	decisivePriority                      // COMMIT and NEW_VIEW of the current height and commit certificates, which end a view or a term
+	priorities
+)
+
+type queuedMessage struct {
+	message     *interfaces.ConsensusRawMessage
+	messageType protocol.MessageType
+	blockHeight primitives.BlockHeight
+	arrival     uint64
+}
+
+// MessageQueue is a bounded queue of consensus messages between the MainLoop and the WorkerLoop.
+// Messages of the current height are popped before future and stale ones, in arrival order. Priorities are
+// relative to the current height and are re-evaluated when it changes. When the queue is full, the oldest
+// message of the lowest priority is evicted, or the pushed message is dropped if nothing queued is less valuable,
+// so COMMIT and NEW_VIEW of the current height are the last to go.
+type MessageQueue struct {
+	lock          sync.Mutex
+	capacity      int
+	currentHeight func() primitives.BlockHeight
+	rankedHeight  primitives.BlockHeight
+	queues        [priorities]*list.List
+	length        int
+	arrivals      uint64
+	ready         chan struct{}
+	dropped       map[protocol.MessageType]uint64
+}
+
+func NewMessageQueue(capacity int, currentHeight func() primitives.BlockHeight) *MessageQueue {
+	if capacity < 1 {
+		capacity = DEFAULT_CAPACITY
+	}
+	q := &MessageQueue{
+		capacity:      capacity,
+		currentHeight: currentHeight,
+		ready:         make(chan struct{}, 1),
+		dropped:       make(map[protocol.MessageType]uint64),
+	}
+	for i := range q.queues {
+		q.queues[i] = list.New()
+	}
+	return q
+}
+
+// Push never blocks
+func (q *MessageQueue) Push(message *interfaces.ConsensusRawMessage) {
+	parsedMessage := interfaces.ToConsensusMessage(message)
+	if parsedMessage == nil {
+		return
+	}
+	item := &queuedMessage{
+		message:     message,
+		messageType: parsedMessage.MessageType(),
+		blockHeight: parsedMessage.BlockHeight(),
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.arrivals++
+	item.arrival = q.arrivals
+	q.rerankIfHeightChanged()
+	p := q.priorityOf(item)
+	if q.length == q.capacity {
+		lowest := q.lowestQueued()
+		if lowest > p {
+			q.dropped[item.messageType]++
+			return
+		}
+		evicted := q.queues[lowest].Remove(q.queues[lowest].Front()).(*queuedMessage)
+		q.dropped[evicted.messageType]++
+		q.length--
+	}
+	q.queues[p].PushBack(item)
+	q.length++
+	q.signalReady()
+}
+
+// Ready fires once there is a message to Pop
+func (q *MessageQueue) Ready() <-chan struct{} {
+	return q.ready
+}
+
+// Pop returns nil when the queue is empty
+func (q *MessageQueue) Pop() *interfaces.ConsensusRawMessage {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.rerankIfHeightChanged()
+	queue := q.nextQueue()
+	if queue == nil {
+		return nil
+	}
+	front := queue.Front()
+	queue.Remove(front)
+	q.length--
+	if q.length > 0 {
+		q.signalReady()
+	}
+	return front.Value.(*queuedMessage).message
+}
+
+func (q *MessageQueue) Metrics() metrics.MessageQueueMetrics {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	dropped := make(map[protocol.MessageType]uint64, len(q.dropped))
+	for messageType, count := range q.dropped {
+		dropped[messageType] = count
+	}
+	return metrics.NewMessageQueueMetrics(q.length, q.capacity, dropped)
+}
+
+func (q *MessageQueue) signalReady() {
+	select {
+	case q.ready <- struct{}{}:
+	default: // already signaled
+	}
+}
+
+// Messages of the current height are popped in arrival order whatever their priority, so a COMMIT is never
+// handled before the PREPREPARE it follows. Future messages come next and stale ones last.
+func (q *MessageQueue) nextQueue() *list.List {
+	current, decisive := q.queues[currentHeightPriority].Front(), q.queues[decisivePriority].Front()
+	switch {
+	case current != nil && (decisive == nil || current.Value.(*queuedMessage).arrival < decisive.Value.(*queuedMessage).arrival):
+		return q.queues[currentHeightPriority]
+	case decisive != nil:
+		return q.queues[decisivePriority]
+	case q.queues[futurePriority].Len() > 0:
+		return q.queues[futurePriority]
+	case q.queues[stalePriority].Len() > 0:
+		return q.queues[stalePriority]
+	}
+	return nil
+}
+
+func (q *MessageQueue) lowestQueued() priority {
+	for p := stalePriority; p < priorities; p++ {
+		if q.queues[p].Len() > 0 {
+			return p
+		}
+	}
+	return stalePriority
+}
+
+func (q *MessageQueue) priorityOf(item *queuedMessage) priority {
+	switch {
+	case item.blockHeight < q.rankedHeight:
+		return stalePriority
+	case item.messageType == protocol.LEAN_HELIX_COMMIT_CERTIFICATE:
+		return decisivePriority
+	case item.blockHeight > q.rankedHeight:
+		return futurePriority
+	case item.messageType == protocol.LEAN_HELIX_COMMIT || item.messageType == protocol.LEAN_HELIX_NEW_VIEW:
+		return decisivePriority
+	default:
+		return currentHeightPriority
+	}
+}
+
+func (q *MessageQueue) rerankIfHeightChanged() {
+	height := q.currentHeight()
+	if height == q.rankedHeight {
+		return
+	}
+	q.rankedHeight = height
+	var items []*queuedMessage
+	for _, queue := range q.queues {
+		for e := queue.Front(); e != nil; e = e.Next() {
+			items = append(items, e.Value.(*queuedMessage))
+		}
+		queue.Init()
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].arrival < items[j].arrival })
+	for _, item := range items {
+		q.queues[q.priorityOf(item)].PushBack(item)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package test
+
+import (
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/services/messagequeue"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
+	"github.com/orbs-network/lean-helix-go/test/builders"
+	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+var sender = primitives.MemberId("sender")
+var block = mocks.ABlock(interfaces.GenesisBlock)
+
+func aPrepare(blockHeight primitives.BlockHeight, view primitives.View) *interfaces.ConsensusRawMessage {
+	return builders.APrepareMessage(123, mocks.NewMockKeyManager(sender), sender, blockHeight, view, block).ToConsensusRawMessage()
+}
+
+func aCommit(blockHeight primitives.BlockHeight, view primitives.View) *interfaces.ConsensusRawMessage {
+	return builders.ACommitMessage(123, mocks.NewMockKeyManager(sender), sender, blockHeight, view, block, 0).ToConsensusRawMessage()
+}
+
+func pop(t *testing.T, q *messagequeue.MessageQueue) interfaces.ConsensusMessage {
+	select {
+	case <-q.Ready():
+	default:
+		t.Fatal("queue should be ready")
+	}
+	message := q.Pop()
+	require.NotNil(t, message)
+	return interfaces.ToConsensusMessage(message)
+}
+
+func heightOf(h *primitives.BlockHeight) func() primitives.BlockHeight {
+	return func() primitives.BlockHeight { return *h }
+}
+
+func TestMessagesOfTheCurrentHeightArePoppedBeforeFutureAndStaleOnes(t *testing.T) {
+	height := primitives.BlockHeight(5)
+	q := messagequeue.NewMessageQueue(10, heightOf(&height))
+
+	q.Push(aPrepare(6, 0))
+	q.Push(aPrepare(5, 0))
+	q.Push(aPrepare(4, 0))
+	q.Push(aCommit(5, 0))
+
+	require.Equal(t, protocol.LEAN_HELIX_PREPARE, pop(t, q).MessageType(), "messages of the current height should keep their arrival order")
+	require.Equal(t, protocol.LEAN_HELIX_COMMIT, pop(t, q).MessageType())
+	require.Equal(t, primitives.BlockHeight(6), pop(t, q).BlockHeight(), "future messages should be popped before stale ones")
+	require.Equal(t, primitives.BlockHeight(4), pop(t, q).BlockHeight())
+	require.Nil(t, q.Pop())
+}
+
+func TestMessagesOfTheSamePriorityArePoppedInArrivalOrder(t *testing.T) {
+	height := primitives.BlockHeight(5)
+	q := messagequeue.NewMessageQueue(10, heightOf(&height))
+
+	for view := primitives.View(0); view < 5; view++ {
+		q.Push(aPrepare(5, view))
+	}
+
+	for view := primitives.View(0); view < 5; view++ {
+		require.Equal(t, view, pop(t, q).View())
+	}
+}
+
+func TestOverflowEvictsTheOldestMessageOfTheLowestPriority(t *testing.T) {
+	height := primitives.BlockHeight(5)
+	q := messagequeue.NewMessageQueue(3, heightOf(&height))
+
+	q.Push(aPrepare(4, 0))
+	q.Push(aPrepare(4, 1))
+	q.Push(aPrepare(5, 0))
+	q.Push(aCommit(5, 0))
+
+	require.Equal(t, 3, q.Metrics().Length())
+	require.Equal(t, uint64(1), q.Metrics().DroppedOf(protocol.LEAN_HELIX_PREPARE))
+	require.Equal(t, protocol.LEAN_HELIX_PREPARE, pop(t, q).MessageType())
+	require.Equal(t, protocol.LEAN_HELIX_COMMIT, pop(t, q).MessageType())
+	require.Equal(t, primitives.View(1), pop(t, q).View(), "the oldest stale PREPARE should have been evicted")
+}
+
+func TestOverflowDropsAPushedMessageLessValuableThanAllQueued(t *testing.T) {
+	height := primitives.BlockHeight(5)
+	q := messagequeue.NewMessageQueue(2, heightOf(&height))
+
+	q.Push(aCommit(5, 0))
+	q.Push(aCommit(5, 1))
+	q.Push(aPrepare(6, 0))
+
+	require.Equal(t, uint64(1), q.Metrics().Dropped())
+	require.Equal(t, primitives.View(0), pop(t, q).View())
+	require.Equal(t, primitives.View(1), pop(t, q).View())
+	require.Nil(t, q.Pop())
+}
+
+func TestMessagesAreRerankedWhenTheHeightChanges(t *testing.T) {
+	height := primitives.BlockHeight(5)
+	q := messagequeue.NewMessageQueue(10, heightOf(&height))
+
+	q.Push(aPrepare(5, 0))
+	q.Push(aCommit(6, 0))
+
+	height = 6
+	require.Equal(t, primitives.BlockHeight(6), pop(t, q).BlockHeight(), "messages of the new current height should come first")
+	require.Equal(t, primitives.BlockHeight(5), pop(t, q).BlockHeight())
+}
+
+func TestCapacityDefaultsWhenNotConfigured(t *testing.T) {
+	height := primitives.BlockHeight(1)
+	q := messagequeue.NewMessageQueue(0, heightOf(&height))
+	require.Equal(t, messagequeue.DEFAULT_CAPACITY, q.Metrics().Capacity())
+}
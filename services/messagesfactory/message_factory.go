@@ -8,6 +8,7 @@ package messagesfactory
 
 import (
 	"context"
+	"github.com/orbs-network/lean-helix-go/services/blockdissemination"
 	"github.com/orbs-network/lean-helix-go/services/interfaces"
 	"github.com/orbs-network/lean-helix-go/services/preparedmessages"
 	"github.com/orbs-network/lean-helix-go/services/randomseed"
@@ -18,6 +19,7 @@ import (
 type MessageFactory struct {
 	instanceId primitives.InstanceId
 	keyManager interfaces.KeyManager
+	beacon     interfaces.RandomnessBeacon
 	memberId   primitives.MemberId
 	randomSeed uint64
 }
@@ -107,8 +109,7 @@ func (f *MessageFactory) CreateCommitMessage(
 		Signature: primitives.Signature(f.keyManager.SignConsensusMessage(context.Background(), blockHeight, signedHeader.Build().Raw())),
 	}
 
-	randomSeedBytes := randomseed.RandomSeedToBytes(f.randomSeed)
-	share := f.keyManager.SignRandomSeed(context.Background(), blockHeight, randomSeedBytes)
+	share := f.beacon.ProduceShare(context.Background(), blockHeight, f.randomSeed)
 	contentBuilder := protocol.CommitContentBuilder{
 		SignedHeader: signedHeader,
 		Sender:       sender,
@@ -189,14 +190,22 @@ func (f *MessageFactory) CreateViewChangeMessageContentBuilder(
 		PreparedProof: preparedProofBuilder,
 	}
 
+	builtHeader := signedHeader.Build()
 	sender := &protocol.SenderSignatureBuilder{
 		MemberId:  f.memberId,
-		Signature: primitives.Signature(f.keyManager.SignConsensusMessage(context.Background(), blockHeight, signedHeader.Build().Raw())),
+		Signature: primitives.Signature(f.keyManager.SignConsensusMessage(context.Background(), blockHeight, builtHeader.Raw())),
+	}
+
+	summary := interfaces.ViewChangeSummaryHeaderFor(builtHeader).Build()
+	summarySender := &protocol.SenderSignatureBuilder{
+		MemberId:  f.memberId,
+		Signature: primitives.Signature(f.keyManager.SignConsensusMessage(context.Background(), blockHeight, summary.Raw())),
 	}
 
 	return &protocol.ViewChangeMessageContentBuilder{
-		SignedHeader: signedHeader,
-		Sender:       sender,
+		SignedHeader:  signedHeader,
+		Sender:        sender,
+		SummarySender: summarySender,
 	}
 }
 
@@ -228,10 +237,32 @@ func (f *MessageFactory) CreateNewViewMessageContentBuilder(
 		View:                    view,
 		ViewChangeConfirmations: confirmations,
 	}
+	return f.signNewViewMessageContent(signedHeader, ppContentBuilder)
+}
+
+// A NEW_VIEW whose confirmations only attest to their prepared views, backed by the single highest prepared proof among them
+func (f *MessageFactory) CreateCompactNewViewMessageContentBuilder(
+	blockHeight primitives.BlockHeight,
+	view primitives.View,
+	ppContentBuilder *protocol.PreprepareContentBuilder,
+	compactConfirmations []*protocol.ViewChangeSummaryContentBuilder,
+	highestPreparedProof *protocol.PreparedProofBuilder) *protocol.NewViewMessageContentBuilder {
+
+	signedHeader := &protocol.NewViewHeaderBuilder{
+		MessageType:          protocol.LEAN_HELIX_NEW_VIEW,
+		InstanceId:           f.instanceId,
+		BlockHeight:          blockHeight,
+		View:                 view,
+		CompactConfirmations: compactConfirmations,
+		HighestPreparedProof: highestPreparedProof,
+	}
+	return f.signNewViewMessageContent(signedHeader, ppContentBuilder)
+}
 
+func (f *MessageFactory) signNewViewMessageContent(signedHeader *protocol.NewViewHeaderBuilder, ppContentBuilder *protocol.PreprepareContentBuilder) *protocol.NewViewMessageContentBuilder {
 	sender := &protocol.SenderSignatureBuilder{
 		MemberId:  f.memberId,
-		Signature: primitives.Signature(f.keyManager.SignConsensusMessage(context.Background(), blockHeight, signedHeader.Build().Raw())),
+		Signature: primitives.Signature(f.keyManager.SignConsensusMessage(context.Background(), signedHeader.BlockHeight, signedHeader.Build().Raw())),
 	}
 
 	return &protocol.NewViewMessageContentBuilder{
@@ -252,10 +283,192 @@ func (f *MessageFactory) CreateNewViewMessage(
 	return interfaces.NewNewViewMessage(contentBuilder.Build(), block)
 }
 
+func (f *MessageFactory) CreateCompactNewViewMessage(
+	blockHeight primitives.BlockHeight,
+	view primitives.View,
+	ppContentBuilder *protocol.PreprepareContentBuilder,
+	compactConfirmations []*protocol.ViewChangeSummaryContentBuilder,
+	highestPreparedProof *protocol.PreparedProofBuilder,
+	block interfaces.Block) *interfaces.NewViewMessage {
+
+	contentBuilder := f.CreateCompactNewViewMessageContentBuilder(blockHeight, view, ppContentBuilder, compactConfirmations, highestPreparedProof)
+	return interfaces.NewNewViewMessage(contentBuilder.Build(), block)
+}
+
+func (f *MessageFactory) createRetransmissionHeader(
+	messageType protocol.MessageType,
+	blockHeight primitives.BlockHeight,
+	view primitives.View) (*protocol.RetransmissionHeaderBuilder, *protocol.SenderSignatureBuilder) {
+
+	signedHeader := &protocol.RetransmissionHeaderBuilder{
+		MessageType: messageType,
+		InstanceId:  f.instanceId,
+		BlockHeight: blockHeight,
+		View:        view,
+	}
+
+	sender := &protocol.SenderSignatureBuilder{
+		MemberId:  f.memberId,
+		Signature: primitives.Signature(f.keyManager.SignConsensusMessage(context.Background(), blockHeight, signedHeader.Build().Raw())),
+	}
+
+	return signedHeader, sender
+}
+
+func (f *MessageFactory) CreateMessagesRequestMessage(
+	blockHeight primitives.BlockHeight,
+	view primitives.View) *interfaces.MessagesRequestMessage {
+
+	signedHeader, sender := f.createRetransmissionHeader(protocol.LEAN_HELIX_MESSAGES_REQUEST, blockHeight, view)
+	contentBuilder := protocol.MessagesRequestContentBuilder{
+		SignedHeader: signedHeader,
+		Sender:       sender,
+	}
+
+	return interfaces.NewMessagesRequestMessage(contentBuilder.Build())
+}
+
+func (f *MessageFactory) CreateMessagesResponseMessage(
+	blockHeight primitives.BlockHeight,
+	view primitives.View,
+	prepareMessages []*interfaces.PrepareMessage,
+	commitMessages []*interfaces.CommitMessage) *interfaces.MessagesResponseMessage {
+
+	signedHeader, sender := f.createRetransmissionHeader(protocol.LEAN_HELIX_MESSAGES_RESPONSE, blockHeight, view)
+
+	prepares := make([]*protocol.PrepareContentBuilder, 0, len(prepareMessages))
+	for _, pm := range prepareMessages {
+		prepares = append(prepares, protocol.PrepareContentBuilderFromRaw(pm.Content().Raw()))
+	}
+	commits := make([]*protocol.CommitContentBuilder, 0, len(commitMessages))
+	for _, cm := range commitMessages {
+		commits = append(commits, protocol.CommitContentBuilderFromRaw(cm.Content().Raw()))
+	}
+
+	contentBuilder := protocol.MessagesResponseContentBuilder{
+		SignedHeader:    signedHeader,
+		Sender:          sender,
+		PrepareMessages: prepares,
+		CommitMessages:  commits,
+	}
+
+	return interfaces.NewMessagesResponseMessage(contentBuilder.Build())
+}
+
+func (f *MessageFactory) CreateCommitCertificateMessage(
+	blockHeight primitives.BlockHeight,
+	view primitives.View,
+	block interfaces.Block,
+	blockProof []byte) *interfaces.CommitCertificateMessage {
+
+	signedHeader, sender := f.createRetransmissionHeader(protocol.LEAN_HELIX_COMMIT_CERTIFICATE, blockHeight, view)
+	contentBuilder := protocol.CommitCertificateContentBuilder{
+		SignedHeader: signedHeader,
+		Sender:       sender,
+		BlockProof:   blockProof,
+	}
+
+	return interfaces.NewCommitCertificateMessage(contentBuilder.Build(), block)
+}
+
+func (f *MessageFactory) createBlockRefHeader(
+	messageType protocol.MessageType,
+	blockHeight primitives.BlockHeight,
+	view primitives.View,
+	blockHash primitives.BlockHash) (*protocol.BlockRefBuilder, *protocol.SenderSignatureBuilder) {
+
+	signedHeader := &protocol.BlockRefBuilder{
+		MessageType: messageType,
+		InstanceId:  f.instanceId,
+		BlockHeight: blockHeight,
+		View:        view,
+		BlockHash:   blockHash,
+	}
+
+	sender := &protocol.SenderSignatureBuilder{
+		MemberId:  f.memberId,
+		Signature: primitives.Signature(f.keyManager.SignConsensusMessage(context.Background(), blockHeight, signedHeader.Build().Raw())),
+	}
+
+	return signedHeader, sender
+}
+
+func (f *MessageFactory) CreateBlockRequestMessage(
+	blockHeight primitives.BlockHeight,
+	view primitives.View,
+	blockHash primitives.BlockHash) *interfaces.BlockRequestMessage {
+
+	signedHeader, sender := f.createBlockRefHeader(protocol.LEAN_HELIX_BLOCK_REQUEST, blockHeight, view, blockHash)
+	contentBuilder := protocol.BlockRequestContentBuilder{
+		SignedHeader: signedHeader,
+		Sender:       sender,
+	}
+
+	return interfaces.NewBlockRequestMessage(contentBuilder.Build())
+}
+
+func (f *MessageFactory) CreateBlockResponseMessage(
+	blockHeight primitives.BlockHeight,
+	view primitives.View,
+	blockHash primitives.BlockHash,
+	block interfaces.Block) *interfaces.BlockResponseMessage {
+
+	signedHeader, sender := f.createBlockRefHeader(protocol.LEAN_HELIX_BLOCK_RESPONSE, blockHeight, view, blockHash)
+	contentBuilder := protocol.BlockResponseContentBuilder{
+		SignedHeader: signedHeader,
+		Sender:       sender,
+	}
+
+	return interfaces.NewBlockResponseMessage(contentBuilder.Build(), block)
+}
+
+// The header carrying the chunks root is signed once and shared by the chunks of all members
+func (f *MessageFactory) CreateBlockChunkMessages(
+	blockHeight primitives.BlockHeight,
+	view primitives.View,
+	blockHash primitives.BlockHash,
+	blockChunks *blockdissemination.BlockChunks) []*interfaces.BlockChunkMessage {
+
+	signedHeader := &protocol.BlockChunkHeaderBuilder{
+		MessageType: protocol.LEAN_HELIX_BLOCK_CHUNK,
+		InstanceId:  f.instanceId,
+		BlockHeight: blockHeight,
+		View:        view,
+		BlockHash:   blockHash,
+		ChunksRoot:  blockChunks.Root,
+		DataChunks:  uint32(blockChunks.DataChunks),
+		TotalChunks: uint32(len(blockChunks.Chunks)),
+		BlockSize:   uint32(blockChunks.Size),
+	}
+	rawSignedHeader := signedHeader.Build().Raw()
+	sender := &protocol.SenderSignatureBuilder{
+		MemberId:  f.memberId,
+		Signature: primitives.Signature(f.keyManager.SignConsensusMessage(context.Background(), blockHeight, rawSignedHeader)),
+	}
+
+	messages := make([]*interfaces.BlockChunkMessage, len(blockChunks.Chunks))
+	for i, chunk := range blockChunks.Chunks {
+		contentBuilder := protocol.BlockChunkContentBuilder{
+			SignedHeader: protocol.BlockChunkHeaderBuilderFromRaw(rawSignedHeader),
+			Sender:       sender,
+			Index:        uint32(i),
+			Chunk:        chunk,
+			MerkleProof:  blockChunks.Proofs[i],
+		}
+		messages[i] = interfaces.NewBlockChunkMessage(contentBuilder.Build())
+	}
+	return messages
+}
+
 func NewMessageFactory(instanceId primitives.InstanceId, keyManager interfaces.KeyManager, memberId primitives.MemberId, randomSeed uint64) *MessageFactory {
+	return NewMessageFactoryWithRandomnessBeacon(instanceId, keyManager, randomseed.NewKeyManagerRandomnessBeacon(keyManager), memberId, randomSeed)
+}
+
+func NewMessageFactoryWithRandomnessBeacon(instanceId primitives.InstanceId, keyManager interfaces.KeyManager, beacon interfaces.RandomnessBeacon, memberId primitives.MemberId, randomSeed uint64) *MessageFactory {
 	return &MessageFactory{
 		instanceId: instanceId,
 		keyManager: keyManager,
+		beacon:     beacon,
 		memberId:   memberId,
 		randomSeed: randomSeed,
 	}
@@ -126,6 +126,10 @@ func TestMessageFactory(t *testing.T) {
 				MemberId:  memberId1,
 				Signature: node1KeyManager.SignConsensusMessage(context.Background(), blockHeight, signedHeader.Build().Raw()),
 			},
+			SummarySender: &protocol.SenderSignatureBuilder{
+				MemberId:  memberId1,
+				Signature: node1KeyManager.SignConsensusMessage(context.Background(), blockHeight, interfaces.ViewChangeSummaryHeaderFor(signedHeader.Build()).Build().Raw()),
+			},
 		}
 
 		actualVCM := node1Factory.CreateViewChangeMessage(blockHeight, view, nil)
@@ -182,6 +186,10 @@ func TestMessageFactory(t *testing.T) {
 				MemberId:  memberId1,
 				Signature: node1KeyManager.SignConsensusMessage(context.Background(), blockHeight, signedHeader.Build().Raw()),
 			},
+			SummarySender: &protocol.SenderSignatureBuilder{
+				MemberId:  memberId1,
+				Signature: node1KeyManager.SignConsensusMessage(context.Background(), blockHeight, interfaces.ViewChangeSummaryHeaderFor(signedHeader.Build()).Build().Raw()),
+			},
 		}
 
 		preparedMessages := &preparedmessages.PreparedMessages{
@@ -250,6 +258,10 @@ func TestMessageFactory(t *testing.T) {
 				MemberId:  memberId1,
 				Signature: node1KeyManager.SignConsensusMessage(context.Background(), blockHeight, nodesVCHeader.Build().Raw()),
 			},
+			SummarySender: &protocol.SenderSignatureBuilder{
+				MemberId:  memberId1,
+				Signature: node1KeyManager.SignConsensusMessage(context.Background(), blockHeight, interfaces.ViewChangeSummaryHeaderFor(nodesVCHeader.Build()).Build().Raw()),
+			},
 		}
 		node2Confirmation := &protocol.ViewChangeMessageContentBuilder{
 			SignedHeader: nodesVCHeader,
@@ -257,6 +269,10 @@ func TestMessageFactory(t *testing.T) {
 				MemberId:  memberId2,
 				Signature: node2KeyManager.SignConsensusMessage(context.Background(), blockHeight, nodesVCHeader.Build().Raw()),
 			},
+			SummarySender: &protocol.SenderSignatureBuilder{
+				MemberId:  memberId2,
+				Signature: node2KeyManager.SignConsensusMessage(context.Background(), blockHeight, interfaces.ViewChangeSummaryHeaderFor(nodesVCHeader.Build()).Build().Raw()),
+			},
 		}
 		nvmHeader := &protocol.NewViewHeaderBuilder{
 			MessageType: protocol.LEAN_HELIX_NEW_VIEW,
@@ -306,4 +322,79 @@ func TestMessageFactory(t *testing.T) {
 
 	})
 
+	t.Run("create compact NewViewMessage", func(t *testing.T) {
+		ppm := node0Factory.CreatePreprepareMessage(blockHeight, view, block, blockHash)
+		preparedMessages := &preparedmessages.PreparedMessages{
+			PreprepareMessage: ppm,
+			PrepareMessages: []*interfaces.PrepareMessage{
+				node1Factory.CreatePrepareMessage(blockHeight, view, blockHash),
+				node2Factory.CreatePrepareMessage(blockHeight, view, blockHash),
+			},
+		}
+		vcms := []*interfaces.ViewChangeMessage{
+			node1Factory.CreateViewChangeMessage(blockHeight, view+1, preparedMessages),
+			node2Factory.CreateViewChangeMessage(blockHeight, view+1, nil),
+		}
+
+		// Construct the "expected" message manually
+		summaryHeader1 := &protocol.ViewChangeSummaryHeaderBuilder{
+			MessageType:       protocol.LEAN_HELIX_VIEW_CHANGE_SUMMARY,
+			InstanceId:        instanceId,
+			BlockHeight:       blockHeight,
+			View:              view + 1,
+			PreparedView:      view,
+			PreparedBlockHash: blockHash,
+		}
+		summaryHeader2 := &protocol.ViewChangeSummaryHeaderBuilder{
+			MessageType: protocol.LEAN_HELIX_VIEW_CHANGE_SUMMARY,
+			InstanceId:  instanceId,
+			BlockHeight: blockHeight,
+			View:        view + 1,
+		}
+		nvmHeader := &protocol.NewViewHeaderBuilder{
+			MessageType: protocol.LEAN_HELIX_NEW_VIEW,
+			InstanceId:  instanceId,
+			BlockHeight: blockHeight,
+			View:        view + 1,
+			CompactConfirmations: []*protocol.ViewChangeSummaryContentBuilder{
+				{
+					SignedHeader: summaryHeader1,
+					Sender: &protocol.SenderSignatureBuilder{
+						MemberId:  memberId1,
+						Signature: node1KeyManager.SignConsensusMessage(context.Background(), blockHeight, summaryHeader1.Build().Raw()),
+					},
+				},
+				{
+					SignedHeader: summaryHeader2,
+					Sender: &protocol.SenderSignatureBuilder{
+						MemberId:  memberId2,
+						Signature: node2KeyManager.SignConsensusMessage(context.Background(), blockHeight, summaryHeader2.Build().Raw()),
+					},
+				},
+			},
+			HighestPreparedProof: protocol.PreparedProofBuilderFromRaw(vcms[0].Content().SignedHeader().PreparedProof().Raw()),
+		}
+		ppContentBuilder := node0Factory.CreatePreprepareMessageContentBuilder(blockHeight, view+1, block, blockHash)
+		nvmContentBuilder := &protocol.NewViewMessageContentBuilder{
+			SignedHeader: nvmHeader,
+			Sender: &protocol.SenderSignatureBuilder{
+				MemberId:  memberId0,
+				Signature: node0KeyManager.SignConsensusMessage(context.Background(), blockHeight, nvmHeader.Build().Raw()),
+			},
+			Message: ppContentBuilder,
+		}
+
+		// Construct "actual" message with message factories
+		actualNVM := node0Factory.CreateCompactNewViewMessage(
+			blockHeight,
+			view+1,
+			ppContentBuilder,
+			interfaces.ExtractCompactConfirmationsFromViewChangeMessages(vcms),
+			interfaces.ExtractHighestPreparedProofFromViewChangeMessages(vcms),
+			block)
+		expectedNVM := interfaces.NewNewViewMessage(nvmContentBuilder.Build(), block)
+
+		require.True(t, bytes.Compare(expectedNVM.Raw(), actualNVM.Raw()) == 0, "compared bytes of NVM")
+	})
+
 }
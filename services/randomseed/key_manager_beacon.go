@@ -0,0 +1,56 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package randomseed
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
+)
+
+// keyManagerBeacon is the default RandomnessBeacon: threshold signatures of the KeyManager on the seed,
+// with the next seed hashed from the aggregated signature in the previous block proof
+type keyManagerBeacon struct {
+	keyManager interfaces.KeyManager
+}
+
+func NewKeyManagerRandomnessBeacon(keyManager interfaces.KeyManager) interfaces.RandomnessBeacon {
+	return &keyManagerBeacon{keyManager: keyManager}
+}
+
+// Returns the configured RandomnessBeacon, or the default one over the config's KeyManager
+func RandomnessBeaconOf(config *interfaces.Config) interfaces.RandomnessBeacon {
+	if config.RandomnessBeacon != nil {
+		return config.RandomnessBeacon
+	}
+	return NewKeyManagerRandomnessBeacon(config.KeyManager)
+}
+
+func (b *keyManagerBeacon) DeriveRandomSeed(blockHeight primitives.BlockHeight, prevBlockProof *protocol.BlockProof) uint64 {
+	return CalculateRandomSeed(prevBlockProof.RandomSeedSignature())
+}
+
+func (b *keyManagerBeacon) ProduceShare(ctx context.Context, blockHeight primitives.BlockHeight, randomSeed uint64) primitives.RandomSeedSignature {
+	return b.keyManager.SignRandomSeed(ctx, blockHeight, RandomSeedToBytes(randomSeed))
+}
+
+func (b *keyManagerBeacon) VerifyShare(blockHeight primitives.BlockHeight, randomSeed uint64, share *protocol.SenderSignature) error {
+	return b.keyManager.VerifyRandomSeed(blockHeight, RandomSeedToBytes(randomSeed), share)
+}
+
+func (b *keyManagerBeacon) AggregateShares(blockHeight primitives.BlockHeight, randomSeed uint64, shares []*protocol.SenderSignature) primitives.RandomSeedSignature {
+	return b.keyManager.AggregateRandomSeed(blockHeight, shares)
+}
+
+func (b *keyManagerBeacon) VerifyAggregate(blockHeight primitives.BlockHeight, randomSeed uint64, aggregate primitives.RandomSeedSignature) error {
+	masterRandomSeed := (&protocol.SenderSignatureBuilder{
+		Signature: primitives.Signature(aggregate),
+		MemberId:  nil, // master
+	}).Build()
+	return b.keyManager.VerifyRandomSeed(blockHeight, RandomSeedToBytes(randomSeed), masterRandomSeed)
+}
@@ -26,19 +26,10 @@ func CalculateRandomSeed(signature []byte) uint64 {
 	return binary.LittleEndian.Uint64(array)
 }
 
-func ValidateRandomSeed(keyManager interfaces.KeyManager, blockHeight primitives.BlockHeight, blockProof *protocol.BlockProof, prevBlockProof *protocol.BlockProof) error {
-	randomSeedSignature := blockProof.RandomSeedSignature()
-	prevRandomSeedSignature := prevBlockProof.RandomSeedSignature()
+func ValidateRandomSeed(beacon interfaces.RandomnessBeacon, blockHeight primitives.BlockHeight, blockProof *protocol.BlockProof, prevBlockProof *protocol.BlockProof) error {
+	randomSeed := beacon.DeriveRandomSeed(blockHeight, prevBlockProof) // Calculate the random seed based on prev block proof
 
-	randomSeed := CalculateRandomSeed(prevRandomSeedSignature) // Calculate the random seed based on prev block proof
-
-	// validate random seed signature against master publicKey
-	masterRandomSeed := (&protocol.SenderSignatureBuilder{
-		Signature: primitives.Signature(randomSeedSignature),
-		MemberId:  nil, // master
-	}).Build()
-
-	if err := keyManager.VerifyRandomSeed(blockHeight, RandomSeedToBytes(randomSeed), masterRandomSeed); err != nil {
+	if err := beacon.VerifyAggregate(blockHeight, randomSeed, blockProof.RandomSeedSignature()); err != nil {
 		return errors.Wrap(err, "VerifyRandomSeed() failed")
 	}
 
@@ -44,7 +44,7 @@ func TestValidateRandomSeed(t *testing.T) {
 	memberId := primitives.MemberId("Dummy Member Id")
 	keyManager := mocks.NewMockKeyManager(memberId)
 
-	randomseed.ValidateRandomSeed(keyManager, 4, blockProof, prevBlockProof)
+	randomseed.ValidateRandomSeed(randomseed.NewKeyManagerRandomnessBeacon(keyManager), 4, blockProof, prevBlockProof)
 
 	randomSeedSignature := primitives.Signature(blockProof.RandomSeedSignature())
 	prevRandomSeedSignature := prevBlockProof.RandomSeedSignature()
@@ -13,6 +13,7 @@ import (
 	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
 	"github.com/orbs-network/lean-helix-go/state"
 	"github.com/orbs-network/scribe/log"
+	"github.com/pkg/errors"
 )
 
 type RawMessageFilter struct {
@@ -23,15 +24,17 @@ type RawMessageFilter struct {
 	futureCache              map[primitives.BlockHeight][]interfaces.ConsensusMessage
 	logger                   L.LHLogger
 	latestFutureBlockHeight  primitives.BlockHeight // needed for limiting future cache to 1 term (potential memory leak)
+	onMessageRejected        interfaces.OnMessageRejectedCallback
 }
 
-func NewConsensusMessageFilter(instanceId primitives.InstanceId, myMemberId primitives.MemberId, logger L.LHLogger, state *state.State) *RawMessageFilter {
+func NewConsensusMessageFilter(instanceId primitives.InstanceId, myMemberId primitives.MemberId, logger L.LHLogger, state *state.State, onMessageRejected interfaces.OnMessageRejectedCallback) *RawMessageFilter {
 	res := &RawMessageFilter{
-		instanceId:  instanceId,
-		myMemberId:  myMemberId,
-		futureCache: make(map[primitives.BlockHeight][]interfaces.ConsensusMessage),
-		logger:      logger,
-		state:       state,
+		instanceId:        instanceId,
+		myMemberId:        myMemberId,
+		futureCache:       make(map[primitives.BlockHeight][]interfaces.ConsensusMessage),
+		logger:            logger,
+		state:             state,
+		onMessageRejected: onMessageRejected,
 	}
 
 	return res
@@ -49,17 +52,20 @@ func (f *RawMessageFilter) HandleConsensusRawMessage(rawMessage *interfaces.Cons
 
 	if message.BlockHeight() < f.state.Height() {
 		f.logger.Debug("LHFILTER IGNORING RECEIVED %s with H=%d V=%d sender=%s IGNORING message from the past", message.MessageType(), message.BlockHeight(), message.View(), termincommittee.Str(message.SenderMemberId()))
+		f.onMessageRejected.Reject(rawMessage, errors.Wrapf(interfaces.ErrStaleHeight, "%s of H=%d while current H=%d", message.MessageType(), message.BlockHeight(), f.state.Height()))
 		return
 	}
 
 	if message.InstanceId() != f.instanceId {
 		f.logger.Info("LHFILTER IGNORING RECEIVED %s with H=%d V=%d sender=%s IGNORING message from different instanceID=%s because my instanceID==%s", message.MessageType(), message.BlockHeight(), message.View(), termincommittee.Str(message.SenderMemberId()), message.InstanceId(), f.instanceId)
+		f.onMessageRejected.Reject(rawMessage, errors.Wrapf(interfaces.ErrWrongInstance, "%s of instanceID=%s while my instanceID=%s", message.MessageType(), message.InstanceId(), f.instanceId))
 		return
 	}
 
 	if message.BlockHeight() > f.state.Height() {
 		f.pushToCache(message.BlockHeight(), message)
 		f.logger.Debug("LHFILTER STORING RECEIVED %s with H=%d V=%d sender=%s STORING message from future height", message.MessageType(), message.BlockHeight(), message.View(), termincommittee.Str(message.SenderMemberId()))
+		f.onMessageRejected.Reject(rawMessage, errors.Wrapf(interfaces.ErrFutureMessageCached, "%s of H=%d while current H=%d", message.MessageType(), message.BlockHeight(), f.state.Height()))
 		return
 	}
 	f.logger.Debug("LHFILTER RECEIVED %s with H=%d V=%d sender=%s OK PROCESSING", message.MessageType(), message.BlockHeight(), message.View(), termincommittee.Str(message.SenderMemberId()))
@@ -106,6 +112,7 @@ func (f *RawMessageFilter) processConsensusMessage(message interfaces.ConsensusM
 	f.logger.Debug("received consensus message", log.Stringable("message-type", message.MessageType()), log.Stringable("sender", message.SenderMemberId()))
 	if err := f.consensusMessagesHandler.HandleConsensusMessage(message); err != nil {
 		f.logger.Info("LHFILTER LHMSG Failed in HandleConsensusMessage(): %s", err)
+		f.onMessageRejected.RejectMessage(message, err)
 	}
 }
 
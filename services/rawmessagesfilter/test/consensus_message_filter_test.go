@@ -16,6 +16,7 @@ import (
 	"github.com/orbs-network/lean-helix-go/test"
 	"github.com/orbs-network/lean-helix-go/test/builders"
 	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"math/rand"
 	"testing"
@@ -64,7 +65,7 @@ func TestGettingAMessage(t *testing.T) {
 	test.WithContext(func(ctx context.Context) {
 		instanceId := primitives.InstanceId(rand.Uint64())
 		mockState := mocks.NewMockState().WithHeightView(10, 20)
-		filter := rawmessagesfilter.NewConsensusMessageFilter(instanceId, primitives.MemberId("My MemberId"), testLogger(mockState.State), mockState.State)
+		filter := rawmessagesfilter.NewConsensusMessageFilter(instanceId, primitives.MemberId("My MemberId"), testLogger(mockState.State), mockState.State, nil)
 		messagesHandler := NewTermMessagesHandlerMock()
 		filter.ConsumeCacheMessages(messagesHandler)
 
@@ -90,7 +91,7 @@ func TestFilterMessagesFromThePast(t *testing.T) {
 	test.WithContext(func(ctx context.Context) {
 		instanceId := primitives.InstanceId(rand.Uint64())
 		mockState := mocks.NewMockState().WithHeightView(10, 0)
-		filter := rawmessagesfilter.NewConsensusMessageFilter(instanceId, primitives.MemberId("My MemberId"), testLogger(mockState.State), mockState.State)
+		filter := rawmessagesfilter.NewConsensusMessageFilter(instanceId, primitives.MemberId("My MemberId"), testLogger(mockState.State), mockState.State, nil)
 		messagesHandler := NewTermMessagesHandlerMock()
 		filter.ConsumeCacheMessages(messagesHandler)
 
@@ -109,7 +110,7 @@ func TestFilterMessagesFromThePast(t *testing.T) {
 func TestFilterMessagesWithBadInstanceId(t *testing.T) {
 	test.WithContext(func(ctx context.Context) {
 		mockState := mocks.NewMockState().WithHeightView(10, 0)
-		filter := rawmessagesfilter.NewConsensusMessageFilter(777, primitives.MemberId("My MemberId"), testLogger(mockState.State), mockState.State)
+		filter := rawmessagesfilter.NewConsensusMessageFilter(777, primitives.MemberId("My MemberId"), testLogger(mockState.State), mockState.State, nil)
 		messagesHandler := NewTermMessagesHandlerMock()
 		filter.ConsumeCacheMessages(messagesHandler)
 
@@ -125,11 +126,33 @@ func TestFilterMessagesWithBadInstanceId(t *testing.T) {
 	})
 }
 
+func TestFilteredMessagesAreReportedWithTheirReason(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		mockState := mocks.NewMockState().WithHeightView(10, 0)
+		var reasons []error
+		onMessageRejected := func(message *interfaces.ConsensusRawMessage, err error) {
+			reasons = append(reasons, errors.Cause(err))
+		}
+		filter := rawmessagesfilter.NewConsensusMessageFilter(777, primitives.MemberId("My MemberId"), testLogger(mockState.State), mockState.State, onMessageRejected)
+		messagesHandler := NewTermMessagesHandlerMock()
+		filter.ConsumeCacheMessages(messagesHandler)
+
+		filter.HandleConsensusRawMessage(GeneratePreprepareMessage(777, 9, 0, "Sender MemberId"))
+		filter.HandleConsensusRawMessage(GeneratePreprepareMessage(666, 10, 0, "Sender MemberId"))
+		filter.HandleConsensusRawMessage(GeneratePreprepareMessage(777, 11, 0, "Sender MemberId"))
+		filter.HandleConsensusRawMessage(GeneratePreprepareMessage(777, 10, 0, "Sender MemberId"))
+		filter.HandleConsensusRawMessage(GeneratePreprepareMessage(777, 10, 0, "My MemberId"))
+
+		require.Equal(t, []error{interfaces.ErrStaleHeight, interfaces.ErrWrongInstance, interfaces.ErrFutureMessageCached}, reasons)
+		require.Equal(t, 1, len(messagesHandler.history))
+	})
+}
+
 func TestCacheMessagesFromTheFuture(t *testing.T) {
 	test.WithContext(func(ctx context.Context) {
 		instanceId := primitives.InstanceId(rand.Uint64())
 		mockState := mocks.NewMockState().WithHeightView(10, 0)
-		filter := rawmessagesfilter.NewConsensusMessageFilter(instanceId, primitives.MemberId("My MemberId"), testLogger(mockState.State), mockState.State)
+		filter := rawmessagesfilter.NewConsensusMessageFilter(instanceId, primitives.MemberId("My MemberId"), testLogger(mockState.State), mockState.State, nil)
 		messagesHandler := NewTermMessagesHandlerMock()
 		filter.ConsumeCacheMessages(messagesHandler)
 
@@ -149,7 +172,7 @@ func TestFilterMessagesWithMyMemberId(t *testing.T) {
 	test.WithContext(func(ctx context.Context) {
 		instanceId := primitives.InstanceId(rand.Uint64())
 		mockState := mocks.NewMockState().WithHeightView(10, 0)
-		filter := rawmessagesfilter.NewConsensusMessageFilter(instanceId, primitives.MemberId("My MemberId"), testLogger(mockState.State), mockState.State)
+		filter := rawmessagesfilter.NewConsensusMessageFilter(instanceId, primitives.MemberId("My MemberId"), testLogger(mockState.State), mockState.State, nil)
 		messagesHandler := NewTermMessagesHandlerMock()
 		filter.ConsumeCacheMessages(messagesHandler)
 
@@ -10,7 +10,9 @@ import (
 	"context"
 	"fmt"
 	"github.com/orbs-network/lean-helix-go/instrumentation/metrics"
+	"github.com/orbs-network/lean-helix-go/services/blockdissemination"
 	"github.com/orbs-network/lean-helix-go/services/blockextractor"
+	"github.com/orbs-network/lean-helix-go/services/events"
 	"github.com/orbs-network/lean-helix-go/services/interfaces"
 	L "github.com/orbs-network/lean-helix-go/services/logger"
 	"github.com/orbs-network/lean-helix-go/services/messagesfactory"
@@ -27,6 +29,7 @@ import (
 	"runtime"
 	"sort"
 	"strings"
+	"time"
 )
 
 // The algorithm cannot function with less committee members
@@ -53,6 +56,29 @@ type TermInCommittee struct {
 	prevBlock                       interfaces.Block
 	QuorumWeight                    uint // TODO primitive
 	State                           *state.State
+	stuckHeightView                 *state.HeightView
+	lastMessagesResponses           map[storage.MemberIdStr]time.Time
+	rebroadcast                     *rebroadcastSchedule
+	viewChangeVotes                 map[storage.MemberIdStr]primitives.View
+	broadcastViewChange             bool
+	storeFutureViewChanges          bool
+	blockDisseminationByHash        bool
+	pendingProposal                 *pendingProposal
+	blockCodec                      interfaces.BlockCodec
+	chunkCollectors                 map[string]*blockdissemination.ChunksCollector
+	rebuiltBlocks                   map[string]interfaces.Block
+	compactNewView                  bool
+	validatedProposals              map[validatedProposal]bool
+	blockValidationScheduler        interfaces.BlockValidationScheduler
+	validationsInProgress           map[primitives.View]bool
+	publisher                       *events.Publisher
+	onMessageRejected               interfaces.OnMessageRejectedCallback
+}
+
+// A block proposal that passed ValidateBlockProposal in an earlier view of the term
+type validatedProposal struct {
+	leaderMemberId storage.MemberIdStr
+	blockHash      string
 }
 
 func GetMemberIds(members []interfaces.CommitteeMember) []primitives.MemberId {
@@ -63,7 +89,7 @@ func GetMemberIds(members []interfaces.CommitteeMember) []primitives.MemberId {
 	return ids
 }
 
-func NewTermInCommittee(log L.LHLogger, config *interfaces.Config, state *state.State, messageFactory *messagesfactory.MessageFactory, electionTrigger interfaces.ElectionScheduler, committeeMembers []interfaces.CommitteeMember, prevBlock interfaces.Block, canBeFirstLeader bool, onCommit OnInCommitteeCommitCallback) *TermInCommittee {
+func NewTermInCommittee(log L.LHLogger, config *interfaces.Config, state *state.State, messageFactory *messagesfactory.MessageFactory, electionTrigger interfaces.ElectionScheduler, committeeMembers []interfaces.CommitteeMember, prevBlock interfaces.Block, canBeFirstLeader bool, onCommit OnInCommitteeCommitCallback, blockValidationScheduler interfaces.BlockValidationScheduler, publisher *events.Publisher) *TermInCommittee {
 
 	keyManager := config.KeyManager
 	blockUtils := config.BlockUtils
@@ -86,19 +112,33 @@ func NewTermInCommittee(log L.LHLogger, config *interfaces.Config, state *state.
 	log.Debug("NewTermInCommittee: committeeMembersCount=%d members=%s", len(committeeMembers), ToCommitteeMembersStr(committeeMembers))
 
 	result := &TermInCommittee{
-		State:                   state,
-		onCommit:                onCommit,
-		prevBlock:               prevBlock,
-		keyManager:              keyManager,
-		communication:           comm,
-		storage:                 config.Storage,
-		electionTrigger:         electionTrigger,
-		blockUtils:              blockUtils,
-		committeeMembers:        committeeMembers,
-		otherCommitteeMemberIds: otherCommitteeMemberIds,
-		messageFactory:          messageFactory,
-		myMemberId:              myMemberId,
-		logger:                  log,
+		State:                    state,
+		onCommit:                 onCommit,
+		prevBlock:                prevBlock,
+		keyManager:               keyManager,
+		communication:            comm,
+		storage:                  config.Storage,
+		electionTrigger:          electionTrigger,
+		blockUtils:               blockUtils,
+		committeeMembers:         committeeMembers,
+		otherCommitteeMemberIds:  otherCommitteeMemberIds,
+		messageFactory:           messageFactory,
+		myMemberId:               myMemberId,
+		logger:                   log,
+		lastMessagesResponses:    make(map[storage.MemberIdStr]time.Time),
+		viewChangeVotes:          make(map[storage.MemberIdStr]primitives.View),
+		broadcastViewChange:      config.BroadcastViewChange,
+		storeFutureViewChanges:   config.StoreFutureViewChanges,
+		blockDisseminationByHash: config.BlockDisseminationByHash,
+		blockCodec:               config.BlockCodec,
+		chunkCollectors:          make(map[string]*blockdissemination.ChunksCollector),
+		rebuiltBlocks:            make(map[string]interfaces.Block),
+		compactNewView:           config.CompactNewView,
+		validatedProposals:       make(map[validatedProposal]bool),
+		blockValidationScheduler: blockValidationScheduler,
+		validationsInProgress:    make(map[primitives.View]bool),
+		publisher:                publisher,
+		onMessageRejected:        config.OnMessageRejectedCB,
 	}
 
 	result.startTerm(canBeFirstLeader)
@@ -171,6 +211,10 @@ func (tic *TermInCommittee) startTerm(canBeFirstLeader bool) {
 		return // not leader, do nothing
 	}
 
+	if tic.isPaused("startTerm() PREPREPARE") {
+		return
+	}
+
 	tic.logger.Debug("LHFLOW startTerm() I AM THE LEADER OF FIRST VIEW, requesting new block")
 	tic.logger.ConsensusTrace("I am the leader", nil)
 
@@ -191,15 +235,20 @@ func (tic *TermInCommittee) startTerm(canBeFirstLeader bool) {
 		tic.logger.Info("LHFLOW startTerm() RequestNewBlockProposal() context canceled, not sending PREPREPARE - %s", ctx.Err())
 		return
 	}
+	if block == nil {
+		tic.logger.Info("LHFLOW startTerm() RequestNewBlockProposal() returned no block, not sending PREPREPARE")
+		return
+	}
 
 	ppm := tic.messageFactory.CreatePreprepareMessage(currentHV.Height(), currentHV.View(), block, blockHash)
 
 	tic.storage.StorePreprepare(ppm)
 	tic.logger.Debug("LHMSG SEND PREPREPARE (msg: H=%d V=%d sender=%s)",
 		ppm.BlockHeight(), ppm.View(), Str(ppm.SenderMemberId()))
-	if err := tic.sendConsensusMessage(ppm); err != nil {
+	if err := tic.sendProposal(ppm, block, blockHash); err != nil {
 		tic.logger.Info("LHMSG SEND PREPREPARE FAILED - %s", err)
 	}
+	tic.publish(events.PROPOSAL_SENT, currentHV.Height(), currentHV.View(), blockHash)
 
 }
 
@@ -221,6 +270,10 @@ func (tic *TermInCommittee) initView(newView primitives.View) (*state.HeightView
 	return current, nil
 }
 
+func (tic *TermInCommittee) CommitteeMemberIds() []primitives.MemberId {
+	return GetMemberIds(tic.committeeMembers)
+}
+
 func (tic *TermInCommittee) Dispose() {
 	tic.electionTrigger.Stop()
 	height := tic.State.Height()
@@ -229,10 +282,10 @@ func (tic *TermInCommittee) Dispose() {
 }
 
 func (tic *TermInCommittee) calcLeaderMemberId(view primitives.View) primitives.MemberId {
-	return calcLeaderOfViewAndCommittee(view, tic.committeeMembers)
+	return CalcLeaderOfViewAndCommittee(view, tic.committeeMembers)
 }
 
-func calcLeaderOfViewAndCommittee(view primitives.View, committeeMembers []interfaces.CommitteeMember) primitives.MemberId {
+func CalcLeaderOfViewAndCommittee(view primitives.View, committeeMembers []interfaces.CommitteeMember) primitives.MemberId {
 	index := int(view) % len(committeeMembers)
 	return committeeMembers[index].Id
 }
@@ -245,42 +298,77 @@ func (tic *TermInCommittee) moveToNextLeaderByElection(height primitives.BlockHe
 	}
 	tic.logger.Debug("LHFLOW moveToNextLeaderByElection() calling initView(), will increment view to V=%d", currentHV.View()+1)
 	tic.logViewMessages(" transition from view (%d) to view (%d) by moveToNextLeaderByElection", uint(currentHV.View()), uint(currentHV.View()+1))
-	currentHV, err := tic.initView(currentHV.View() + 1)
+	tic.moveToView(currentHV.View()+1, updateMetrics)
+}
+
+// RequestViewChange moves to the next view as if the election timeout of height and view passed, see MainLoop.RequestViewChange
+func (tic *TermInCommittee) RequestViewChange(height primitives.BlockHeight, view primitives.View, updateMetrics interfaces.OnElectionCallback) {
+	tic.moveToNextLeaderByElection(height, view, updateMetrics)
+}
+
+// Enters the view and votes for its leader with a VIEW_CHANGE
+func (tic *TermInCommittee) moveToView(view primitives.View, updateMetrics interfaces.OnElectionCallback) {
+	currentHV, err := tic.initView(view)
 	if err != nil {
-		tic.logger.Info("LHFLOW moveToNextLeaderByElection() initView() failed, cannot continue: %s", err)
+		tic.logger.Info("LHFLOW moveToView() initView() failed, cannot continue: %s", err)
 		return
 	}
 
 	newLeaderId := tic.calcLeaderMemberId(currentHV.View())
-	tic.logger.Debug("LHFLOW moveToNextLeaderByElection() calculated newLeaderId=%s of V=%d", Str(newLeaderId), currentHV.View())
+	tic.logger.Debug("LHFLOW moveToView() calculated newLeaderId=%s of V=%d", Str(newLeaderId), currentHV.View())
+	if tic.isPaused("moveToView() VIEW_CHANGE") {
+		if updateMetrics != nil {
+			updateMetrics(metrics.NewElectionMetrics(newLeaderId, currentHV.View()))
+		}
+		return
+	}
 	var preparedMessages *preparedmessages.PreparedMessages
 	if tic.preparedLocally != nil && tic.preparedLocally.isPreparedLocally {
 		preparedMessages = preparedmessages.ExtractPreparedMessages(currentHV.Height(), tic.preparedLocally.latestView, tic.storage, tic.committeeMembers)
 	}
 	vcm := tic.messageFactory.CreateViewChangeMessage(currentHV.Height(), currentHV.View(), preparedMessages)
+	tic.storage.StoreViewChange(vcm)
+
+	tic.sendViewChange(newLeaderId, vcm)
+	tic.publish(events.VIEW_CHANGE_SENT, currentHV.Height(), currentHV.View(), nil)
 
 	if err := tic.isLeader(tic.myMemberId, currentHV.View()); err == nil {
-		tic.logger.Debug("LHFLOW moveToNextLeaderByElection() I WILL BE LEADER if I get enough VIEW_CHANGE votes. My leadership of V=%d will time out in %s", currentHV.View(), tic.electionTrigger.CalcTimeout(currentHV.View()))
-		tic.storage.StoreViewChange(vcm)
+		tic.logger.Debug("LHFLOW moveToView() I WILL BE LEADER if I get enough VIEW_CHANGE votes. My leadership of V=%d will time out in %s", currentHV.View(), tic.electionTrigger.CalcTimeout(currentHV.View()))
 		tic.checkElected(currentHV.Height(), currentHV.View())
-	} else {
-		tic.logger.Debug("LHFLOW LHMSG SEND VIEW_CHANGE to %s in moveToNextLeader() (I'M NOT LEADER: %s) (msg: H=%d V=%d sender=%s)",
-			newLeaderId, err, vcm.BlockHeight(), vcm.View(), Str(vcm.SenderMemberId()))
-		if sendErr := tic.sendConsensusMessageToSpecificMember(newLeaderId, vcm); sendErr != nil {
-			tic.logger.Info("LHMSG SEND VIEW_CHANGE to %s FAILED - %s", newLeaderId, sendErr)
-		}
 	}
 	if updateMetrics != nil {
 		updateMetrics(metrics.NewElectionMetrics(newLeaderId, currentHV.View()))
 	}
 }
 
+// VIEW_CHANGE goes to the leader of its view, or with Config.BroadcastViewChange to the whole committee,
+// so that every member collects the votes of future views and can synchronize its view
+func (tic *TermInCommittee) sendViewChange(leaderId primitives.MemberId, vcm *interfaces.ViewChangeMessage) {
+	if tic.broadcastViewChange {
+		tic.logger.Debug("LHFLOW LHMSG SEND VIEW_CHANGE to ALL (leader is %s) (msg: H=%d V=%d sender=%s)",
+			Str(leaderId), vcm.BlockHeight(), vcm.View(), Str(vcm.SenderMemberId()))
+		if err := tic.sendConsensusMessage(vcm); err != nil {
+			tic.logger.Info("LHMSG SEND VIEW_CHANGE FAILED - %s", err)
+		}
+		return
+	}
+
+	if leaderId.Equal(tic.myMemberId) {
+		return
+	}
+	tic.logger.Debug("LHFLOW LHMSG SEND VIEW_CHANGE to %s (msg: H=%d V=%d sender=%s)",
+		Str(leaderId), vcm.BlockHeight(), vcm.View(), Str(vcm.SenderMemberId()))
+	if err := tic.sendConsensusMessageToSpecificMember(leaderId, vcm); err != nil {
+		tic.logger.Info("LHMSG SEND VIEW_CHANGE to %s FAILED - %s", Str(leaderId), err)
+	}
+}
+
 func (tic *TermInCommittee) isLeader(memberId primitives.MemberId, v primitives.View) error {
 	return isLeaderOfViewForThisCommittee(memberId, v, tic.committeeMembers)
 }
 
 func isLeaderOfViewForThisCommittee(leaderCandidateId primitives.MemberId, v primitives.View, committeeMembers []interfaces.CommitteeMember) error {
-	calculatedLeaderId := calcLeaderOfViewAndCommittee(v, committeeMembers)
+	calculatedLeaderId := CalcLeaderOfViewAndCommittee(v, committeeMembers)
 	if !leaderCandidateId.Equal(calculatedLeaderId) {
 		return errors.Errorf("candidate leader is %s but calculated leader for V=%s is %s", Str(leaderCandidateId), v, Str(calculatedLeaderId))
 	}
@@ -315,6 +403,9 @@ func (tic *TermInCommittee) checkElected(height primitives.BlockHeight, view pri
 }
 
 func (tic *TermInCommittee) onElectedByViewChange(view primitives.View, viewChangeMessages []*interfaces.ViewChangeMessage) {
+	if tic.isPaused("onElectedByViewChange() NEW_VIEW") {
+		return
+	}
 	tic.latestViewThatProcessedVCMOrNVM = view
 	tic.logger.Debug("LHFLOW onElectedByViewChange() I AM THE LEADER BY VIEW CHANGE for V=%d, now calling initView()", view)
 	currentHeightView := tic.State.HeightView()
@@ -326,6 +417,7 @@ func (tic *TermInCommittee) onElectedByViewChange(view primitives.View, viewChan
 		tic.logger.Debug("LHFLOW onElectedByViewChange() failed: %s", err)
 		return
 	}
+	tic.publish(events.ELECTED_AS_LEADER, currentHeightView.Height(), view, nil)
 	block, blockHash := blockextractor.GetLatestBlockFromViewChangeMessages(viewChangeMessages)
 	if block == nil {
 		tic.logger.Debug("LHFLOW onElectedByViewChange() MISSING BLOCK IN VIEW_CHANGE, calling RequestNewBlockProposal()")
@@ -341,20 +433,50 @@ func (tic *TermInCommittee) onElectedByViewChange(view primitives.View, viewChan
 			tic.logger.Info("LHFLOW onElectedByViewChange() RequestNewBlockProposal() context canceled, not sending NEW_VIEW - %s", ctx.Err())
 			return
 		}
+		if block == nil {
+			tic.logger.Info("LHFLOW onElectedByViewChange() RequestNewBlockProposal() returned no block, not sending NEW_VIEW")
+			return
+		}
 		tic.logger.Debug("LHFLOW onElectedByViewChange() SEND NEW_VIEW with the new block that was returned from RequestNewBlockProposal()")
 	} else {
 		tic.logger.Debug("LHFLOW onElectedByViewChange() SEND NEW_VIEW with the block with H=%d from the latest VIEW_CHANGE messages", block.Height())
 	}
 	ppmContentBuilder := tic.messageFactory.CreatePreprepareMessageContentBuilder(tic.State.Height(), view, block, blockHash)
 	ppm := tic.messageFactory.CreatePreprepareMessageFromContentBuilder(ppmContentBuilder, block)
-	confirmations := interfaces.ExtractConfirmationsFromViewChangeMessages(viewChangeMessages)
-	nvm := tic.messageFactory.CreateNewViewMessage(tic.State.Height(), view, ppmContentBuilder, confirmations, block)
+	nvm := tic.createNewViewMessage(view, ppmContentBuilder, viewChangeMessages, block)
 	tic.storage.StorePreprepare(ppm)
 	tic.logger.Debug("LHMSG SEND NEW_VIEW (msg: H=%d V=%d sender=%s)",
 		nvm.BlockHeight(), nvm.View(), Str(nvm.SenderMemberId()))
-	if err := tic.sendConsensusMessage(nvm); err != nil {
+	if err := tic.sendProposal(nvm, block, blockHash); err != nil {
 		tic.logger.Info("LHMSG SEND NEW_VIEW FAILED - %s", err)
 	}
+	tic.publish(events.PROPOSAL_SENT, currentHeightView.Height(), view, blockHash)
+}
+
+// The compact NEW_VIEW needs every vote to carry a summary signature, otherwise the full VIEW_CHANGE messages are sent
+func (tic *TermInCommittee) createNewViewMessage(view primitives.View, ppmContentBuilder *protocol.PreprepareContentBuilder, viewChangeMessages []*interfaces.ViewChangeMessage, block interfaces.Block) *interfaces.NewViewMessage {
+	if tic.compactNewView {
+		if compactConfirmations := interfaces.ExtractCompactConfirmationsFromViewChangeMessages(viewChangeMessages); compactConfirmations != nil {
+			highestPreparedProof := interfaces.ExtractHighestPreparedProofFromViewChangeMessages(viewChangeMessages)
+			return tic.messageFactory.CreateCompactNewViewMessage(tic.State.Height(), view, ppmContentBuilder, compactConfirmations, highestPreparedProof, block)
+		}
+		tic.logger.Debug("LHFLOW onElectedByViewChange() not all VIEW_CHANGE messages carry a summary signature, sending a full NEW_VIEW")
+	}
+	confirmations := interfaces.ExtractConfirmationsFromViewChangeMessages(viewChangeMessages)
+	return tic.messageFactory.CreateNewViewMessage(tic.State.Height(), view, ppmContentBuilder, confirmations, block)
+}
+
+func (tic *TermInCommittee) publish(eventType events.EventType, height primitives.BlockHeight, view primitives.View, blockHash primitives.BlockHash) {
+	tic.publisher.Publish(&events.Event{Type: eventType, Height: height, View: view, BlockHash: blockHash})
+}
+
+// A paused node follows heights without signing or proposing, see MainLoop.Pause
+func (tic *TermInCommittee) isPaused(skipped string) bool {
+	if !tic.State.Paused() {
+		return false
+	}
+	tic.logger.Debug("LHFLOW %s SKIPPED - paused", skipped)
+	return true
 }
 
 func (tic *TermInCommittee) sendConsensusMessage(message interfaces.ConsensusMessage) error {
@@ -379,10 +501,18 @@ func (tic *TermInCommittee) HandlePrePrepare(ppm *interfaces.PreprepareMessage)
 
 	if err := tic.validatePreprepare(ppm); err != nil {
 		tic.logger.Info("LHMSG RECEIVED PREPREPARE IGNORE: validatePreprepare() failed: %s", err)
+		if errors.Cause(err) == interfaces.ErrInvalidSignature {
+			tic.onMessageRejected.RejectMessage(ppm, err)
+		}
 		return
 	}
 
 	header := ppm.Content().SignedHeader()
+	tic.publish(events.PROPOSAL_RECEIVED, header.BlockHeight(), header.View(), header.BlockHash())
+	if tic.blockDisseminationByHash && ppm.Block() == nil {
+		tic.fetchProposedBlock(ppm, header.BlockHeight(), header.View(), header.BlockHash())
+		return
+	}
 
 	ctx, err := tic.State.Contexts.For(state.NewHeightView(header.BlockHeight(), header.View()))
 	if err != nil {
@@ -391,18 +521,67 @@ func (tic *TermInCommittee) HandlePrePrepare(ppm *interfaces.PreprepareMessage)
 	}
 
 	// TODO Is this the correct memberId or should it be ppm.Content().Sender().MemberId ?
-	err = tic.blockUtils.ValidateBlockProposal(ctx, ppm.BlockHeight(), tic.calcLeaderMemberId(header.View()), ppm.Block(), ppm.Content().SignedHeader().BlockHash(), tic.prevBlock)
-	if err != nil {
-		tic.logger.Info("LHMSG RECEIVED PREPREPARE IGNORE: blockUtils.ValidateBlockProposal() failed: %s", err)
+	tic.validateBlockProposal(ctx, ppm, tic.calcLeaderMemberId(header.View()), func(err error) {
+		if err != nil {
+			tic.logger.Info("LHMSG RECEIVED PREPREPARE IGNORE: blockUtils.ValidateBlockProposal() failed: %s", err)
+			return
+		}
+
+		if ctx.Err() != nil { // TODO required?
+			tic.logger.Info("LHFLOW HandlePrePrepare() ValidateBlockProposal - %s", ctx.Err())
+			return
+		}
+
+		tic.processPreprepare(ppm)
+	})
+}
+
+// A block proposed again by the same leader in a later view is validated once per term. Only successful validations
+// are remembered, and a remembered block is still checked against its hash so a different block under the same hash is rejected.
+// With a BlockValidationScheduler, onValidated is called later on and only if the term is still at the proposal's view or below it.
+func (tic *TermInCommittee) validateBlockProposal(ctx context.Context, ppm *interfaces.PreprepareMessage, leaderMemberId primitives.MemberId, onValidated func(err error)) {
+	blockHash := ppm.Content().SignedHeader().BlockHash()
+	key := validatedProposal{storage.MemberIdStr(leaderMemberId), string(blockHash)}
+	if tic.validatedProposals[key] {
+		if !tic.blockUtils.ValidateBlockCommitment(ppm.BlockHeight(), ppm.Block(), blockHash) {
+			onValidated(errors.Errorf("block does not match the already validated block hash %s", blockHash))
+			return
+		}
+		tic.logger.Debug("LHMSG block proposal already validated for H=%d, skipping ValidateBlockProposal", ppm.BlockHeight())
+		onValidated(nil)
 		return
 	}
 
-	if ctx.Err() != nil { // TODO required?
-		tic.logger.Info("LHFLOW HandlePrePrepare() ValidateBlockProposal - %s", ctx.Err())
+	blockUtils, prevBlock := tic.blockUtils, tic.prevBlock
+	validate := func(ctx context.Context) error {
+		return blockUtils.ValidateBlockProposal(ctx, ppm.BlockHeight(), leaderMemberId, ppm.Block(), blockHash, prevBlock)
+	}
+	onResult := func(err error) {
+		if err == nil && ctx.Err() == nil {
+			tic.validatedProposals[key] = true
+		}
+		onValidated(err)
+	}
+
+	if tic.blockValidationScheduler == nil {
+		onResult(validate(ctx))
 		return
 	}
 
-	tic.processPreprepare(ppm)
+	view := ppm.View()
+	if tic.validationsInProgress[view] {
+		tic.logger.Debug("LHMSG already validating a block proposal of H=%d V=%d, ignoring another one", ppm.BlockHeight(), view)
+		return
+	}
+	tic.validationsInProgress[view] = true
+	tic.blockValidationScheduler.ScheduleBlockValidation(ctx, validate, func(err error) {
+		delete(tic.validationsInProgress, view)
+		if current := tic.State.HeightView(); current.Height() != ppm.BlockHeight() || current.View() > view {
+			tic.logger.Debug("LHMSG block proposal of H=%d V=%d validated after moving to %s, ignoring it", ppm.BlockHeight(), view, current)
+			return
+		}
+		onResult(err)
+	})
 }
 
 func (tic *TermInCommittee) validatePreprepare(ppm *interfaces.PreprepareMessage) error {
@@ -418,7 +597,7 @@ func (tic *TermInCommittee) validatePreprepare(ppm *interfaces.PreprepareMessage
 	if err := tic.keyManager.VerifyConsensusMessage(header.BlockHeight(), header.Raw(), sender); err != nil {
 		tic.logger.ConsensusTrace("failed to verify preprepare - maybe a committee mismatch?", err, log.Stringable("sender", sender))
 
-		return errors.Wrapf(err, "verification failed for sender %s signature on header", Str(sender.MemberId()))
+		return errors.Wrapf(interfaces.ErrInvalidSignature, "verification failed for sender %s signature on header: %s", Str(sender.MemberId()), err)
 	}
 
 	if err := tic.isLeader(sender.MemberId(), ppm.View()); err != nil {
@@ -442,6 +621,12 @@ func (tic *TermInCommittee) processPreprepare(ppm *interfaces.PreprepareMessage)
 		return
 	}
 
+	if tic.isPaused("processPreprepare() PREPARE") { // the PREPREPARE is kept, so the block commits once the others do
+		tic.storage.StorePreprepare(ppm)
+		tic.checkCommitted(header.BlockHeight(), header.View(), header.BlockHash())
+		return
+	}
+
 	pm := tic.messageFactory.CreatePrepareMessage(header.BlockHeight(), header.View(), header.BlockHash())
 	tic.storage.StorePreprepare(ppm)
 	tic.storage.StorePrepare(pm)
@@ -464,6 +649,7 @@ func (tic *TermInCommittee) HandlePrepare(pm *interfaces.PrepareMessage) {
 
 	if err := tic.keyManager.VerifyConsensusMessage(header.BlockHeight(), header.Raw(), sender); err != nil {
 		tic.logger.Info("LHMSG RECEIVED PREPARE IGNORE - verification failed for Prepare block-height=%v view=%d block-hash=%s err=%v", header.BlockHeight(), header.View(), header.BlockHash(), err)
+		tic.onMessageRejected.RejectMessage(pm, errors.Wrapf(interfaces.ErrInvalidSignature, "PREPARE: %s", err))
 		return
 	}
 	if header.View() < tic.State.View() {
@@ -528,8 +714,14 @@ func (tic *TermInCommittee) countPrepared(height primitives.BlockHeight, view pr
 }
 
 func (tic *TermInCommittee) onPreparedLocally(blockHeight primitives.BlockHeight, view primitives.View, blockHash primitives.BlockHash) {
+	if tic.isPaused("onPreparedLocally() COMMIT") { // not prepared locally either, so the COMMIT is sent if this view prepares again after Resume
+		tic.checkCommitted(blockHeight, view, blockHash)
+		return
+	}
 	tic.setPreparedLocally(view)
 	tic.logger.Debug("LHFLOW LHMSG PHASE PREPARED, PreparedLocally set to V=%d", view)
+	tic.publish(events.PREPARED_LOCALLY, blockHeight, view, blockHash)
+	tic.proposeNextBlockAhead(blockHeight, view, blockHash)
 	cm := tic.messageFactory.CreateCommitMessage(blockHeight, view, blockHash)
 	tic.storage.StoreCommit(cm)
 	tic.logger.Debug("LHMSG SEND COMMIT (msg: H=%d V=%d sender=%s)",
@@ -540,6 +732,25 @@ func (tic *TermInCommittee) onPreparedLocally(blockHeight primitives.BlockHeight
 	tic.checkCommitted(blockHeight, view, blockHash)
 }
 
+// With a PipelinedBlockUtils, the block of the next height is built on top of the prepared block while this height commits
+func (tic *TermInCommittee) proposeNextBlockAhead(blockHeight primitives.BlockHeight, view primitives.View, blockHash primitives.BlockHash) {
+	pipeline, ok := tic.blockUtils.(interfaces.PipelinedBlockUtils)
+	if !ok {
+		return
+	}
+	ppm, ok := tic.storage.GetPreprepareMessage(blockHeight, view)
+	if !ok || ppm.Block() == nil {
+		return
+	}
+	ctx, err := tic.State.Contexts.For(state.NewHeightView(blockHeight+1, MaxView))
+	if err != nil {
+		tic.logger.Debug("LHFLOW proposeNextBlockAhead() H=%d - %s", blockHeight+1, err)
+		return
+	}
+	tic.logger.Debug("LHFLOW proposeNextBlockAhead() building H=%d on top of prepared H=%d V=%d", blockHeight+1, blockHeight, view)
+	pipeline.ProposeAhead(ctx, blockHeight+1, tic.myMemberId, ppm.Block(), blockHash)
+}
+
 func (tic *TermInCommittee) HandleCommit(cm *interfaces.CommitMessage) {
 	tic.logger.Debug("LHMSG RECEIVED COMMIT (msg: H=%d V=%d sender=%s)",
 		cm.BlockHeight(), cm.View(), Str(cm.SenderMemberId()))
@@ -548,6 +759,7 @@ func (tic *TermInCommittee) HandleCommit(cm *interfaces.CommitMessage) {
 
 	if err := tic.keyManager.VerifyConsensusMessage(header.BlockHeight(), header.Raw(), sender); err != nil {
 		tic.logger.Info("LHMSG RECEIVED COMMIT IGNORE - verification failed for Commit block-height=%d view=%d block-hash=%s err=%v", header.BlockHeight(), header.View(), header.BlockHash(), err)
+		tic.onMessageRejected.RejectMessage(cm, errors.Wrapf(interfaces.ErrInvalidSignature, "COMMIT: %s", err))
 		return
 	}
 	tic.logger.Debug("LHMSG RECEIVED COMMIT STORE")
@@ -596,6 +808,7 @@ func (tic *TermInCommittee) checkCommitted(blockHeight primitives.BlockHeight, v
 	tic.committedBlock = ppm.Block()
 	tic.logger.Debug("LHFLOW LHMSG PHASE COMMITTED CommittedBlock set to H=%d, calling onCommit() with H=%d V=%d block-hash=%s num-commit-messages=%d",
 		ppm.Block().Height(), blockHeight, view, blockHash, len(commits))
+	tic.publish(events.COMMITTED, blockHeight, view, blockHash)
 	tic.onCommit(ctx, ppm.Block(), commits)
 }
 
@@ -607,7 +820,7 @@ func (tic *TermInCommittee) sendCommitIfNotAlreadySent(commits []*interfaces.Com
 			break
 		}
 	}
-	if !iSentCommitMessage {
+	if !iSentCommitMessage && !tic.isPaused("sendCommitIfNotAlreadySent() COMMIT") {
 		cm := tic.messageFactory.CreateCommitMessage(blockHeight, view, blockHash)
 		tic.logger.Debug("LHMSG SEND COMMIT [checkCommitted] because I did not send it during onPreparedLocally")
 		if err := tic.sendConsensusMessage(cm); err != nil {
@@ -620,13 +833,17 @@ func (tic *TermInCommittee) HandleViewChange(vcm *interfaces.ViewChangeMessage)
 	tic.logger.Debug("LHMSG RECEIVED VIEW_CHANGE (msg: H=%d V=%d sender=%s)",
 		vcm.BlockHeight(), vcm.View(), Str(vcm.SenderMemberId()))
 
-	if err := tic.isViewChangeAccepted(tic.myMemberId, tic.State.View(), vcm.Content()); err != nil {
+	isLeaderOfView := tic.isLeader(tic.myMemberId, vcm.View()) == nil
+	if err := tic.isViewChangeAccepted(isLeaderOfView, tic.State.View(), vcm.Content()); err != nil {
 		tic.logger.Debug("LHMSG RECEIVED VIEW_CHANGE IGNORE - %s", err)
 		return
 	}
 
 	if err := tic.isViewChangeValid(tic.myMemberId, tic.State.View(), vcm.Content()); err != nil {
 		tic.logger.Info("LHMSG RECEIVED VIEW_CHANGE IGNORE - invalid VIEW_CHANGE: %s", err)
+		if errors.Cause(err) == interfaces.ErrInvalidSignature {
+			tic.onMessageRejected.RejectMessage(vcm, err)
+		}
 		return
 	}
 
@@ -639,20 +856,28 @@ func (tic *TermInCommittee) HandleViewChange(vcm *interfaces.ViewChangeMessage)
 		}
 	}
 
-	tic.storage.StoreViewChange(vcm)
-	tic.checkElected(header.BlockHeight(), header.View())
+	if isLeaderOfView || tic.storeFutureViewChanges {
+		tic.storage.StoreViewChange(vcm)
+	}
+	if isLeaderOfView {
+		tic.checkElected(header.BlockHeight(), header.View())
+	}
+	// without broadcast, the votes a member receives are only those for views it leads, too few to synchronize on
+	if tic.broadcastViewChange {
+		tic.recordViewChangeVote(vcm.SenderMemberId(), header.View())
+		tic.checkViewSync()
+	}
 }
 
-func (tic *TermInCommittee) isViewChangeAccepted(expectedLeaderForView primitives.MemberId, view primitives.View, vcmContent *protocol.ViewChangeMessageContent) error {
+// The leader of a view collects its VIEW_CHANGE votes; all others only consider votes for views above their own, for view synchronization
+func (tic *TermInCommittee) isViewChangeAccepted(isLeaderOfView bool, view primitives.View, vcmContent *protocol.ViewChangeMessageContent) error {
 	vcmView := vcmContent.SignedHeader().View()
-	calculatedLeaderForView := tic.calcLeaderMemberId(vcmView)
-	if !expectedLeaderForView.Equal(calculatedLeaderForView) {
-		return errors.Errorf("I am not the calculated leader %s who should collect these messages - I am %s", Str(calculatedLeaderForView), Str(expectedLeaderForView))
-	}
-
 	if view > vcmView {
 		return errors.Errorf("message view %s is older than current term's view %s", vcmView, view)
 	}
+	if !isLeaderOfView && view == vcmView {
+		return errors.Errorf("I am not the calculated leader %s who should collect messages of my current view %s", Str(tic.calcLeaderMemberId(vcmView)), view)
+	}
 	return nil
 }
 
@@ -663,36 +888,70 @@ func (tic *TermInCommittee) isViewChangeValid(expectedLeaderFromNewView primitiv
 	preparedProof := header.PreparedProof()
 
 	if err := tic.keyManager.VerifyConsensusMessage(header.BlockHeight(), header.Raw(), sender); err != nil {
-		return errors.Wrapf(err, "keyManager.VerifyConsensusMessage failed")
+		return errors.Wrapf(interfaces.ErrInvalidSignature, "keyManager.VerifyConsensusMessage failed: %s", err)
 	}
 
 	if !proofsvalidator.ValidatePreparedProof(tic.State.Height(), vcmView, preparedProof, tic.keyManager, tic.committeeMembers, func(view primitives.View) primitives.MemberId { return tic.calcLeaderMemberId(view) }) {
 		return fmt.Errorf("failed ValidatePreparedProof()")
 	}
+
+	// a bad summary signature would only surface later, failing the leader's compact NEW_VIEW
+	if summarySender := vcm.SummarySender(); summarySender != nil && len(summarySender.Signature()) > 0 {
+		if !summarySender.MemberId().Equal(sender.MemberId()) {
+			return errors.Errorf("summary signed by %s instead of the sender %s", Str(summarySender.MemberId()), Str(sender.MemberId()))
+		}
+		summary := interfaces.ViewChangeSummaryHeaderFor(header).Build()
+		if err := tic.keyManager.VerifyConsensusMessage(header.BlockHeight(), summary.Raw(), summarySender); err != nil {
+			return errors.Wrapf(interfaces.ErrInvalidSignature, "keyManager.VerifyConsensusMessage failed for the summary: %s", err)
+		}
+	}
 	return nil
 }
 
-func (tic *TermInCommittee) validateViewChangeVotes(targetBlockHeight primitives.BlockHeight, targetView primitives.View, confirmations []*protocol.ViewChangeMessageContent) error {
-	senders := make([]primitives.MemberId, len(confirmations))
+// The sender, height and view of a VIEW_CHANGE vote, taken from either a full or a compact NEW_VIEW confirmation
+type viewChangeVote struct {
+	senderId    primitives.MemberId
+	blockHeight primitives.BlockHeight
+	view        primitives.View
+}
+
+func votesOfConfirmations(confirmations []*protocol.ViewChangeMessageContent) []viewChangeVote {
+	votes := make([]viewChangeVote, len(confirmations))
 	for i, confirmation := range confirmations {
-		senders[i] = confirmation.Sender().MemberId()
+		votes[i] = viewChangeVote{confirmation.Sender().MemberId(), confirmation.SignedHeader().BlockHeight(), confirmation.SignedHeader().View()}
+	}
+	return votes
+}
+
+func votesOfCompactConfirmations(compactConfirmations []*protocol.ViewChangeSummaryContent) []viewChangeVote {
+	votes := make([]viewChangeVote, len(compactConfirmations))
+	for i, confirmation := range compactConfirmations {
+		votes[i] = viewChangeVote{confirmation.Sender().MemberId(), confirmation.SignedHeader().BlockHeight(), confirmation.SignedHeader().View()}
+	}
+	return votes
+}
+
+func (tic *TermInCommittee) validateViewChangeVotes(targetBlockHeight primitives.BlockHeight, targetView primitives.View, votes []viewChangeVote) error {
+	senders := make([]primitives.MemberId, len(votes))
+	for i, vote := range votes {
+		senders[i] = vote.senderId
 	}
 	isQuorum, totalWeights, q := tic.isQuorum(senders)
 	if !isQuorum {
-		return fmt.Errorf("there are %d confirmations with total weight of %d but %d is needed", len(confirmations), totalWeights, q)
+		return fmt.Errorf("there are %d confirmations with total weight of %d but %d is needed", len(votes), totalWeights, q)
 	}
 
 	set := make(map[string]bool)
 
 	// VerifyConsensusMessage that all _Block heights and views match, and all public keys are unique
-	for _, confirmation := range confirmations {
-		senderMemberIdStr := string(confirmation.Sender().MemberId())
-		confirmationBlockHeight := confirmation.SignedHeader().BlockHeight()
+	for _, vote := range votes {
+		senderMemberIdStr := string(vote.senderId)
+		confirmationBlockHeight := vote.blockHeight
 		if confirmationBlockHeight != targetBlockHeight {
 			return fmt.Errorf("confirmation of memberId %s has block height %d which is different than targetBlockHeight %d ",
 				senderMemberIdStr, confirmationBlockHeight, targetBlockHeight)
 		}
-		confirmationView := confirmation.SignedHeader().View()
+		confirmationView := vote.view
 		if confirmationView != targetView {
 			return fmt.Errorf("confirmation of memberId %s has view %d which is different than targetView %d ",
 				senderMemberIdStr, confirmationView, targetView)
@@ -707,6 +966,63 @@ func (tic *TermInCommittee) validateViewChangeVotes(targetBlockHeight primitives
 
 }
 
+// Unlike full confirmations, whose signatures are checked along with the latest prepared proof, every summary is all there is of its vote
+func (tic *TermInCommittee) verifyCompactConfirmations(compactConfirmations []*protocol.ViewChangeSummaryContent) error {
+	for _, confirmation := range compactConfirmations {
+		header := confirmation.SignedHeader()
+		if header.MessageType() != protocol.LEAN_HELIX_VIEW_CHANGE_SUMMARY {
+			return errors.Errorf("compact confirmation of memberId %s has message type %s", Str(confirmation.Sender().MemberId()), header.MessageType())
+		}
+		if err := tic.keyManager.VerifyConsensusMessage(header.BlockHeight(), header.Raw(), confirmation.Sender()); err != nil {
+			return errors.Wrapf(err, "compact confirmation of memberId %s failed verification", Str(confirmation.Sender().MemberId()))
+		}
+	}
+	return nil
+}
+
+// The highest prepared proof must be valid, of the latest prepared view attested to by the compact confirmations and for the same block.
+// Returns the prepared block hash, or nil if none of the votes is prepared.
+func (tic *TermInCommittee) validateHighestPreparedProof(targetView primitives.View, compactConfirmations []*protocol.ViewChangeSummaryContent, highestPreparedProof *protocol.PreparedProof) (primitives.BlockHash, error) {
+	hasProof := highestPreparedProof != nil && len(highestPreparedProof.Raw()) > 0
+	var preparedView primitives.View
+	var preparedBlockHash primitives.BlockHash
+	if hasProof {
+		preparedView = highestPreparedProof.PreprepareBlockRef().View()
+		preparedBlockHash = highestPreparedProof.PreprepareBlockRef().BlockHash()
+	}
+
+	attested := false
+	for _, confirmation := range compactConfirmations {
+		header := confirmation.SignedHeader()
+		if len(header.PreparedBlockHash()) == 0 {
+			continue
+		}
+		if !hasProof {
+			return nil, errors.Errorf("confirmation of memberId %s is prepared in view %d but there is no prepared proof", Str(confirmation.Sender().MemberId()), header.PreparedView())
+		}
+		if header.PreparedView() > preparedView {
+			return nil, errors.Errorf("confirmation of memberId %s is prepared in view %d, later than the prepared proof of view %d", Str(confirmation.Sender().MemberId()), header.PreparedView(), preparedView)
+		}
+		if header.PreparedView() == preparedView {
+			if !header.PreparedBlockHash().Equal(preparedBlockHash) {
+				return nil, errors.Errorf("confirmation of memberId %s is prepared in view %d for a different block than the prepared proof", Str(confirmation.Sender().MemberId()), preparedView)
+			}
+			attested = true
+		}
+	}
+
+	if !hasProof {
+		return nil, nil
+	}
+	if !attested {
+		return nil, errors.Errorf("no confirmation attests to the prepared proof of view %d", preparedView)
+	}
+	if !proofsvalidator.ValidatePreparedProof(tic.State.Height(), targetView, highestPreparedProof, tic.keyManager, tic.committeeMembers, func(view primitives.View) primitives.MemberId { return tic.calcLeaderMemberId(view) }) {
+		return nil, errors.New("failed ValidatePreparedProof()")
+	}
+	return preparedBlockHash, nil
+}
+
 func (tic *TermInCommittee) HandleNewView(nvm *interfaces.NewViewMessage) {
 	tic.logger.Debug("LHMSG RECEIVED NEW_VIEW (msg: H=%d V=%d sender=%s)",
 		nvm.BlockHeight(), nvm.View(), Str(nvm.SenderMemberId()))
@@ -715,6 +1031,8 @@ func (tic *TermInCommittee) HandleNewView(nvm *interfaces.NewViewMessage) {
 	ppMessageContent := nvm.Content().Message()
 	viewChangeConfirmationsIter := nvmHeader.ViewChangeConfirmationsIterator()
 	viewChangeConfirmations := make([]*protocol.ViewChangeMessageContent, 0, 1)
+	compactConfirmationsIter := nvmHeader.CompactConfirmationsIterator()
+	compactConfirmations := make([]*protocol.ViewChangeSummaryContent, 0, 1)
 
 	if tic.State.View() > nvmHeader.View() {
 		tic.logger.Info("LHMSG RECEIVED NEW_VIEW IGNORE - current view %d is higher than message view %d", tic.State.View(), nvmHeader.View())
@@ -727,10 +1045,19 @@ func (tic *TermInCommittee) HandleNewView(nvm *interfaces.NewViewMessage) {
 		}
 		viewChangeConfirmations = append(viewChangeConfirmations, viewChangeConfirmationsIter.NextViewChangeConfirmations())
 	}
+	for compactConfirmationsIter.HasNext() {
+		compactConfirmations = append(compactConfirmations, compactConfirmationsIter.NextCompactConfirmations())
+	}
+	isCompact := len(compactConfirmations) > 0
+	if isCompact && len(viewChangeConfirmations) > 0 {
+		tic.logger.Info("LHMSG RECEIVED NEW_VIEW IGNORE - has both full and compact confirmations")
+		return
+	}
 
 	if err := tic.keyManager.VerifyConsensusMessage(nvmHeader.BlockHeight(), nvmHeader.Raw(), nvmSender); err != nil {
 		//this.logger.log({ subject: "Warning", message: `blockHeight:[${blockHeight}], view:[${view}], HandleNewView from "${senderId}", ignored because the signature verification failed` });
 		tic.logger.Info("LHMSG RECEIVED NEW_VIEW IGNORE - keyManager.VerifyConsensusMessage() failed: %s", err)
+		tic.onMessageRejected.RejectMessage(nvm, errors.Wrapf(interfaces.ErrInvalidSignature, "NEW_VIEW: %s", err))
 		return
 	}
 
@@ -741,7 +1068,16 @@ func (tic *TermInCommittee) HandleNewView(nvm *interfaces.NewViewMessage) {
 		return
 	}
 
-	if err := tic.validateViewChangeVotes(nvmHeader.BlockHeight(), nvmHeader.View(), viewChangeConfirmations); err != nil {
+	votes := votesOfConfirmations(viewChangeConfirmations)
+	if isCompact {
+		if err := tic.verifyCompactConfirmations(compactConfirmations); err != nil {
+			tic.logger.Info("LHMSG RECEIVED NEW_VIEW IGNORE - verifyCompactConfirmations failed: %s", err)
+			return
+		}
+		votes = votesOfCompactConfirmations(compactConfirmations)
+	}
+
+	if err := tic.validateViewChangeVotes(nvmHeader.BlockHeight(), nvmHeader.View(), votes); err != nil {
 		//this.logger.log({ subject: "Warning", message: `blockHeight:[${blockHeight}], view:[${view}], HandleNewView from "${senderId}", votes is invalid` });
 		tic.logger.Info("LHMSG RECEIVED NEW_VIEW IGNORE - validateViewChangeVotes failed: %s", err)
 		return
@@ -761,8 +1097,20 @@ func (tic *TermInCommittee) HandleNewView(nvm *interfaces.NewViewMessage) {
 		return
 	}
 
-	latestVote := tic.latestViewChangeVote(viewChangeConfirmations)
-	if latestVote != nil {
+	if tic.blockDisseminationByHash && nvm.Block() == nil {
+		tic.fetchProposedBlock(nvm, nvmHeader.BlockHeight(), nvmHeader.View(), ppMessageContent.SignedHeader().BlockHash())
+		return
+	}
+
+	var latestPreparedBlockHash primitives.BlockHash
+	if isCompact {
+		preparedBlockHash, err := tic.validateHighestPreparedProof(nvmHeader.View(), compactConfirmations, nvmHeader.HighestPreparedProof())
+		if err != nil {
+			tic.logger.Info("LHMSG RECEIVED NEW_VIEW IGNORE - NewView.HighestPreparedProof is invalid: %s", err)
+			return
+		}
+		latestPreparedBlockHash = preparedBlockHash
+	} else if latestVote := tic.latestViewChangeVote(viewChangeConfirmations); latestVote != nil {
 
 		calculatedLeaderFromViewChange := tic.calcLeaderMemberId(latestVote.SignedHeader().View())
 		if !calculatedLeaderFromNewView.Equal(calculatedLeaderFromViewChange) {
@@ -775,22 +1123,23 @@ func (tic *TermInCommittee) HandleNewView(nvm *interfaces.NewViewMessage) {
 			return
 		}
 
-		// rewrite this mess
-		latestVoteBlockHash := latestVote.SignedHeader().PreparedProof().PreprepareBlockRef().BlockHash()
-		if latestVoteBlockHash != nil {
-			isValidDigest := tic.blockUtils.ValidateBlockCommitment(nvmHeader.BlockHeight(), nvm.Block(), latestVoteBlockHash)
-			if !isValidDigest {
-				//this.logger.log({ subject: "Warning", message: `blockHeight:[${blockHeight}], view:[${view}], HandleNewView from "${senderId}", the given _Block (PP._Block) doesn't match the best _Block from the VCProof` });
-				tic.logger.Info("LHMSG RECEIVED NEW_VIEW IGNORE - NewView.ViewChangeConfirmation (with latest view) is invalid")
-				return
-			}
+		latestPreparedBlockHash = latestVote.SignedHeader().PreparedProof().PreprepareBlockRef().BlockHash()
+	}
+
+	// rewrite this mess
+	if latestPreparedBlockHash != nil {
+		isValidDigest := tic.blockUtils.ValidateBlockCommitment(nvmHeader.BlockHeight(), nvm.Block(), latestPreparedBlockHash)
+		if !isValidDigest {
+			//this.logger.log({ subject: "Warning", message: `blockHeight:[${blockHeight}], view:[${view}], HandleNewView from "${senderId}", the given _Block (PP._Block) doesn't match the best _Block from the VCProof` });
+			tic.logger.Info("LHMSG RECEIVED NEW_VIEW IGNORE - NewView.ViewChangeConfirmation (with latest view) is invalid")
+			return
 		}
 	}
 
 	ppm := interfaces.NewPreprepareMessage(ppMessageContent, nvm.Block())
 
 	// leader proposed a new block in this view, checking its proposal
-	if latestVote == nil {
+	if latestPreparedBlockHash == nil {
 		header := ppm.Content().SignedHeader()
 
 		ctx, err := tic.State.Contexts.For(state.NewHeightView(nvmHeader.BlockHeight(), nvm.View()))
@@ -800,29 +1149,38 @@ func (tic *TermInCommittee) HandleNewView(nvm *interfaces.NewViewMessage) {
 		}
 
 		// TODO Is this the correct member Id or should it be ppm.Content().Sender().MemberId()?
-		err = tic.blockUtils.ValidateBlockProposal(ctx, ppm.BlockHeight(), tic.calcLeaderMemberId(header.View()), ppm.Block(), ppm.Content().SignedHeader().BlockHash(), tic.prevBlock)
-		if err != nil {
-			tic.logger.Info("LHFLOW LHMSG RECEIVED NEW_VIEW IGNORE - Proposed block failed ValidateBlockProposal: %s", err)
-			return
-		}
+		tic.validateBlockProposal(ctx, ppm, tic.calcLeaderMemberId(header.View()), func(err error) {
+			if err != nil {
+				tic.logger.Info("LHFLOW LHMSG RECEIVED NEW_VIEW IGNORE - Proposed block failed ValidateBlockProposal: %s", err)
+				return
+			}
 
-		if ctx.Err() != nil { // TODO required?
-			tic.logger.Info("LHFLOW LHMSG RECEIVED NEW_VIEW IGNORE - ValidateBlockProposal - %s", ctx.Err())
-			return
-		}
+			if ctx.Err() != nil { // TODO required?
+				tic.logger.Info("LHFLOW LHMSG RECEIVED NEW_VIEW IGNORE - ValidateBlockProposal - %s", ctx.Err())
+				return
+			}
+
+			tic.acceptNewView(nvmHeader.View(), ppm)
+		})
+		return
 	}
 
+	tic.acceptNewView(nvmHeader.View(), ppm)
+}
+
+func (tic *TermInCommittee) acceptNewView(view primitives.View, ppm *interfaces.PreprepareMessage) {
 	if err := tic.validatePreprepare(ppm); err == nil {
-		tic.latestViewThatProcessedVCMOrNVM = nvmHeader.View()
+		tic.latestViewThatProcessedVCMOrNVM = view
 		tic.logger.Debug("LHFLOW LHMSG RECEIVED NEW_VIEW OK - calling initView(). latestViewThatProcessedVCMOrNVM set to V=%d", tic.latestViewThatProcessedVCMOrNVM)
 		currentHeightView := tic.State.HeightView()
-		if currentHeightView.View() < nvmHeader.View() { // hadn't logged yet
-			tic.logViewMessages(" transition from view (%d) to view (%d) by NewViewMessage", uint(currentHeightView.View()), uint(nvmHeader.View()))
+		if currentHeightView.View() < view { // hadn't logged yet
+			tic.logViewMessages(" transition from view (%d) to view (%d) by NewViewMessage", uint(currentHeightView.View()), uint(view))
 		}
-		if _, err := tic.initView(nvmHeader.View()); err != nil {
+		if _, err := tic.initView(view); err != nil {
 			tic.logger.Debug("LHFLOW LHMSG HandleNewView() - initView() failed: %s", err)
 			return
 		}
+		tic.publish(events.NEW_VIEW_ACCEPTED, ppm.BlockHeight(), view, ppm.Content().SignedHeader().BlockHash())
 		tic.processPreprepare(ppm)
 	} else {
 		tic.logger.Info("LHFLOW LHMSG RECEIVED NEW_VIEW FAILED validation of PPM: %s", err)
@@ -0,0 +1,148 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package termincommittee
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/services/blockdissemination"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/pkg/errors"
+)
+
+// Erasure coded dissemination: the leader sends each member only its own chunk of the block, and every member forwards its chunk to everyone else.
+// Any f+1 chunks rebuild the block, so f silent members cannot keep it from an honest replica. Leader egress is about N/(f+1) blocks
+// instead of N-1, plus the blocks it sends in answer to BLOCK_REQUEST.
+func (tic *TermInCommittee) disperseBlockChunks(blockHeight primitives.BlockHeight, view primitives.View, blockHash primitives.BlockHash, block interfaces.Block) {
+	encodedBlock, err := tic.blockCodec.EncodeBlock(block)
+	if err != nil {
+		tic.logger.Info("LHMSG SEND BLOCK_CHUNK FAILED - cannot encode block: %s", err)
+		return
+	}
+	blockChunks, err := blockdissemination.SplitBlock(encodedBlock, len(tic.committeeMembers))
+	if err != nil {
+		tic.logger.Info("LHMSG SEND BLOCK_CHUNK FAILED - %s", err)
+		return
+	}
+
+	messages := tic.messageFactory.CreateBlockChunkMessages(blockHeight, view, blockHash, blockChunks)
+	tic.logger.Debug("LHMSG SEND BLOCK_CHUNK (msg: H=%d V=%d) %d chunks of %d bytes, %d needed", blockHeight, view, len(messages), len(blockChunks.Chunks[0]), blockChunks.DataChunks)
+	for i, member := range tic.committeeMembers {
+		if member.Id.Equal(tic.myMemberId) {
+			continue
+		}
+		if err := tic.sendConsensusMessageToSpecificMember(member.Id, messages[i]); err != nil {
+			tic.logger.Info("LHMSG SEND BLOCK_CHUNK to %s FAILED - %s", Str(member.Id), err)
+		}
+	}
+}
+
+func (tic *TermInCommittee) HandleBlockChunk(bcm *interfaces.BlockChunkMessage) {
+	header := bcm.Content().SignedHeader()
+	sender := bcm.Content().Sender()
+	index := bcm.Index()
+	tic.logger.Debug("LHMSG RECEIVED BLOCK_CHUNK (msg: H=%d V=%d sender=%s index=%d)", header.BlockHeight(), header.View(), Str(sender.MemberId()), index)
+
+	if tic.blockCodec == nil {
+		tic.logger.Info("LHMSG RECEIVED BLOCK_CHUNK IGNORE - no BlockCodec configured")
+		return
+	}
+
+	if header.BlockHeight() != tic.State.Height() || header.View() < tic.State.View() {
+		tic.logger.Debug("LHMSG RECEIVED BLOCK_CHUNK IGNORE - not of current height or of a past view")
+		return
+	}
+
+	key := string(header.Raw())
+	collector, ok := tic.chunkCollectors[key]
+	if !ok {
+		if err := tic.validateBlockChunkHeader(bcm); err != nil {
+			tic.logger.Info("LHMSG RECEIVED BLOCK_CHUNK IGNORE - %s", err)
+			if errors.Cause(err) == interfaces.ErrInvalidSignature {
+				tic.onMessageRejected.RejectMessage(bcm, err)
+			}
+			return
+		}
+		collector = blockdissemination.NewChunksCollector(int(header.DataChunks()), int(header.TotalChunks()), int(header.BlockSize()))
+		tic.chunkCollectors[key] = collector
+	}
+
+	if !blockdissemination.VerifyMerkleProof(header.ChunksRoot(), bcm.Chunk(), index, int(header.TotalChunks()), bcm.MerkleProof()) {
+		tic.logger.Info("LHMSG RECEIVED BLOCK_CHUNK IGNORE - chunk %d does not match the chunks root", index)
+		return
+	}
+
+	if !collector.Add(index, bcm.Chunk()) {
+		return
+	}
+
+	if tic.committeeMembers[index].Id.Equal(tic.myMemberId) {
+		tic.forwardMyBlockChunk(bcm)
+	}
+
+	if collector.CanRebuild() {
+		tic.rebuildBlockFromChunks(collector, header.BlockHeight(), header.BlockHash())
+	}
+}
+
+func (tic *TermInCommittee) validateBlockChunkHeader(bcm *interfaces.BlockChunkMessage) error {
+	header := bcm.Content().SignedHeader()
+	sender := bcm.Content().Sender()
+	committeeSize := len(tic.committeeMembers)
+
+	if err := tic.keyManager.VerifyConsensusMessage(header.BlockHeight(), header.Raw(), sender); err != nil {
+		return errors.Wrapf(interfaces.ErrInvalidSignature, "verification failed for BlockChunk header: %s", err)
+	}
+	if err := tic.isLeader(sender.MemberId(), header.View()); err != nil {
+		return errors.Wrap(err, "BLOCK_CHUNK header not signed by the leader")
+	}
+	if int(header.TotalChunks()) != committeeSize || int(header.DataChunks()) != blockdissemination.DataChunksFor(committeeSize) {
+		return errors.Errorf("BLOCK_CHUNK header has %d/%d chunks but committee of %d needs %d/%d", header.DataChunks(), header.TotalChunks(), committeeSize, blockdissemination.DataChunksFor(committeeSize), committeeSize)
+	}
+	if bcm.Index() >= committeeSize {
+		return errors.Errorf("BLOCK_CHUNK index %d is out of committee of %d", bcm.Index(), committeeSize)
+	}
+	return nil
+}
+
+// The leader does not need our chunk, everyone else does
+func (tic *TermInCommittee) forwardMyBlockChunk(bcm *interfaces.BlockChunkMessage) {
+	targets := make([]primitives.MemberId, 0, len(tic.otherCommitteeMemberIds))
+	for _, memberId := range tic.otherCommitteeMemberIds {
+		if !memberId.Equal(bcm.SenderMemberId()) {
+			targets = append(targets, memberId)
+		}
+	}
+	tic.logger.Debug("LHMSG SEND BLOCK_CHUNK forwarding my chunk %d to %d members", bcm.Index(), len(targets))
+	if err := tic.communication.SendConsensusMessage(context.TODO(), targets, bcm.ToConsensusRawMessage()); err != nil {
+		tic.logger.Info("LHMSG SEND BLOCK_CHUNK forwarding FAILED - %s", err)
+	}
+}
+
+func (tic *TermInCommittee) rebuildBlockFromChunks(collector *blockdissemination.ChunksCollector, blockHeight primitives.BlockHeight, blockHash primitives.BlockHash) {
+	encodedBlock, err := collector.Rebuild()
+	if err != nil {
+		tic.logger.Info("LHMSG BLOCK_CHUNK cannot rebuild block - %s", err)
+		return
+	}
+	block, err := tic.blockCodec.DecodeBlock(encodedBlock)
+	if err != nil {
+		tic.logger.Info("LHMSG BLOCK_CHUNK cannot decode rebuilt block - %s", err)
+		return
+	}
+	if !tic.blockUtils.ValidateBlockCommitment(blockHeight, block, blockHash) {
+		tic.logger.Info("LHMSG BLOCK_CHUNK rebuilt block does not match the block hash signed by the leader")
+		return
+	}
+
+	tic.logger.Debug("LHMSG BLOCK_CHUNK rebuilt block of H=%d", blockHeight)
+	tic.rebuiltBlocks[string(blockHash)] = block
+	if pending := tic.pendingProposal; pending != nil && pending.blockHeight == blockHeight && pending.blockHash.Equal(blockHash) {
+		tic.pendingProposal = nil
+		tic.handleProposalWithBlock(pending.message, block)
+	}
+}
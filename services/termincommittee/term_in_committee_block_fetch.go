@@ -0,0 +1,159 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package termincommittee
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/services/proofsvalidator"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/scribe/log"
+	"github.com/pkg/errors"
+)
+
+// A PREPREPARE or NEW_VIEW that arrived without its block, waiting for a peer to send the block with the proposed hash
+type pendingProposal struct {
+	message     interfaces.ConsensusMessage
+	blockHeight primitives.BlockHeight
+	view        primitives.View
+	blockHash   primitives.BlockHash
+}
+
+// With block dissemination by hash the leader sends its proposal without the block, replicas fetch it from any peer holding it
+// or rebuild it from erasure coded chunks.
+// PREPARE and COMMIT votes only refer to the block hash, so the way the block travels does not affect safety.
+func (tic *TermInCommittee) sendProposal(message interfaces.ConsensusMessage, block interfaces.Block, blockHash primitives.BlockHash) error {
+	rawMessage := interfaces.CreateConsensusRawMessage(message)
+	if tic.blockDisseminationByHash {
+		rawMessage.Block = nil
+	}
+	tic.logger.Debug("LHMSG SEND sendProposal() target=ALL, msgType=%v, withBlock=%t", message.MessageType(), rawMessage.Block != nil)
+	err := tic.communication.SendConsensusMessage(context.TODO(), tic.otherCommitteeMemberIds, rawMessage)
+	tic.logger.ConsensusTrace("sent proposal to all other members", err, log.Stringable("message-type", message.MessageType()))
+
+	if tic.blockDisseminationByHash && tic.blockCodec != nil {
+		tic.disperseBlockChunks(message.BlockHeight(), message.View(), blockHash, block)
+	}
+	return err
+}
+
+func (tic *TermInCommittee) fetchProposedBlock(message interfaces.ConsensusMessage, blockHeight primitives.BlockHeight, view primitives.View, blockHash primitives.BlockHash) {
+	if view < tic.State.View() {
+		tic.logger.Debug("LHMSG RECEIVED %s IGNORE - proposal without block of V=%d is older than current view %d", message.MessageType(), view, tic.State.View())
+		return
+	}
+
+	// a NEW_VIEW usually re-proposes a block we already received in an earlier view
+	if block := tic.findProposedBlock(blockHeight, view, blockHash); block != nil {
+		tic.logger.Debug("LHMSG RECEIVED %s without block, found block locally (msg: H=%d V=%d)", message.MessageType(), blockHeight, view)
+		tic.handleProposalWithBlock(message, block)
+		return
+	}
+
+	tic.pendingProposal = &pendingProposal{
+		message:     message,
+		blockHeight: blockHeight,
+		view:        view,
+		blockHash:   blockHash,
+	}
+	// with erasure coded blocks the request is sent as well, in case the block cannot be rebuilt from the chunks,
+	// without waiting for RequestMissingMessages() which does not run unless Config.RetransmissionInterval is set
+	tic.requestProposedBlock()
+}
+
+func (tic *TermInCommittee) requestProposedBlock() {
+	pending := tic.pendingProposal
+	brq := tic.messageFactory.CreateBlockRequestMessage(pending.blockHeight, pending.view, pending.blockHash)
+	tic.logger.Debug("LHMSG SEND BLOCK_REQUEST (msg: H=%d V=%d)", pending.blockHeight, pending.view)
+	if err := tic.sendConsensusMessage(brq); err != nil {
+		tic.logger.Info("LHMSG SEND BLOCK_REQUEST FAILED - %s", err)
+	}
+}
+
+// Looks for a block with the given hash among the blocks rebuilt from chunks and the PREPREPAREs we stored for the height,
+// from the given view down. The views searched are bounded by our current view, not by the view of a peer's message.
+func (tic *TermInCommittee) findProposedBlock(blockHeight primitives.BlockHeight, view primitives.View, blockHash primitives.BlockHash) interfaces.Block {
+	if block, ok := tic.rebuiltBlocks[string(blockHash)]; ok && block.Height() == blockHeight {
+		return block
+	}
+	if currentView := tic.State.View(); view > currentView {
+		view = currentView
+	}
+	for v := view; ; v-- {
+		if ppm, ok := tic.storage.GetPreprepareMessage(blockHeight, v); ok && ppm.Block() != nil && ppm.Content().SignedHeader().BlockHash().Equal(blockHash) {
+			return ppm.Block()
+		}
+		if v == 0 {
+			return nil
+		}
+	}
+}
+
+func (tic *TermInCommittee) handleProposalWithBlock(message interfaces.ConsensusMessage, block interfaces.Block) {
+	switch message := message.(type) {
+	case *interfaces.PreprepareMessage:
+		tic.HandlePrePrepare(interfaces.NewPreprepareMessage(message.Content(), block))
+	case *interfaces.NewViewMessage:
+		tic.HandleNewView(interfaces.NewNewViewMessage(message.Content(), block))
+	}
+}
+
+func (tic *TermInCommittee) HandleBlockRequest(brq *interfaces.BlockRequestMessage) {
+	tic.logger.Debug("LHMSG RECEIVED BLOCK_REQUEST (msg: H=%d V=%d sender=%s)",
+		brq.BlockHeight(), brq.View(), Str(brq.SenderMemberId()))
+	header := brq.Content().SignedHeader()
+	sender := brq.Content().Sender()
+
+	if current := tic.State.HeightView(); header.BlockHeight() != current.Height() || header.View() > current.View() {
+		tic.logger.Debug("LHMSG RECEIVED BLOCK_REQUEST IGNORE - H=%d V=%d is not of the current height or is above the current view, current: %s", header.BlockHeight(), header.View(), current)
+		return
+	}
+
+	if err := tic.keyManager.VerifyConsensusMessage(header.BlockHeight(), header.Raw(), sender); err != nil {
+		tic.logger.Info("LHMSG RECEIVED BLOCK_REQUEST IGNORE - verification failed for BlockRequest block-height=%d view=%d err=%v", header.BlockHeight(), header.View(), err)
+		tic.onMessageRejected.RejectMessage(brq, errors.Wrapf(interfaces.ErrInvalidSignature, "BLOCK_REQUEST: %s", err))
+		return
+	}
+
+	if !proofsvalidator.IsInMembers(tic.committeeMembers, sender.MemberId()) {
+		tic.logger.Info("LHMSG RECEIVED BLOCK_REQUEST IGNORE - sender %s is not a committee member", Str(sender.MemberId()))
+		tic.onMessageRejected.RejectMessage(brq, errors.Wrapf(interfaces.ErrNotACommitteeMember, "BLOCK_REQUEST from %s", Str(sender.MemberId())))
+		return
+	}
+
+	block := tic.findProposedBlock(header.BlockHeight(), header.View(), header.BlockHash())
+	if block == nil {
+		tic.logger.Debug("LHMSG RECEIVED BLOCK_REQUEST IGNORE - no block stored for H=%d V=%d with the requested hash", header.BlockHeight(), header.View())
+		return
+	}
+
+	response := tic.messageFactory.CreateBlockResponseMessage(header.BlockHeight(), header.View(), header.BlockHash(), block)
+	tic.logger.Debug("LHMSG SEND BLOCK_RESPONSE (msg: H=%d V=%d) to %s", header.BlockHeight(), header.View(), Str(sender.MemberId()))
+	if err := tic.sendConsensusMessageToSpecificMember(sender.MemberId(), response); err != nil {
+		tic.logger.Info("LHMSG SEND BLOCK_RESPONSE FAILED - %s", err)
+	}
+}
+
+func (tic *TermInCommittee) HandleBlockResponse(brs *interfaces.BlockResponseMessage) {
+	tic.logger.Debug("LHMSG RECEIVED BLOCK_RESPONSE (msg: H=%d V=%d sender=%s)",
+		brs.BlockHeight(), brs.View(), Str(brs.SenderMemberId()))
+
+	pending := tic.pendingProposal
+	if pending == nil || pending.blockHeight != brs.BlockHeight() || !pending.blockHash.Equal(brs.BlockHash()) {
+		tic.logger.Debug("LHMSG RECEIVED BLOCK_RESPONSE IGNORE - no pending proposal for this block")
+		return
+	}
+
+	if brs.Block() == nil || !tic.blockUtils.ValidateBlockCommitment(pending.blockHeight, brs.Block(), pending.blockHash) {
+		tic.logger.Info("LHMSG RECEIVED BLOCK_RESPONSE IGNORE - block from %s does not match the proposed block hash", Str(brs.SenderMemberId()))
+		return
+	}
+
+	// the block is authenticated by the hash signed by the leader, the responder's signature is not needed
+	tic.pendingProposal = nil
+	tic.handleProposalWithBlock(pending.message, brs.Block())
+}
@@ -0,0 +1,93 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package termincommittee
+
+import (
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/lean-helix-go/state"
+	"math/rand"
+	"time"
+)
+
+// Spacing between rebroadcasts doubles up to this many times the base interval
+const MaxRebroadcastBackoffExponent = 5
+
+type rebroadcastSchedule struct {
+	hv       *state.HeightView
+	attempts uint
+	nextAt   time.Time
+}
+
+func calcRebroadcastDelay(base time.Duration, attempts uint) time.Duration {
+	if attempts > MaxRebroadcastBackoffExponent {
+		attempts = MaxRebroadcastBackoffExponent
+	}
+	delay := base << attempts
+	return delay + time.Duration(rand.Int63n(int64(delay)/2+1)) // jitter keeps members from rebroadcasting in lockstep
+}
+
+// Called periodically by the worker loop.
+// Until the height is committed, resends our own latest COMMIT, PREPARE or VIEW_CHANGE of the current view,
+// with exponentially growing spacing. The schedule restarts whenever the view changes.
+func (tic *TermInCommittee) RebroadcastOwnMessages(baseInterval time.Duration, now time.Time) {
+	if tic.committedBlock != nil {
+		return
+	}
+
+	current := tic.State.HeightView()
+	schedule := tic.rebroadcast
+	if schedule == nil || schedule.hv.Height() != current.Height() || schedule.hv.View() != current.View() {
+		tic.rebroadcast = &rebroadcastSchedule{
+			hv:     current,
+			nextAt: now.Add(calcRebroadcastDelay(baseInterval, 0)),
+		}
+		return
+	}
+	if now.Before(schedule.nextAt) {
+		return
+	}
+
+	message := tic.latestOwnMessage(current.Height(), current.View())
+	if message == nil {
+		return
+	}
+	schedule.attempts++
+	schedule.nextAt = now.Add(calcRebroadcastDelay(baseInterval, schedule.attempts))
+
+	tic.logger.Debug("LHMSG REBROADCAST %s (msg: H=%d V=%d) attempt #%d", message.MessageType(), message.BlockHeight(), message.View(), schedule.attempts)
+	if vcm, ok := message.(*interfaces.ViewChangeMessage); ok {
+		tic.sendViewChange(tic.calcLeaderMemberId(current.View()), vcm)
+		return
+	}
+	if err := tic.sendConsensusMessage(message); err != nil {
+		tic.logger.Info("LHMSG REBROADCAST %s FAILED - %s", message.MessageType(), err)
+	}
+}
+
+// Returns the most advanced message we sent in the view
+func (tic *TermInCommittee) latestOwnMessage(height primitives.BlockHeight, view primitives.View) interfaces.ConsensusMessage {
+	commits, _ := tic.storage.GetCommitMessagesFromView(height, view)
+	for _, cm := range commits {
+		if cm.SenderMemberId().Equal(tic.myMemberId) {
+			return cm
+		}
+	}
+	prepares, _ := tic.storage.GetPrepareMessagesFromView(height, view)
+	for _, pm := range prepares {
+		if pm.SenderMemberId().Equal(tic.myMemberId) {
+			return pm
+		}
+	}
+	viewChanges, _ := tic.storage.GetViewChangeMessages(height, view)
+	for _, vcm := range viewChanges {
+		if vcm.SenderMemberId().Equal(tic.myMemberId) {
+			return vcm
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package termincommittee
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/services/proofsvalidator"
+	"github.com/orbs-network/lean-helix-go/services/storage"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// A requester is answered at most once per interval, so a faulty member cannot make us flood the network
+const MinIntervalBetweenMessagesResponses = 100 * time.Millisecond
+
+// Called periodically by the worker loop.
+// If we are still stuck in the same view as on the previous call, with a PREPREPARE but without a committed block,
+// we ask the members whose COMMIT we have not seen to retransmit their PREPARE and COMMIT messages of this view.
+// A proposal still waiting for its block has its BLOCK_REQUEST repeated instead.
+func (tic *TermInCommittee) RequestMissingMessages() {
+	current := tic.State.HeightView()
+	if tic.committedBlock != nil {
+		return
+	}
+
+	if tic.pendingProposal != nil && tic.pendingProposal.view >= current.View() {
+		tic.requestProposedBlock()
+		return
+	}
+
+	ppm, ok := tic.storage.GetPreprepareMessage(current.Height(), current.View())
+	if !ok {
+		tic.stuckHeightView = nil
+		return
+	}
+
+	if tic.stuckHeightView == nil || tic.stuckHeightView.Height() != current.Height() || tic.stuckHeightView.View() != current.View() {
+		tic.stuckHeightView = current
+		return
+	}
+
+	blockHash := ppm.Content().SignedHeader().BlockHash()
+	committed := make(map[storage.MemberIdStr]bool)
+	for _, memberId := range tic.storage.GetCommitSendersIds(current.Height(), current.View(), blockHash) {
+		committed[storage.MemberIdStr(memberId)] = true
+	}
+	targets := make([]primitives.MemberId, 0, len(tic.otherCommitteeMemberIds))
+	for _, memberId := range tic.otherCommitteeMemberIds {
+		if !committed[storage.MemberIdStr(memberId)] {
+			targets = append(targets, memberId)
+		}
+	}
+	if len(targets) == 0 {
+		return
+	}
+
+	mrm := tic.messageFactory.CreateMessagesRequestMessage(current.Height(), current.View())
+	tic.logger.Debug("LHMSG SEND MESSAGES_REQUEST (msg: H=%d V=%d) to %d members", current.Height(), current.View(), len(targets))
+	if err := tic.communication.SendConsensusMessage(context.TODO(), targets, mrm.ToConsensusRawMessage()); err != nil {
+		tic.logger.Info("LHMSG SEND MESSAGES_REQUEST FAILED - %s", err)
+	}
+}
+
+func (tic *TermInCommittee) HandleMessagesRequest(mrm *interfaces.MessagesRequestMessage) {
+	tic.logger.Debug("LHMSG RECEIVED MESSAGES_REQUEST (msg: H=%d V=%d sender=%s)",
+		mrm.BlockHeight(), mrm.View(), Str(mrm.SenderMemberId()))
+	header := mrm.Content().SignedHeader()
+	sender := mrm.Content().Sender()
+
+	if err := tic.keyManager.VerifyConsensusMessage(header.BlockHeight(), header.Raw(), sender); err != nil {
+		tic.logger.Info("LHMSG RECEIVED MESSAGES_REQUEST IGNORE - verification failed for MessagesRequest block-height=%d view=%d err=%v", header.BlockHeight(), header.View(), err)
+		tic.onMessageRejected.RejectMessage(mrm, errors.Wrapf(interfaces.ErrInvalidSignature, "MESSAGES_REQUEST: %s", err))
+		return
+	}
+
+	if !proofsvalidator.IsInMembers(tic.committeeMembers, sender.MemberId()) {
+		tic.logger.Info("LHMSG RECEIVED MESSAGES_REQUEST IGNORE - sender %s is not a committee member", Str(sender.MemberId()))
+		tic.onMessageRejected.RejectMessage(mrm, errors.Wrapf(interfaces.ErrNotACommitteeMember, "MESSAGES_REQUEST from %s", Str(sender.MemberId())))
+		return
+	}
+
+	requester := storage.MemberIdStr(sender.MemberId())
+	if lastResponse, ok := tic.lastMessagesResponses[requester]; ok && time.Since(lastResponse) < MinIntervalBetweenMessagesResponses {
+		tic.logger.Debug("LHMSG RECEIVED MESSAGES_REQUEST IGNORE - rate limited, last response to %s was %s ago", Str(sender.MemberId()), time.Since(lastResponse))
+		return
+	}
+
+	var prepares []*interfaces.PrepareMessage
+	var commits []*interfaces.CommitMessage
+	for _, message := range tic.storage.GetAllMessagesFromView(header.BlockHeight(), header.View()) {
+		switch message := message.(type) {
+		case *interfaces.PrepareMessage:
+			prepares = append(prepares, message)
+		case *interfaces.CommitMessage:
+			commits = append(commits, message)
+		}
+	}
+	if len(prepares) == 0 && len(commits) == 0 {
+		tic.logger.Debug("LHMSG RECEIVED MESSAGES_REQUEST IGNORE - no PREPARE or COMMIT messages stored for H=%d V=%d", header.BlockHeight(), header.View())
+		return
+	}
+
+	tic.lastMessagesResponses[requester] = time.Now()
+	response := tic.messageFactory.CreateMessagesResponseMessage(header.BlockHeight(), header.View(), prepares, commits)
+	tic.logger.Debug("LHMSG SEND MESSAGES_RESPONSE (msg: H=%d V=%d prepares=%d commits=%d)", header.BlockHeight(), header.View(), len(prepares), len(commits))
+	if err := tic.sendConsensusMessageToSpecificMember(sender.MemberId(), response); err != nil {
+		tic.logger.Info("LHMSG SEND MESSAGES_RESPONSE FAILED - %s", err)
+	}
+}
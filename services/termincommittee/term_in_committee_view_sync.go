@@ -0,0 +1,46 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package termincommittee
+
+import (
+	"github.com/orbs-network/lean-helix-go/services/quorum"
+	"github.com/orbs-network/lean-helix-go/services/storage"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+)
+
+// Keeps the highest view each member voted for with a valid VIEW_CHANGE
+func (tic *TermInCommittee) recordViewChangeVote(sender primitives.MemberId, view primitives.View) {
+	if latest, ok := tic.viewChangeVotes[storage.MemberIdStr(sender)]; !ok || view > latest {
+		tic.viewChangeVotes[storage.MemberIdStr(sender)] = view
+	}
+}
+
+// PBFT view synchronization: once members weighing more than f have voted for views above ours,
+// at least one honest member has timed out, so we join the smallest of those views without waiting for our own timer
+func (tic *TermInCommittee) checkViewSync() {
+	currentView := tic.State.View()
+	var senders []primitives.MemberId
+	var smallestView primitives.View
+	for sender, view := range tic.viewChangeVotes {
+		if view <= currentView {
+			continue
+		}
+		if len(senders) == 0 || view < smallestView {
+			smallestView = view
+		}
+		senders = append(senders, primitives.MemberId(sender))
+	}
+
+	hasHonest, totalWeight, byzMaxWeight := quorum.HasHonest(senders, tic.committeeMembers)
+	if !hasHonest {
+		return
+	}
+
+	tic.logger.Debug("LHFLOW checkViewSync() %d members with total weight of %d (more than %d) voted for views above V=%d, joining V=%d", len(senders), totalWeight, byzMaxWeight, currentView, smallestView)
+	tic.logViewMessages(" transition from view (%d) to view (%d) by checkViewSync", uint(currentView), uint(smallestView))
+	tic.moveToView(smallestView, nil)
+}
@@ -0,0 +1,84 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package test
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// runs the scheduled validations only when the test completes them
+type manualBlockValidationScheduler struct {
+	pending []func()
+}
+
+func (s *manualBlockValidationScheduler) ScheduleBlockValidation(ctx context.Context, validate func(ctx context.Context) error, onValidated func(err error)) {
+	s.pending = append(s.pending, func() {
+		err := validate(ctx)
+		if err == nil {
+			err = ctx.Err()
+		}
+		onValidated(err)
+	})
+}
+
+func (s *manualBlockValidationScheduler) completeValidations() {
+	pending := s.pending
+	s.pending = nil
+	for _, onValidated := range pending {
+		onValidated()
+	}
+}
+
+func TestNewViewIsAcceptedOnceItsBlockIsValidated(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		block := mocks.ABlock(interfaces.GenesisBlock)
+		scheduler := &manualBlockValidationScheduler{}
+		h := NewHarnessWithBlockValidationScheduler(ctx, t, []interfaces.Block{block}, scheduler)
+
+		h.setNode1AsTheLeader(ctx, 1, 1, block)
+		require.Len(t, scheduler.pending, 1)
+		h.assertView(0)
+
+		scheduler.completeValidations()
+		h.assertView(1)
+		require.True(t, h.hasPreprepare(1, 1, block))
+		require.Equal(t, 1, h.countPrepare(1, 1, block), "a PREPARE should be sent once the block is validated")
+	})
+}
+
+func TestTheSameViewIsNotValidatedTwiceConcurrently(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		block := mocks.ABlock(interfaces.GenesisBlock)
+		scheduler := &manualBlockValidationScheduler{}
+		h := NewHarnessWithBlockValidationScheduler(ctx, t, []interfaces.Block{block}, scheduler)
+
+		h.setNode1AsTheLeader(ctx, 1, 1, block)
+		h.setNode1AsTheLeader(ctx, 1, 1, block)
+		require.Len(t, scheduler.pending, 1)
+	})
+}
+
+func TestValidationCompletedAfterAViewChangeIsIgnored(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		block := mocks.ABlock(interfaces.GenesisBlock)
+		scheduler := &manualBlockValidationScheduler{}
+		h := NewHarnessWithBlockValidationScheduler(ctx, t, []interfaces.Block{block}, scheduler)
+
+		h.setNode1AsTheLeader(ctx, 1, 1, block)
+		h.electionTillView(ctx, 2) // elections are handled while the block is validated
+
+		scheduler.completeValidations()
+		h.assertView(2)
+		require.False(t, h.hasPreprepare(1, 1, block))
+		require.Equal(t, 0, h.countPrepare(1, 1, block))
+	})
+}
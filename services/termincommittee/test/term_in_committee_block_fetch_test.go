@@ -0,0 +1,114 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package test
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/services/messagesfactory"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
+	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/orbs-network/lean-helix-go/test/builders"
+	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"github.com/stretchr/testify/require"
+	"math"
+	"testing"
+)
+
+func withBlockDisseminationByHash(config *interfaces.Config) {
+	config.BlockDisseminationByHash = true
+}
+
+func (h *harness) receiveAndHandlePreprepareWithoutBlock(ctx context.Context, fromNode int, blockHeight primitives.BlockHeight, view primitives.View, block interfaces.Block) {
+	leader := h.net.Nodes[fromNode]
+	ppm := builders.APreprepareMessage(h.instanceId, leader.KeyManager, leader.MemberId, blockHeight, view, block)
+	h.termInCommittee.HandlePrePrepare(interfaces.NewPreprepareMessage(ppm.Content(), nil))
+}
+
+func (h *harness) receiveAndHandleBlockRequest(ctx context.Context, fromNode int, blockHeight primitives.BlockHeight, view primitives.View, block interfaces.Block) {
+	sender := h.net.Nodes[fromNode]
+	factory := messagesfactory.NewMessageFactory(h.instanceId, sender.KeyManager, sender.MemberId, 0)
+	h.termInCommittee.HandleBlockRequest(factory.CreateBlockRequestMessage(blockHeight, view, mocks.CalculateBlockHash(block)))
+}
+
+func (h *harness) receiveAndHandleBlockResponse(ctx context.Context, fromNode int, blockHeight primitives.BlockHeight, view primitives.View, blockHash primitives.BlockHash, block interfaces.Block) {
+	sender := h.net.Nodes[fromNode]
+	factory := messagesfactory.NewMessageFactory(h.instanceId, sender.KeyManager, sender.MemberId, 0)
+	h.termInCommittee.HandleBlockResponse(factory.CreateBlockResponseMessage(blockHeight, view, blockHash, block))
+}
+
+func TestLeaderSendsPreprepareWithoutBlockWhenDisseminatingByHash(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		block := mocks.ABlock(interfaces.GenesisBlock)
+		h := NewHarnessWithConfig(ctx, 0, nil, t, []interfaces.Block{block}, withBlockDisseminationByHash)
+
+		sent := h.myNode.Communication.GetSentMessages(protocol.LEAN_HELIX_PREPREPARE)
+		require.Len(t, sent, 1)
+		require.Nil(t, sent[0].Block, "PREPREPARE should carry only the block hash")
+		require.True(t, h.hasPreprepare(1, 0, block), "leader should keep its own block to serve BLOCK_REQUEST")
+
+		h.receiveAndHandleBlockRequest(ctx, 2, 1, 0, block)
+		responses := h.myNode.Communication.GetSentMessages(protocol.LEAN_HELIX_BLOCK_RESPONSE)
+		require.Len(t, responses, 1)
+		require.Equal(t, block, responses[0].Block)
+	})
+}
+
+func TestReplicaFetchesProposedBlockBeforePreparing(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		myBlock := mocks.ABlock(interfaces.GenesisBlock)
+		block := mocks.ABlock(interfaces.GenesisBlock)
+		otherBlock := mocks.ABlock(interfaces.GenesisBlock)
+		h := NewHarnessWithConfig(ctx, 0, nil, t, []interfaces.Block{myBlock}, withBlockDisseminationByHash)
+		h.triggerElection(ctx) // leaving V=0, in which I am the leader
+
+		h.receiveAndHandlePreprepareWithoutBlock(ctx, 1, 1, 1, block)
+		require.Equal(t, 1, h.countSentMessages(protocol.LEAN_HELIX_BLOCK_REQUEST), "should request the proposed block from peers")
+		require.False(t, h.hasPreprepare(1, 1, block))
+		require.Equal(t, 0, h.countPrepare(1, 1, block), "should not vote before validating the block")
+
+		blockHash := mocks.CalculateBlockHash(block)
+		h.receiveAndHandleBlockResponse(ctx, 2, 1, 1, blockHash, otherBlock)
+		require.False(t, h.hasPreprepare(1, 1, block), "a block not matching the proposed hash should be ignored")
+
+		h.termInCommittee.RequestMissingMessages()
+		require.Equal(t, 2, h.countSentMessages(protocol.LEAN_HELIX_BLOCK_REQUEST), "should repeat the BLOCK_REQUEST while the block is missing")
+
+		h.receiveAndHandleBlockResponse(ctx, 3, 1, 1, blockHash, block)
+		require.True(t, h.hasPreprepare(1, 1, block))
+		require.Equal(t, 1, h.countPrepare(1, 1, block), "should send PREPARE once the block arrived")
+	})
+}
+
+func TestReplicaUsesLocallyKnownBlockOfProposalWithoutBlock(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		block := mocks.ABlock(interfaces.GenesisBlock)
+		h := NewHarnessWithConfig(ctx, 0, nil, t, []interfaces.Block{block}, withBlockDisseminationByHash)
+		h.triggerElection(ctx) // leaving V=0, in which I proposed block
+
+		h.receiveAndHandlePreprepareWithoutBlock(ctx, 1, 1, 1, block)
+		require.Equal(t, 0, h.countSentMessages(protocol.LEAN_HELIX_BLOCK_REQUEST), "should not fetch a block it already holds")
+		require.True(t, h.hasPreprepare(1, 1, block))
+		require.Equal(t, 1, h.countPrepare(1, 1, block))
+	})
+}
+
+func TestBlockRequestOfAnotherHeightOrAFutureViewIsIgnored(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		block := mocks.ABlock(interfaces.GenesisBlock)
+		h := NewHarnessWithConfig(ctx, 0, nil, t, []interfaces.Block{block}, withBlockDisseminationByHash)
+		require.True(t, h.hasPreprepare(1, 0, block))
+
+		h.receiveAndHandleBlockRequest(ctx, 2, 2, 0, block)
+		h.receiveAndHandleBlockRequest(ctx, 2, 1, math.MaxUint64, block) // would count down every view to 0
+		require.Equal(t, 0, h.countSentMessages(protocol.LEAN_HELIX_BLOCK_RESPONSE))
+
+		h.receiveAndHandleBlockRequest(ctx, 2, 1, 0, block)
+		require.Equal(t, 1, h.countSentMessages(protocol.LEAN_HELIX_BLOCK_RESPONSE))
+	})
+}
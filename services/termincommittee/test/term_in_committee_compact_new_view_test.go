@@ -0,0 +1,127 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package test
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/services/messagesfactory"
+	"github.com/orbs-network/lean-helix-go/services/preparedmessages"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
+	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/orbs-network/lean-helix-go/test/builders"
+	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func withCompactNewView(config *interfaces.Config) {
+	config.CompactNewView = true
+}
+
+// node0 prepared blockOnView3 and node2 prepared blockOnView4, both voting node1 as the leader of V=5
+func (h *harness) votesPreparedOnViews3And4() (votes []*protocol.ViewChangeMessageContentBuilder, preparedOnView3 *preparedmessages.PreparedMessages, blockOnView3 interfaces.Block, blockOnView4 interfaces.Block) {
+	blockOnView3 = mocks.ABlock(interfaces.GenesisBlock)
+	preparedOnView3 = builders.CreatePreparedMessages(h.instanceId, h.net.Nodes[3], []builders.Sender{h.net.Nodes[0], h.net.Nodes[1], h.net.Nodes[2]}, 1, 3, blockOnView3)
+	blockOnView4 = mocks.ABlock(interfaces.GenesisBlock)
+	preparedOnView4 := builders.CreatePreparedMessages(h.instanceId, h.net.Nodes[0], []builders.Sender{h.net.Nodes[1], h.net.Nodes[2], h.net.Nodes[3]}, 1, 4, blockOnView4)
+
+	votes = builders.NewVotesBuilder(h.instanceId).
+		WithVote(h.getMemberKeyManager(0), h.getNodeMemberId(0), 1, 5, preparedOnView3).
+		WithVote(h.getMemberKeyManager(2), h.getNodeMemberId(2), 1, 5, preparedOnView4).
+		WithVote(h.getMemberKeyManager(3), h.getNodeMemberId(3), 1, 5, nil).
+		Build()
+	return
+}
+
+func TestCompactNewViewWithTheHighestPreparedProofIsAccepted(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		h := NewHarness(ctx, t)
+		votes, _, _, blockOnView4 := h.votesPreparedOnViews3And4()
+
+		nvm := builders.NewNewViewBuilder().
+			LeadBy(h.getMemberKeyManager(1), h.getNodeMemberId(1)).
+			WithViewChangeVotes(votes).
+			Compact().
+			OnBlock(blockOnView4).
+			OnBlockHeight(1).
+			OnView(5).
+			Build()
+		require.False(t, nvm.Content().SignedHeader().ViewChangeConfirmationsIterator().HasNext(), "a compact NEW_VIEW should not carry the full VIEW_CHANGE messages")
+
+		h.handleNewViewMessage(ctx, nvm)
+
+		h.assertView(5)
+		require.True(t, h.hasPreprepare(1, 5, blockOnView4))
+	})
+}
+
+func TestCompactNewViewIsRejectedIfItsPreparedProofIsNotTheHighest(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		h := NewHarness(ctx, t)
+		votes, preparedOnView3, blockOnView3, _ := h.votesPreparedOnViews3And4()
+
+		// node2 attests to a prepared V=4, the V=3 proof cannot justify re-proposing blockOnView3
+		nvm := builders.NewNewViewBuilder().
+			LeadBy(h.getMemberKeyManager(1), h.getNodeMemberId(1)).
+			WithViewChangeVotes(votes).
+			WithHighestPreparedProof(messagesfactory.CreatePreparedProofBuilderFromPreparedMessages(preparedOnView3)).
+			OnBlock(blockOnView3).
+			OnBlockHeight(1).
+			OnView(5).
+			Build()
+
+		h.handleNewViewMessage(ctx, nvm)
+
+		h.assertView(0)
+	})
+}
+
+func TestCompactNewViewIsRejectedWithoutPreparedProofIfAVoteIsPrepared(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		h := NewHarness(ctx, t)
+		votes, _, _, _ := h.votesPreparedOnViews3And4()
+
+		newBlock := mocks.ABlock(interfaces.GenesisBlock)
+		nvm := builders.NewNewViewBuilder().
+			LeadBy(h.getMemberKeyManager(1), h.getNodeMemberId(1)).
+			WithViewChangeVotes(votes).
+			WithHighestPreparedProof(nil).
+			OnBlock(newBlock).
+			OnBlockHeight(1).
+			OnView(5).
+			Build()
+
+		h.handleNewViewMessage(ctx, nvm)
+
+		h.assertView(0)
+	})
+}
+
+func TestLeaderSendsCompactNewViewWhenConfigured(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		block := mocks.ABlock(interfaces.GenesisBlock)
+		h := NewHarnessWithConfig(ctx, 0, nil, t, []interfaces.Block{block}, withCompactNewView)
+
+		// I am the leader of V=4 and vote for it myself
+		h.electionTillView(ctx, 4)
+		h.receiveAndHandleViewChange(ctx, 2, 1, 4)
+		h.receiveAndHandleViewChange(ctx, 3, 1, 4)
+
+		sent := h.myNode.Communication.GetSentMessages(protocol.LEAN_HELIX_NEW_VIEW)
+		require.Len(t, sent, 1)
+		nvm := interfaces.ToConsensusMessage(sent[0]).(*interfaces.NewViewMessage)
+		header := nvm.Content().SignedHeader()
+		require.False(t, header.ViewChangeConfirmationsIterator().HasNext(), "a compact NEW_VIEW should not carry the full VIEW_CHANGE messages")
+
+		compactConfirmations := 0
+		for iter := header.CompactConfirmationsIterator(); iter.HasNext(); iter.NextCompactConfirmations() {
+			compactConfirmations++
+		}
+		require.Equal(t, 3, compactConfirmations)
+	})
+}
@@ -0,0 +1,96 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package test
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
+	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func withStoreFutureViewChanges(config *interfaces.Config) {
+	config.StoreFutureViewChanges = true
+}
+
+func withBroadcastViewChange(config *interfaces.Config) {
+	config.BroadcastViewChange = true
+}
+
+func TestViewChangeIsSentOnlyToNextLeaderByDefault(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		h := NewHarness(ctx, t)
+
+		h.triggerElection(ctx)
+		h.assertView(1)
+
+		require.Equal(t, 1, h.countViewChangesSentTo(1, 1), "VIEW_CHANGE should be sent to node1, the leader of V=1")
+		require.Equal(t, 0, h.countViewChangesSentTo(1, 2), "VIEW_CHANGE should not be sent to members who are not the leader of V=1")
+		require.Equal(t, 0, h.countViewChangesSentTo(1, 3), "VIEW_CHANGE should not be sent to members who are not the leader of V=1")
+	})
+}
+
+func TestViewChangeIsBroadcastToCommitteeWhenConfigured(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		h := NewHarnessWithConfig(ctx, 0, nil, t, nil, withBroadcastViewChange)
+
+		h.triggerElection(ctx)
+		h.assertView(1)
+
+		for nodeIdx := 1; nodeIdx < 4; nodeIdx++ {
+			require.Equal(t, 1, h.countViewChangesSentTo(1, nodeIdx), "VIEW_CHANGE should be sent to all other committee members")
+		}
+	})
+}
+
+func TestViewChangeOfAViewLedByOthersIsOnlyCountedForViewSyncByDefault(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		h := NewHarnessWithConfig(ctx, 0, nil, t, nil, withBroadcastViewChange)
+
+		// node2 leads V=2, node1 weighs 2 which is not more than f=3
+		h.receiveAndHandleViewChange(ctx, 1, 1, 2)
+		h.assertView(0)
+		require.Equal(t, 0, h.countViewChange(1, 2), "VIEW_CHANGE of a view led by another member should not be stored")
+
+		// node1 + node2 weigh 5
+		h.receiveAndHandleViewChange(ctx, 2, 1, 2)
+		h.assertView(2)
+		require.Equal(t, 1, h.countViewChange(1, 2), "only our own VIEW_CHANGE should be stored")
+	})
+}
+
+func TestViewChangeOfAViewLedByOthersIsStoredWhenConfigured(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		h := NewHarnessWithConfig(ctx, 0, nil, t, nil, withStoreFutureViewChanges)
+
+		h.receiveAndHandleViewChange(ctx, 1, 1, 2)
+		h.assertView(0)
+		require.Equal(t, 1, h.countViewChange(1, 2), "VIEW_CHANGE of a future view led by another member should be stored")
+	})
+}
+
+func TestLeaderOfFutureViewIsElectedFromAlreadyCollectedVotes(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		block := mocks.ABlock(interfaces.GenesisBlock)
+		h := NewHarness(ctx, t, block)
+
+		// node2 (weight 3) already voted for V=4, in which I am the leader, while I'm still in V=0
+		h.receiveAndHandleViewChange(ctx, 2, 1, 4)
+		h.assertView(0)
+		require.Equal(t, 1, h.countViewChange(1, 4), "VIEW_CHANGE of a future view we lead should be stored")
+
+		h.electionTillView(ctx, 3)
+
+		// node3 (weight 4) completes the quorum of 7 together with the vote collected earlier
+		h.receiveAndHandleViewChange(ctx, 3, 1, 4)
+		h.assertView(4)
+		require.Equal(t, 1, h.countSentMessages(protocol.LEAN_HELIX_NEW_VIEW), "should be elected as leader of V=4 without waiting for another timeout")
+	})
+}
@@ -45,11 +45,24 @@ func NewHarness(ctx context.Context, t *testing.T, blocksPool ...interfaces.Bloc
 }
 
 func NewHarnessForNodeInd(ctx context.Context, nodeInd int, ticCommitCallback termincommittee.OnInCommitteeCommitCallback, t *testing.T, blocksPool []interfaces.Block) *harness {
+	return NewHarnessWithConfig(ctx, nodeInd, ticCommitCallback, t, blocksPool, nil)
+}
+
+func NewHarnessWithConfig(ctx context.Context, nodeInd int, ticCommitCallback termincommittee.OnInCommitteeCommitCallback, t *testing.T, blocksPool []interfaces.Block, configure func(config *interfaces.Config)) *harness {
+	return newHarness(ctx, nodeInd, ticCommitCallback, t, blocksPool, configure, nil)
+}
+
+func NewHarnessWithBlockValidationScheduler(ctx context.Context, t *testing.T, blocksPool []interfaces.Block, blockValidationScheduler interfaces.BlockValidationScheduler) *harness {
+	return newHarness(ctx, 0, nil, t, blocksPool, nil, blockValidationScheduler)
+}
+
+func newHarness(ctx context.Context, nodeInd int, ticCommitCallback termincommittee.OnInCommitteeCommitCallback, t *testing.T, blocksPool []interfaces.Block, configure func(config *interfaces.Config), blockValidationScheduler interfaces.BlockValidationScheduler) *harness {
 	net := network.
 		NewTestNetworkBuilder().
 		WithNodeCount(4).
 		WithNodeWeights([]primitives.MemberWeight{1, 2, 3, 4}).
 		WithBlocks(blocksPool...).
+		WithMockKeyManagers().
 		//LogToConsole().
 		Build(ctx)
 	myNode := net.Nodes[nodeInd]
@@ -60,6 +73,9 @@ func NewHarnessForNodeInd(ctx context.Context, nodeInd int, ticCommitCallback te
 		logOutput = logger.NewSilentLogger()
 	}
 	termConfig := myNode.BuildConfig(logOutput)
+	if configure != nil {
+		configure(termConfig)
+	}
 	log := logger.NewLhLogger(termConfig, mocks.NewMockState().State)
 
 	prevBlock := myNode.GetLatestBlock()
@@ -69,7 +85,7 @@ func NewHarnessForNodeInd(ctx context.Context, nodeInd int, ticCommitCallback te
 	log.Info("NewHarness calling NewTermInCommittee with H=%d", state.Height())
 
 	// TODO state.State is shadowing state.State and is generally meaninless
-	termInCommittee := termincommittee.NewTermInCommittee(log, termConfig, state.State, messageFactory, myNode.ElectionTrigger, committeeMembers, prevBlock, true, ticCommitCallback)
+	termInCommittee := termincommittee.NewTermInCommittee(log, termConfig, state.State, messageFactory, myNode.ElectionTrigger, committeeMembers, prevBlock, true, ticCommitCallback, blockValidationScheduler, nil)
 
 	return &harness{
 		t:               t,
@@ -77,7 +93,7 @@ func NewHarnessForNodeInd(ctx context.Context, nodeInd int, ticCommitCallback te
 		myMemberId:      myNode.MemberId,
 		myNode:          myNode,
 		net:             net,
-		keyManager:      myNode.KeyManager,
+		keyManager:      myNode.KeyManager.(*mocks.MockKeyManager),
 		termInCommittee: termInCommittee,
 		storage:         termConfig.Storage,
 		electionTrigger: myNode.ElectionTrigger,
@@ -217,6 +233,10 @@ func (h *harness) getLastSentViewChangeMessage() *interfaces.ViewChangeMessage {
 	return lastMessage.(*interfaces.ViewChangeMessage)
 }
 
+func (h *harness) countViewChangesSentTo(view primitives.View, toNodeIdx int) int {
+	return h.myNode.Communication.CountSentMessagesTo(protocol.LEAN_HELIX_VIEW_CHANGE, view, h.getNodeMemberId(toNodeIdx))
+}
+
 func (h *harness) countPrepare(blockHeight primitives.BlockHeight, view primitives.View, block interfaces.Block) int {
 	messages, _ := h.storage.GetPrepareMessages(blockHeight, view, mocks.CalculateBlockHash(block))
 	return len(messages)
@@ -0,0 +1,56 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package test
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type proposedAhead struct {
+	blockHeight   primitives.BlockHeight
+	memberId      primitives.MemberId
+	prevBlock     interfaces.Block
+	prevBlockHash primitives.BlockHash
+}
+
+type blockUtilsProposingAhead struct {
+	interfaces.BlockUtils
+	proposedAhead []*proposedAhead
+}
+
+func (b *blockUtilsProposingAhead) ProposeAhead(ctx context.Context, blockHeight primitives.BlockHeight, memberId primitives.MemberId, prevBlock interfaces.Block, prevBlockHash primitives.BlockHash) {
+	b.proposedAhead = append(b.proposedAhead, &proposedAhead{blockHeight, memberId, prevBlock, prevBlockHash})
+}
+
+func TestNextBlockIsProposedAheadOncePreparedLocally(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		block := mocks.ABlock(interfaces.GenesisBlock)
+		blockUtils := &blockUtilsProposingAhead{}
+		h := NewHarnessWithConfig(ctx, 0, nil, t, []interfaces.Block{block}, func(config *interfaces.Config) {
+			blockUtils.BlockUtils = config.BlockUtils
+			config.BlockUtils = blockUtils
+		})
+		h.setNode1AsTheLeader(ctx, 1, 1, block)
+
+		h.receiveAndHandlePreprepare(ctx, 1, 1, 1, block)
+		h.receiveAndHandlePrepare(ctx, 2, 1, 1, block)
+		require.Empty(t, blockUtils.proposedAhead, "the next block should not be built before the current one is prepared")
+
+		h.receiveAndHandlePrepare(ctx, 3, 1, 1, block)
+		require.Len(t, blockUtils.proposedAhead, 1)
+		require.Equal(t, primitives.BlockHeight(2), blockUtils.proposedAhead[0].blockHeight)
+		require.Equal(t, h.myMemberId, blockUtils.proposedAhead[0].memberId)
+		require.Equal(t, block, blockUtils.proposedAhead[0].prevBlock)
+		require.True(t, mocks.CalculateBlockHash(block).Equal(blockUtils.proposedAhead[0].prevBlockHash))
+	})
+}
@@ -0,0 +1,85 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package test
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
+	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestOwnPrepareIsRebroadcastWithExponentialSpacing(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		block := mocks.ABlock(interfaces.GenesisBlock)
+		h := NewHarness(ctx, t, block)
+		h.triggerElection(ctx)
+		h.setNode1AsTheLeader(ctx, 1, 1, block)
+		sent := h.countSentMessages(protocol.LEAN_HELIX_PREPARE)
+
+		base := 100 * time.Millisecond
+		start := time.Now()
+		h.termInCommittee.RebroadcastOwnMessages(base, start)
+		require.Equal(t, sent, h.countSentMessages(protocol.LEAN_HELIX_PREPARE), "the first call only schedules")
+
+		h.termInCommittee.RebroadcastOwnMessages(base, start.Add(base/2))
+		require.Equal(t, sent, h.countSentMessages(protocol.LEAN_HELIX_PREPARE))
+
+		first := start.Add(3 * base / 2) // latest possible time of first rebroadcast
+		h.termInCommittee.RebroadcastOwnMessages(base, first)
+		require.Equal(t, sent+1, h.countSentMessages(protocol.LEAN_HELIX_PREPARE))
+
+		h.termInCommittee.RebroadcastOwnMessages(base, first.Add(base*19/10))
+		require.Equal(t, sent+1, h.countSentMessages(protocol.LEAN_HELIX_PREPARE), "spacing should have doubled")
+
+		h.termInCommittee.RebroadcastOwnMessages(base, first.Add(3*base))
+		require.Equal(t, sent+2, h.countSentMessages(protocol.LEAN_HELIX_PREPARE))
+	})
+}
+
+func TestViewChangeIsRebroadcastAfterViewChangeAndNotAfterCommit(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		block := mocks.ABlock(interfaces.GenesisBlock)
+		committed := false
+		onCommit := func(ctx context.Context, block interfaces.Block, commitMessages []*interfaces.CommitMessage) {
+			committed = true
+		}
+		h := NewHarnessForNodeInd(ctx, 0, onCommit, t, []interfaces.Block{block})
+		h.triggerElection(ctx)
+		h.setNode1AsTheLeader(ctx, 1, 1, block)
+
+		base := 100 * time.Millisecond
+		now := time.Now()
+		h.termInCommittee.RebroadcastOwnMessages(base, now)
+
+		h.triggerElection(ctx) // V=2, led by node2
+		prepares := h.countSentMessages(protocol.LEAN_HELIX_PREPARE)
+		viewChanges := h.countSentMessages(protocol.LEAN_HELIX_VIEW_CHANGE)
+		now = now.Add(time.Minute)
+		h.termInCommittee.RebroadcastOwnMessages(base, now)
+		h.termInCommittee.RebroadcastOwnMessages(base, now.Add(time.Minute))
+		require.Equal(t, prepares, h.countSentMessages(protocol.LEAN_HELIX_PREPARE), "should stop rebroadcasting messages of the previous view")
+		require.Equal(t, viewChanges+1, h.countSentMessages(protocol.LEAN_HELIX_VIEW_CHANGE))
+
+		h.setNode1AsTheLeader(ctx, 1, 5, block)
+		h.receiveAndHandlePrepare(ctx, 2, 1, 5, block)
+		h.receiveAndHandlePrepare(ctx, 3, 1, 5, block)
+		h.receiveAndHandleCommit(ctx, 2, 1, 5, block, 0)
+		h.receiveAndHandleCommit(ctx, 3, 1, 5, block, 0)
+		require.True(t, committed)
+
+		commits := h.countSentMessages(protocol.LEAN_HELIX_COMMIT)
+		now = now.Add(time.Hour)
+		h.termInCommittee.RebroadcastOwnMessages(base, now)
+		h.termInCommittee.RebroadcastOwnMessages(base, now.Add(time.Hour))
+		require.Equal(t, commits, h.countSentMessages(protocol.LEAN_HELIX_COMMIT), "should not rebroadcast after commit")
+	})
+}
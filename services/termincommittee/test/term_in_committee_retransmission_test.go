@@ -0,0 +1,99 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package test
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/services/messagesfactory"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
+	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func (h *harness) receiveAndHandleMessagesRequest(fromNodeIdx int, blockHeight primitives.BlockHeight, view primitives.View) {
+	sender := h.net.Nodes[fromNodeIdx]
+	messageFactory := messagesfactory.NewMessageFactory(h.instanceId, sender.KeyManager, sender.MemberId, 0)
+	h.termInCommittee.HandleMessagesRequest(messageFactory.CreateMessagesRequestMessage(blockHeight, view))
+}
+
+func (h *harness) countSentMessages(messageType protocol.MessageType) int {
+	return h.myNode.Communication.CountSentMessages(messageType)
+}
+
+func TestMessagesAreRequestedWhenStuckWithPreprepare(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		block := mocks.ABlock(interfaces.GenesisBlock)
+		h := NewHarness(ctx, t, block)
+
+		h.triggerElection(ctx) // leaving V=0, in which I am the leader
+		h.termInCommittee.RequestMissingMessages()
+		h.termInCommittee.RequestMissingMessages()
+		require.Equal(t, 0, h.countSentMessages(protocol.LEAN_HELIX_MESSAGES_REQUEST), "should not request messages without a PREPREPARE")
+
+		h.setNode1AsTheLeader(ctx, 1, 1, block)
+		h.termInCommittee.RequestMissingMessages()
+		require.Equal(t, 0, h.countSentMessages(protocol.LEAN_HELIX_MESSAGES_REQUEST), "should not request messages before being stuck for a whole interval")
+
+		h.termInCommittee.RequestMissingMessages()
+		require.Equal(t, 1, h.countSentMessages(protocol.LEAN_HELIX_MESSAGES_REQUEST))
+
+		h.triggerElection(ctx)
+		h.termInCommittee.RequestMissingMessages()
+		require.Equal(t, 1, h.countSentMessages(protocol.LEAN_HELIX_MESSAGES_REQUEST), "should not request messages of a view without a PREPREPARE")
+	})
+}
+
+func TestMessagesRequestIsAnsweredFromStorageAndRateLimitedPerRequester(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		block := mocks.ABlock(interfaces.GenesisBlock)
+		h := NewHarness(ctx, t, block)
+		h.setNode1AsTheLeader(ctx, 1, 1, block)
+		h.receiveAndHandlePrepare(ctx, 2, 1, 1, block)
+
+		h.receiveAndHandleMessagesRequest(3, 1, 1)
+		require.Equal(t, 1, h.countSentMessages(protocol.LEAN_HELIX_MESSAGES_RESPONSE))
+
+		responses := h.myNode.Communication.GetSentMessages(protocol.LEAN_HELIX_MESSAGES_RESPONSE)
+		response := interfaces.ToConsensusMessage(responses[0]).(*interfaces.MessagesResponseMessage)
+		require.Equal(t, primitives.View(1), response.View())
+		var senders []primitives.MemberId
+		for _, pm := range response.PrepareMessages() {
+			senders = append(senders, pm.SenderMemberId())
+		}
+		require.ElementsMatch(t, []primitives.MemberId{h.getNodeMemberId(0), h.getNodeMemberId(2)}, senders)
+
+		h.receiveAndHandleMessagesRequest(3, 1, 1)
+		require.Equal(t, 1, h.countSentMessages(protocol.LEAN_HELIX_MESSAGES_RESPONSE), "requester should be rate limited")
+
+		h.receiveAndHandleMessagesRequest(2, 1, 1)
+		require.Equal(t, 2, h.countSentMessages(protocol.LEAN_HELIX_MESSAGES_RESPONSE), "rate limit should be per requester")
+
+		h.receiveAndHandleMessagesRequest(1, 1, 7)
+		require.Equal(t, 2, h.countSentMessages(protocol.LEAN_HELIX_MESSAGES_RESPONSE), "should not respond when nothing is stored for the view")
+	})
+}
+
+func TestMessagesRequestFromNonMemberOrWithBadSignatureIsIgnored(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		block := mocks.ABlock(interfaces.GenesisBlock)
+		h := NewHarness(ctx, t, block)
+		h.setNode1AsTheLeader(ctx, 1, 1, block)
+
+		stranger := primitives.MemberId("stranger")
+		messageFactory := messagesfactory.NewMessageFactory(h.instanceId, mocks.NewMockKeyManager(stranger), stranger, 0)
+		h.termInCommittee.HandleMessagesRequest(messageFactory.CreateMessagesRequestMessage(1, 1))
+		require.Equal(t, 0, h.countSentMessages(protocol.LEAN_HELIX_MESSAGES_RESPONSE))
+
+		h.failFutureVerifications()
+		h.receiveAndHandleMessagesRequest(3, 1, 1)
+		require.Equal(t, 0, h.countSentMessages(protocol.LEAN_HELIX_MESSAGES_RESPONSE))
+	})
+}
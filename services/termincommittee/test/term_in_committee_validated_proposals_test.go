@@ -0,0 +1,77 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package test
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type blockUtilsCountingValidations struct {
+	interfaces.BlockUtils
+	validations int
+}
+
+func (b *blockUtilsCountingValidations) ValidateBlockProposal(ctx context.Context, blockHeight primitives.BlockHeight, memberId primitives.MemberId, block interfaces.Block, blockHash primitives.BlockHash, prevBlock interfaces.Block) error {
+	b.validations++
+	return b.BlockUtils.ValidateBlockProposal(ctx, blockHeight, memberId, block, blockHash, prevBlock)
+}
+
+func newHarnessCountingValidations(ctx context.Context, t *testing.T, block interfaces.Block) (*harness, *blockUtilsCountingValidations) {
+	blockUtils := &blockUtilsCountingValidations{}
+	h := NewHarnessWithConfig(ctx, 0, nil, t, []interfaces.Block{block}, func(config *interfaces.Config) {
+		blockUtils.BlockUtils = config.BlockUtils
+		config.BlockUtils = blockUtils
+	})
+	return h, blockUtils
+}
+
+func TestBlockReproposedByTheSameLeaderIsValidatedOnce(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		block := mocks.ABlock(interfaces.GenesisBlock)
+		h, blockUtils := newHarnessCountingValidations(ctx, t, block)
+
+		h.setNode1AsTheLeader(ctx, 1, 1, block)
+		require.Equal(t, 1, blockUtils.validations)
+
+		// node1 leads V=5 as well and proposes the same block again
+		h.setNode1AsTheLeader(ctx, 1, 5, block)
+		h.assertView(5)
+		require.True(t, h.hasPreprepare(1, 5, block))
+		require.Equal(t, 1, blockUtils.validations, "the block should not be validated again")
+	})
+}
+
+func TestBlockProposedByAnotherLeaderIsValidatedAgain(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		block := mocks.ABlock(interfaces.GenesisBlock)
+		h, blockUtils := newHarnessCountingValidations(ctx, t, block)
+
+		h.setNode1AsTheLeader(ctx, 1, 1, block)
+		h.receiveAndHandleNewView(ctx, 2, 1, 2, block)
+		h.assertView(2)
+		require.Equal(t, 2, blockUtils.validations)
+	})
+}
+
+func TestFailedValidationsAreNotRemembered(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		block := mocks.ABlock(interfaces.GenesisBlock)
+		h, blockUtils := newHarnessCountingValidations(ctx, t, block)
+		h.failMyNodeBlockProposalValidations()
+
+		h.setNode1AsTheLeader(ctx, 1, 1, block)
+		h.setNode1AsTheLeader(ctx, 1, 5, block)
+		h.assertView(0)
+		require.Equal(t, 2, blockUtils.validations)
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/orbs-network/lean-helix-go/services/interfaces"
 	"github.com/orbs-network/lean-helix-go/test"
 	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
@@ -133,3 +134,25 @@ func TestNewViewVerification(t *testing.T) {
 		require.False(t, hasPreprepare, "A preprepare should NOT exist in the storage")
 	})
 }
+
+func TestMessagesFailingVerificationAreReportedAsInvalidSignature(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		var rejections []error
+		block := mocks.ABlock(interfaces.GenesisBlock)
+		h := NewHarnessWithConfig(ctx, 0, nil, t, []interfaces.Block{block}, func(config *interfaces.Config) {
+			config.OnMessageRejectedCB = func(message *interfaces.ConsensusRawMessage, err error) {
+				rejections = append(rejections, err)
+			}
+		})
+
+		h.failFutureVerifications()
+		h.receiveAndHandlePrepare(ctx, 1, 1, 0, block)
+		h.receiveAndHandleCommit(ctx, 2, 1, 0, block, 0)
+
+		require.Len(t, rejections, 2)
+		for _, err := range rejections {
+			require.Equal(t, interfaces.ErrInvalidSignature, errors.Cause(err))
+			require.True(t, interfaces.IsPeerMisbehavior(err))
+		}
+	})
+}
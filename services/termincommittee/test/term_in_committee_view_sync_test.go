@@ -0,0 +1,56 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package test
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestViewSyncJoinsSmallestHigherViewOfFPlus1ViewChanges(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		h := NewHarnessWithConfig(ctx, 0, nil, t, nil, withBroadcastViewChange)
+		h.assertView(0)
+
+		// node2 weighs 3, which is not more than f=3
+		h.receiveAndHandleViewChange(ctx, 2, 1, 3)
+		h.assertView(0)
+
+		// node2 + node3 weigh 7
+		h.receiveAndHandleViewChange(ctx, 3, 1, 5)
+		h.assertView(3)
+
+		lastVC := h.getLastSentViewChangeMessage()
+		require.Equal(t, h.getMyNodeMemberId(), lastVC.SenderMemberId())
+		require.Equal(t, 3, int(lastVC.View()), "should broadcast its own VIEW_CHANGE for the joined view")
+		require.Equal(t, 1, h.countViewChange(1, 3), "own VIEW_CHANGE should be stored, node2's vote for a view led by node3 is only counted")
+	})
+}
+
+func TestViewSyncIgnoresVotesForViewsNotAboveCurrent(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		h := NewHarnessWithConfig(ctx, 0, nil, t, nil, withBroadcastViewChange)
+		h.electionTillView(ctx, 2)
+
+		h.receiveAndHandleViewChange(ctx, 2, 1, 1)
+		h.receiveAndHandleViewChange(ctx, 3, 1, 2)
+		h.assertView(2)
+	})
+}
+
+func TestViewSyncIsOffWithoutBroadcastViewChange(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		h := NewHarness(ctx, t)
+
+		// node2 + node3 weigh 7, enough to join V=3 if VIEW_CHANGE were broadcast
+		h.receiveAndHandleViewChange(ctx, 2, 1, 3)
+		h.receiveAndHandleViewChange(ctx, 3, 1, 5)
+		h.assertView(0)
+	})
+}
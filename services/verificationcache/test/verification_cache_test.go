@@ -0,0 +1,132 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package test
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/services/verificationcache"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
+	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type countingKeyManager struct {
+	*mocks.MockKeyManager
+	verifications int
+}
+
+func (km *countingKeyManager) VerifyConsensusMessage(blockHeight primitives.BlockHeight, content []byte, sender *protocol.SenderSignature) error {
+	km.verifications++
+	return km.MockKeyManager.VerifyConsensusMessage(blockHeight, content, sender)
+}
+
+func signed(height primitives.BlockHeight, memberId primitives.MemberId, content []byte) *protocol.SenderSignature {
+	signer := mocks.NewMockKeyManager(memberId)
+	return (&protocol.SenderSignatureBuilder{
+		MemberId:  memberId,
+		Signature: signer.SignConsensusMessage(context.Background(), height, content),
+	}).Build()
+}
+
+func TestRepeatedVerificationIsServedFromCache(t *testing.T) {
+	km := &countingKeyManager{MockKeyManager: mocks.NewMockKeyManager(primitives.MemberId("me"))}
+	cache := verificationcache.NewVerificationCache(km, 10)
+	content := []byte("prepare")
+	sender := signed(5, primitives.MemberId("other"), content)
+
+	require.NoError(t, cache.VerifyConsensusMessage(5, content, sender))
+	require.NoError(t, cache.VerifyConsensusMessage(5, content, sender))
+	require.NoError(t, cache.VerifyConsensusMessage(5, content, sender))
+
+	require.Equal(t, 1, km.verifications)
+	m := cache.Metrics()
+	require.Equal(t, uint64(2), m.Hits())
+	require.Equal(t, uint64(1), m.Misses())
+	require.InDelta(t, 2.0/3.0, m.HitRate(), 0.0001)
+	require.Equal(t, 1, m.Size())
+}
+
+func TestFailedVerificationIsNotCached(t *testing.T) {
+	km := &countingKeyManager{MockKeyManager: mocks.NewMockKeyManager(primitives.MemberId("me"))}
+	cache := verificationcache.NewVerificationCache(km, 10)
+	content := []byte("prepare")
+	forged := (&protocol.SenderSignatureBuilder{
+		MemberId:  primitives.MemberId("other"),
+		Signature: primitives.Signature("forged"),
+	}).Build()
+
+	require.Error(t, cache.VerifyConsensusMessage(5, content, forged))
+	require.Error(t, cache.VerifyConsensusMessage(5, content, forged))
+	require.Equal(t, 2, km.verifications)
+	require.Equal(t, 0, cache.Metrics().Size())
+}
+
+func TestCachedContentWithDifferentSignatureIsVerifiedAgain(t *testing.T) {
+	km := &countingKeyManager{MockKeyManager: mocks.NewMockKeyManager(primitives.MemberId("me"))}
+	cache := verificationcache.NewVerificationCache(km, 10)
+	content := []byte("prepare")
+	require.NoError(t, cache.VerifyConsensusMessage(5, content, signed(5, primitives.MemberId("other"), content)))
+
+	forged := (&protocol.SenderSignatureBuilder{
+		MemberId:  primitives.MemberId("other"),
+		Signature: primitives.Signature("forged"),
+	}).Build()
+	require.Error(t, cache.VerifyConsensusMessage(5, content, forged))
+	require.Equal(t, 2, km.verifications)
+}
+
+func TestLeastRecentlyUsedIsEvicted(t *testing.T) {
+	km := &countingKeyManager{MockKeyManager: mocks.NewMockKeyManager(primitives.MemberId("me"))}
+	cache := verificationcache.NewVerificationCache(km, 2)
+	a, b, c := []byte("a"), []byte("b"), []byte("c")
+	sa, sb, sc := signed(5, primitives.MemberId("x"), a), signed(5, primitives.MemberId("x"), b), signed(5, primitives.MemberId("x"), c)
+
+	require.NoError(t, cache.VerifyConsensusMessage(5, a, sa))
+	require.NoError(t, cache.VerifyConsensusMessage(5, b, sb))
+	require.NoError(t, cache.VerifyConsensusMessage(5, a, sa)) // a is now most recent
+	require.NoError(t, cache.VerifyConsensusMessage(5, c, sc)) // evicts b
+	require.Equal(t, 3, km.verifications)
+
+	require.NoError(t, cache.VerifyConsensusMessage(5, a, sa))
+	require.Equal(t, 3, km.verifications)
+	require.NoError(t, cache.VerifyConsensusMessage(5, b, sb))
+	require.Equal(t, 4, km.verifications)
+}
+
+func TestClearOlderThanKeepsCurrentAndRecentHeights(t *testing.T) {
+	km := &countingKeyManager{MockKeyManager: mocks.NewMockKeyManager(primitives.MemberId("me"))}
+	cache := verificationcache.NewVerificationCache(km, 10)
+	content := []byte("commit")
+	for h := primitives.BlockHeight(1); h <= 4; h++ {
+		require.NoError(t, cache.VerifyConsensusMessage(h, content, signed(h, primitives.MemberId("x"), content)))
+	}
+
+	cache.ClearOlderThan(4)
+	require.Equal(t, 2, cache.Metrics().Size())
+
+	require.NoError(t, cache.VerifyConsensusMessage(2, content, signed(2, primitives.MemberId("x"), content)))
+	require.Equal(t, 5, km.verifications)
+	require.Equal(t, 2, cache.Metrics().Size(), "verifications of garbage-collected heights should not be cached again")
+}
+
+func TestAnotherSplitOfContentAndSignatureIsNotServedFromCache(t *testing.T) {
+	km := &countingKeyManager{MockKeyManager: mocks.NewMockKeyManager(primitives.MemberId("me"))}
+	cache := verificationcache.NewVerificationCache(km, 10)
+	content := []byte("prepare")
+	sender := signed(5, primitives.MemberId("other"), content)
+	require.NoError(t, cache.VerifyConsensusMessage(5, content, sender))
+
+	signature := sender.Signature()
+	shifted := (&protocol.SenderSignatureBuilder{
+		MemberId:  sender.MemberId(),
+		Signature: signature[1:],
+	}).Build()
+	require.Error(t, cache.VerifyConsensusMessage(5, append(append([]byte{}, content...), signature[0]), shifted))
+	require.Equal(t, 2, km.verifications)
+}
@@ -0,0 +1,164 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package verificationcache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"github.com/orbs-network/lean-helix-go/instrumentation/metrics"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
+	"sync"
+)
+
+// Number of heights below the current one whose verifications are kept
+const RETAINED_PAST_HEIGHTS = 1
+
+// Capacity used when the cache is required (e.g. by the verification pipeline) but no size was configured
+const DEFAULT_CAPACITY = 10000
+
+type verificationKind byte
+
+const (
+	consensusMessage verificationKind = iota
+	randomSeedShare
+)
+
+type cacheKey struct {
+	kind   verificationKind
+	height primitives.BlockHeight
+	digest [sha256.Size]byte // covers both content and signature, so a forged signature never hits
+	signer string
+}
+
+// VerificationCache wraps a KeyManager and remembers successful VerifyConsensusMessage and VerifyRandomSeed results,
+// so the same signed content is verified once whether it arrives alone, in a prepared proof or in a NEW_VIEW.
+// Failed verifications are never cached.
+type VerificationCache struct {
+	interfaces.KeyManager
+	lock      sync.Mutex
+	capacity  int
+	entries   map[cacheKey]*list.Element
+	lru       *list.List
+	minHeight primitives.BlockHeight
+	hits      uint64
+	misses    uint64
+}
+
+func NewVerificationCache(keyManager interfaces.KeyManager, capacity int) *VerificationCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &VerificationCache{
+		KeyManager: keyManager,
+		capacity:   capacity,
+		entries:    make(map[cacheKey]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+func keyOf(kind verificationKind, blockHeight primitives.BlockHeight, content []byte, sender *protocol.SenderSignature) cacheKey {
+	hash := sha256.New()
+	var contentLen [8]byte // so that no other split of content and signature hashes the same
+	binary.BigEndian.PutUint64(contentLen[:], uint64(len(content)))
+	hash.Write(contentLen[:])
+	hash.Write(content)
+	hash.Write(sender.Signature())
+	key := cacheKey{
+		kind:   kind,
+		height: blockHeight,
+		signer: string(sender.MemberId()),
+	}
+	copy(key.digest[:], hash.Sum(nil))
+	return key
+}
+
+func (c *VerificationCache) VerifyConsensusMessage(blockHeight primitives.BlockHeight, content []byte, sender *protocol.SenderSignature) error {
+	return c.verify(keyOf(consensusMessage, blockHeight, content, sender), func() error {
+		return c.KeyManager.VerifyConsensusMessage(blockHeight, content, sender)
+	})
+}
+
+func (c *VerificationCache) VerifyRandomSeed(blockHeight primitives.BlockHeight, content []byte, sender *protocol.SenderSignature) error {
+	return c.verify(keyOf(randomSeedShare, blockHeight, content, sender), func() error {
+		return c.KeyManager.VerifyRandomSeed(blockHeight, content, sender)
+	})
+}
+
+func (c *VerificationCache) verify(key cacheKey, verify func() error) error {
+	c.lock.Lock()
+	if element, found := c.entries[key]; found {
+		c.lru.MoveToFront(element)
+		c.hits++
+		c.lock.Unlock()
+		return nil
+	}
+	c.misses++
+	c.lock.Unlock()
+
+	if err := verify(); err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if key.height < c.minHeight {
+		return nil
+	}
+	if _, found := c.entries[key]; !found {
+		c.entries[key] = c.lru.PushFront(key)
+		c.evictOverCapacity()
+	}
+	return nil
+}
+
+// ClearOlderThan drops all verifications of heights below the retention window of currentHeight
+func (c *VerificationCache) ClearOlderThan(currentHeight primitives.BlockHeight) {
+	var minHeight primitives.BlockHeight
+	if currentHeight > RETAINED_PAST_HEIGHTS {
+		minHeight = currentHeight - RETAINED_PAST_HEIGHTS
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if minHeight <= c.minHeight {
+		return
+	}
+	c.minHeight = minHeight
+	for key, element := range c.entries {
+		if key.height < minHeight {
+			c.lru.Remove(element)
+			delete(c.entries, key)
+		}
+	}
+}
+
+func (c *VerificationCache) Resize(capacity int) {
+	if capacity < 1 {
+		capacity = 1
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.capacity = capacity
+	c.evictOverCapacity()
+}
+
+func (c *VerificationCache) evictOverCapacity() {
+	for c.lru.Len() > c.capacity {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(cacheKey))
+	}
+}
+
+func (c *VerificationCache) Metrics() metrics.VerificationCacheMetrics {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return metrics.NewVerificationCacheMetrics(c.hits, c.misses, c.lru.Len())
+}
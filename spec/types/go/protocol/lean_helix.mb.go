@@ -29,7 +29,7 @@ func (x *LeanhelixContent) String() string {
 }
 
 var _LeanhelixContent_Scheme = []membuffers.FieldType{membuffers.TypeUnion}
-var _LeanhelixContent_Unions = [][]membuffers.FieldType{{membuffers.TypeMessage, membuffers.TypeMessage, membuffers.TypeMessage, membuffers.TypeMessage, membuffers.TypeMessage}}
+var _LeanhelixContent_Unions = [][]membuffers.FieldType{{membuffers.TypeMessage, membuffers.TypeMessage, membuffers.TypeMessage, membuffers.TypeMessage, membuffers.TypeMessage, membuffers.TypeMessage, membuffers.TypeMessage, membuffers.TypeMessage, membuffers.TypeMessage, membuffers.TypeMessage, membuffers.TypeMessage}}
 
 func LeanhelixContentReader(buf []byte) *LeanhelixContent {
 	x := &LeanhelixContent{}
@@ -63,6 +63,12 @@ const (
 	LEANHELIX_CONTENT_MESSAGE_COMMIT_MESSAGE      LeanhelixContentMessage = 2
 	LEANHELIX_CONTENT_MESSAGE_VIEW_CHANGE_MESSAGE LeanhelixContentMessage = 3
 	LEANHELIX_CONTENT_MESSAGE_NEW_VIEW_MESSAGE    LeanhelixContentMessage = 4
+	LEANHELIX_CONTENT_MESSAGE_MESSAGES_REQUEST    LeanhelixContentMessage = 5
+	LEANHELIX_CONTENT_MESSAGE_MESSAGES_RESPONSE   LeanhelixContentMessage = 6
+	LEANHELIX_CONTENT_MESSAGE_COMMIT_CERTIFICATE  LeanhelixContentMessage = 7
+	LEANHELIX_CONTENT_MESSAGE_BLOCK_REQUEST       LeanhelixContentMessage = 8
+	LEANHELIX_CONTENT_MESSAGE_BLOCK_RESPONSE      LeanhelixContentMessage = 9
+	LEANHELIX_CONTENT_MESSAGE_BLOCK_CHUNK         LeanhelixContentMessage = 10
 )
 
 func (x *LeanhelixContent) Message() LeanhelixContentMessage {
@@ -159,6 +165,114 @@ func (x *LeanhelixContent) StringNewViewMessage() string {
 	return x.NewViewMessage().String()
 }
 
+func (x *LeanhelixContent) IsMessageMessagesRequest() bool {
+	is, _ := x._message.IsUnionIndex(0, 0, 5)
+	return is
+}
+
+func (x *LeanhelixContent) MessagesRequest() *MessagesRequestContent {
+	is, off := x._message.IsUnionIndex(0, 0, 5)
+	if !is {
+		panic("Accessed union field of incorrect type, did you check which union type it is first?")
+	}
+	b, s := x._message.GetMessageInOffset(off)
+	return MessagesRequestContentReader(b[:s])
+}
+
+func (x *LeanhelixContent) StringMessagesRequest() string {
+	return x.MessagesRequest().String()
+}
+
+func (x *LeanhelixContent) IsMessageMessagesResponse() bool {
+	is, _ := x._message.IsUnionIndex(0, 0, 6)
+	return is
+}
+
+func (x *LeanhelixContent) MessagesResponse() *MessagesResponseContent {
+	is, off := x._message.IsUnionIndex(0, 0, 6)
+	if !is {
+		panic("Accessed union field of incorrect type, did you check which union type it is first?")
+	}
+	b, s := x._message.GetMessageInOffset(off)
+	return MessagesResponseContentReader(b[:s])
+}
+
+func (x *LeanhelixContent) StringMessagesResponse() string {
+	return x.MessagesResponse().String()
+}
+
+func (x *LeanhelixContent) IsMessageCommitCertificate() bool {
+	is, _ := x._message.IsUnionIndex(0, 0, 7)
+	return is
+}
+
+func (x *LeanhelixContent) CommitCertificate() *CommitCertificateContent {
+	is, off := x._message.IsUnionIndex(0, 0, 7)
+	if !is {
+		panic("Accessed union field of incorrect type, did you check which union type it is first?")
+	}
+	b, s := x._message.GetMessageInOffset(off)
+	return CommitCertificateContentReader(b[:s])
+}
+
+func (x *LeanhelixContent) StringCommitCertificate() string {
+	return x.CommitCertificate().String()
+}
+
+func (x *LeanhelixContent) IsMessageBlockRequest() bool {
+	is, _ := x._message.IsUnionIndex(0, 0, 8)
+	return is
+}
+
+func (x *LeanhelixContent) BlockRequest() *BlockRequestContent {
+	is, off := x._message.IsUnionIndex(0, 0, 8)
+	if !is {
+		panic("Accessed union field of incorrect type, did you check which union type it is first?")
+	}
+	b, s := x._message.GetMessageInOffset(off)
+	return BlockRequestContentReader(b[:s])
+}
+
+func (x *LeanhelixContent) StringBlockRequest() string {
+	return x.BlockRequest().String()
+}
+
+func (x *LeanhelixContent) IsMessageBlockResponse() bool {
+	is, _ := x._message.IsUnionIndex(0, 0, 9)
+	return is
+}
+
+func (x *LeanhelixContent) BlockResponse() *BlockResponseContent {
+	is, off := x._message.IsUnionIndex(0, 0, 9)
+	if !is {
+		panic("Accessed union field of incorrect type, did you check which union type it is first?")
+	}
+	b, s := x._message.GetMessageInOffset(off)
+	return BlockResponseContentReader(b[:s])
+}
+
+func (x *LeanhelixContent) StringBlockResponse() string {
+	return x.BlockResponse().String()
+}
+
+func (x *LeanhelixContent) IsMessageBlockChunk() bool {
+	is, _ := x._message.IsUnionIndex(0, 0, 10)
+	return is
+}
+
+func (x *LeanhelixContent) BlockChunk() *BlockChunkContent {
+	is, off := x._message.IsUnionIndex(0, 0, 10)
+	if !is {
+		panic("Accessed union field of incorrect type, did you check which union type it is first?")
+	}
+	b, s := x._message.GetMessageInOffset(off)
+	return BlockChunkContentReader(b[:s])
+}
+
+func (x *LeanhelixContent) StringBlockChunk() string {
+	return x.BlockChunk().String()
+}
+
 func (x *LeanhelixContent) RawMessage() []byte {
 	return x._message.RawBufferForField(0, 0)
 }
@@ -179,6 +293,18 @@ func (x *LeanhelixContent) StringMessage() string {
 		return "(ViewChangeMessage)" + x.StringViewChangeMessage()
 	case LEANHELIX_CONTENT_MESSAGE_NEW_VIEW_MESSAGE:
 		return "(NewViewMessage)" + x.StringNewViewMessage()
+	case LEANHELIX_CONTENT_MESSAGE_MESSAGES_REQUEST:
+		return "(MessagesRequest)" + x.StringMessagesRequest()
+	case LEANHELIX_CONTENT_MESSAGE_MESSAGES_RESPONSE:
+		return "(MessagesResponse)" + x.StringMessagesResponse()
+	case LEANHELIX_CONTENT_MESSAGE_COMMIT_CERTIFICATE:
+		return "(CommitCertificate)" + x.StringCommitCertificate()
+	case LEANHELIX_CONTENT_MESSAGE_BLOCK_REQUEST:
+		return "(BlockRequest)" + x.StringBlockRequest()
+	case LEANHELIX_CONTENT_MESSAGE_BLOCK_RESPONSE:
+		return "(BlockResponse)" + x.StringBlockResponse()
+	case LEANHELIX_CONTENT_MESSAGE_BLOCK_CHUNK:
+		return "(BlockChunk)" + x.StringBlockChunk()
 	}
 	return "(Unknown)"
 }
@@ -192,6 +318,12 @@ type LeanhelixContentBuilder struct {
 	CommitMessage     *CommitContentBuilder
 	ViewChangeMessage *ViewChangeMessageContentBuilder
 	NewViewMessage    *NewViewMessageContentBuilder
+	MessagesRequest   *MessagesRequestContentBuilder
+	MessagesResponse  *MessagesResponseContentBuilder
+	CommitCertificate *CommitCertificateContentBuilder
+	BlockRequest      *BlockRequestContentBuilder
+	BlockResponse     *BlockResponseContentBuilder
+	BlockChunk        *BlockChunkContentBuilder
 
 	// internal
 	// implements membuffers.Builder
@@ -226,6 +358,18 @@ func (w *LeanhelixContentBuilder) Write(buf []byte) (err error) {
 		w._builder.WriteMessage(buf, w.ViewChangeMessage)
 	case LEANHELIX_CONTENT_MESSAGE_NEW_VIEW_MESSAGE:
 		w._builder.WriteMessage(buf, w.NewViewMessage)
+	case LEANHELIX_CONTENT_MESSAGE_MESSAGES_REQUEST:
+		w._builder.WriteMessage(buf, w.MessagesRequest)
+	case LEANHELIX_CONTENT_MESSAGE_MESSAGES_RESPONSE:
+		w._builder.WriteMessage(buf, w.MessagesResponse)
+	case LEANHELIX_CONTENT_MESSAGE_COMMIT_CERTIFICATE:
+		w._builder.WriteMessage(buf, w.CommitCertificate)
+	case LEANHELIX_CONTENT_MESSAGE_BLOCK_REQUEST:
+		w._builder.WriteMessage(buf, w.BlockRequest)
+	case LEANHELIX_CONTENT_MESSAGE_BLOCK_RESPONSE:
+		w._builder.WriteMessage(buf, w.BlockResponse)
+	case LEANHELIX_CONTENT_MESSAGE_BLOCK_CHUNK:
+		w._builder.WriteMessage(buf, w.BlockChunk)
 	}
 	return nil
 }
@@ -252,6 +396,18 @@ func (w *LeanhelixContentBuilder) HexDump(prefix string, offsetFromStart membuff
 		w._builder.HexDumpMessage(prefix, offsetFromStart, "LeanhelixContent.ViewChangeMessage", w.ViewChangeMessage)
 	case LEANHELIX_CONTENT_MESSAGE_NEW_VIEW_MESSAGE:
 		w._builder.HexDumpMessage(prefix, offsetFromStart, "LeanhelixContent.NewViewMessage", w.NewViewMessage)
+	case LEANHELIX_CONTENT_MESSAGE_MESSAGES_REQUEST:
+		w._builder.HexDumpMessage(prefix, offsetFromStart, "LeanhelixContent.MessagesRequest", w.MessagesRequest)
+	case LEANHELIX_CONTENT_MESSAGE_MESSAGES_RESPONSE:
+		w._builder.HexDumpMessage(prefix, offsetFromStart, "LeanhelixContent.MessagesResponse", w.MessagesResponse)
+	case LEANHELIX_CONTENT_MESSAGE_COMMIT_CERTIFICATE:
+		w._builder.HexDumpMessage(prefix, offsetFromStart, "LeanhelixContent.CommitCertificate", w.CommitCertificate)
+	case LEANHELIX_CONTENT_MESSAGE_BLOCK_REQUEST:
+		w._builder.HexDumpMessage(prefix, offsetFromStart, "LeanhelixContent.BlockRequest", w.BlockRequest)
+	case LEANHELIX_CONTENT_MESSAGE_BLOCK_RESPONSE:
+		w._builder.HexDumpMessage(prefix, offsetFromStart, "LeanhelixContent.BlockResponse", w.BlockResponse)
+	case LEANHELIX_CONTENT_MESSAGE_BLOCK_CHUNK:
+		w._builder.HexDumpMessage(prefix, offsetFromStart, "LeanhelixContent.BlockChunk", w.BlockChunk)
 	}
 	return nil
 }
@@ -819,6 +975,7 @@ func CommitContentBuilderFromRaw(raw []byte) *CommitContentBuilder {
 type ViewChangeMessageContent struct {
 	// SignedHeader ViewChangeHeader
 	// Sender SenderSignature
+	// SummarySender SenderSignature
 
 	// internal
 	// implements membuffers.Message
@@ -829,10 +986,10 @@ func (x *ViewChangeMessageContent) String() string {
 	if x == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("{SignedHeader:%s,Sender:%s,}", x.StringSignedHeader(), x.StringSender())
+	return fmt.Sprintf("{SignedHeader:%s,Sender:%s,SummarySender:%s,}", x.StringSignedHeader(), x.StringSender(), x.StringSummarySender())
 }
 
-var _ViewChangeMessageContent_Scheme = []membuffers.FieldType{membuffers.TypeMessage, membuffers.TypeMessage}
+var _ViewChangeMessageContent_Scheme = []membuffers.FieldType{membuffers.TypeMessage, membuffers.TypeMessage, membuffers.TypeMessage}
 var _ViewChangeMessageContent_Unions = [][]membuffers.FieldType{}
 
 func ViewChangeMessageContentReader(buf []byte) *ViewChangeMessageContent {
@@ -893,11 +1050,29 @@ func (x *ViewChangeMessageContent) StringSender() string {
 	return x.Sender().String()
 }
 
+func (x *ViewChangeMessageContent) SummarySender() *SenderSignature {
+	b, s := x._message.GetMessage(2)
+	return SenderSignatureReader(b[:s])
+}
+
+func (x *ViewChangeMessageContent) RawSummarySender() []byte {
+	return x._message.RawBufferForField(2, 0)
+}
+
+func (x *ViewChangeMessageContent) RawSummarySenderWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(2, 0)
+}
+
+func (x *ViewChangeMessageContent) StringSummarySender() string {
+	return x.SummarySender().String()
+}
+
 // builder
 
 type ViewChangeMessageContentBuilder struct {
-	SignedHeader *ViewChangeHeaderBuilder
-	Sender       *SenderSignatureBuilder
+	SignedHeader  *ViewChangeHeaderBuilder
+	Sender        *SenderSignatureBuilder
+	SummarySender *SenderSignatureBuilder
 
 	// internal
 	// implements membuffers.Builder
@@ -928,6 +1103,10 @@ func (w *ViewChangeMessageContentBuilder) Write(buf []byte) (err error) {
 	if err != nil {
 		return
 	}
+	err = w._builder.WriteMessage(buf, w.SummarySender)
+	if err != nil {
+		return
+	}
 	return nil
 }
 
@@ -949,6 +1128,10 @@ func (w *ViewChangeMessageContentBuilder) HexDump(prefix string, offsetFromStart
 	if err != nil {
 		return
 	}
+	err = w._builder.HexDumpMessage(prefix, offsetFromStart, "ViewChangeMessageContent.SummarySender", w.SummarySender)
+	if err != nil {
+		return
+	}
 	return nil
 }
 
@@ -979,6 +1162,174 @@ func ViewChangeMessageContentBuilderFromRaw(raw []byte) *ViewChangeMessageConten
 	return &ViewChangeMessageContentBuilder{_overrideWithRawBuffer: raw}
 }
 
+/////////////////////////////////////////////////////////////////////////////
+// message ViewChangeSummaryContent
+
+// reader
+
+type ViewChangeSummaryContent struct {
+	// SignedHeader ViewChangeSummaryHeader
+	// Sender SenderSignature
+
+	// internal
+	// implements membuffers.Message
+	_message membuffers.InternalMessage
+}
+
+func (x *ViewChangeSummaryContent) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("{SignedHeader:%s,Sender:%s,}", x.StringSignedHeader(), x.StringSender())
+}
+
+var _ViewChangeSummaryContent_Scheme = []membuffers.FieldType{membuffers.TypeMessage, membuffers.TypeMessage}
+var _ViewChangeSummaryContent_Unions = [][]membuffers.FieldType{}
+
+func ViewChangeSummaryContentReader(buf []byte) *ViewChangeSummaryContent {
+	x := &ViewChangeSummaryContent{}
+	x._message.Init(buf, membuffers.Offset(len(buf)), _ViewChangeSummaryContent_Scheme, _ViewChangeSummaryContent_Unions)
+	return x
+}
+
+func (x *ViewChangeSummaryContent) IsValid() bool {
+	return x._message.IsValid()
+}
+
+func (x *ViewChangeSummaryContent) Raw() []byte {
+	return x._message.RawBuffer()
+}
+
+func (x *ViewChangeSummaryContent) Equal(y *ViewChangeSummaryContent) bool {
+	if x == nil && y == nil {
+		return true
+	}
+	if x == nil || y == nil {
+		return false
+	}
+	return bytes.Equal(x.Raw(), y.Raw())
+}
+
+func (x *ViewChangeSummaryContent) SignedHeader() *ViewChangeSummaryHeader {
+	b, s := x._message.GetMessage(0)
+	return ViewChangeSummaryHeaderReader(b[:s])
+}
+
+func (x *ViewChangeSummaryContent) RawSignedHeader() []byte {
+	return x._message.RawBufferForField(0, 0)
+}
+
+func (x *ViewChangeSummaryContent) RawSignedHeaderWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(0, 0)
+}
+
+func (x *ViewChangeSummaryContent) StringSignedHeader() string {
+	return x.SignedHeader().String()
+}
+
+func (x *ViewChangeSummaryContent) Sender() *SenderSignature {
+	b, s := x._message.GetMessage(1)
+	return SenderSignatureReader(b[:s])
+}
+
+func (x *ViewChangeSummaryContent) RawSender() []byte {
+	return x._message.RawBufferForField(1, 0)
+}
+
+func (x *ViewChangeSummaryContent) RawSenderWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(1, 0)
+}
+
+func (x *ViewChangeSummaryContent) StringSender() string {
+	return x.Sender().String()
+}
+
+// builder
+
+type ViewChangeSummaryContentBuilder struct {
+	SignedHeader *ViewChangeSummaryHeaderBuilder
+	Sender       *SenderSignatureBuilder
+
+	// internal
+	// implements membuffers.Builder
+	_builder               membuffers.InternalBuilder
+	_overrideWithRawBuffer []byte
+}
+
+func (w *ViewChangeSummaryContentBuilder) Write(buf []byte) (err error) {
+	if w == nil {
+		return
+	}
+	w._builder.NotifyBuildStart()
+	defer w._builder.NotifyBuildEnd()
+	defer func() {
+		if r := recover(); r != nil {
+			err = &membuffers.ErrBufferOverrun{}
+		}
+	}()
+	if w._overrideWithRawBuffer != nil {
+		return w._builder.WriteOverrideWithRawBuffer(buf, w._overrideWithRawBuffer)
+	}
+	w._builder.Reset()
+	err = w._builder.WriteMessage(buf, w.SignedHeader)
+	if err != nil {
+		return
+	}
+	err = w._builder.WriteMessage(buf, w.Sender)
+	if err != nil {
+		return
+	}
+	return nil
+}
+
+func (w *ViewChangeSummaryContentBuilder) HexDump(prefix string, offsetFromStart membuffers.Offset) (err error) {
+	if w == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = &membuffers.ErrBufferOverrun{}
+		}
+	}()
+	w._builder.Reset()
+	err = w._builder.HexDumpMessage(prefix, offsetFromStart, "ViewChangeSummaryContent.SignedHeader", w.SignedHeader)
+	if err != nil {
+		return
+	}
+	err = w._builder.HexDumpMessage(prefix, offsetFromStart, "ViewChangeSummaryContent.Sender", w.Sender)
+	if err != nil {
+		return
+	}
+	return nil
+}
+
+func (w *ViewChangeSummaryContentBuilder) GetSize() membuffers.Offset {
+	if w == nil {
+		return 0
+	}
+	return w._builder.GetSize()
+}
+
+func (w *ViewChangeSummaryContentBuilder) CalcRequiredSize() membuffers.Offset {
+	if w == nil {
+		return 0
+	}
+	w.Write(nil)
+	return w._builder.GetSize()
+}
+
+func (w *ViewChangeSummaryContentBuilder) Build() *ViewChangeSummaryContent {
+	buf := make([]byte, w.CalcRequiredSize())
+	if w.Write(buf) != nil {
+		return nil
+	}
+	return ViewChangeSummaryContentReader(buf)
+}
+
+func ViewChangeSummaryContentBuilderFromRaw(raw []byte) *ViewChangeSummaryContentBuilder {
+	return &ViewChangeSummaryContentBuilder{_overrideWithRawBuffer: raw}
+}
+
 /////////////////////////////////////////////////////////////////////////////
 // message NewViewMessageContent
 
@@ -1175,44 +1526,44 @@ func NewViewMessageContentBuilderFromRaw(raw []byte) *NewViewMessageContentBuild
 }
 
 /////////////////////////////////////////////////////////////////////////////
-// message SenderSignature
+// message MessagesRequestContent
 
 // reader
 
-type SenderSignature struct {
-	// MemberId primitives.MemberId
-	// Signature primitives.Signature
+type MessagesRequestContent struct {
+	// SignedHeader RetransmissionHeader
+	// Sender SenderSignature
 
 	// internal
 	// implements membuffers.Message
 	_message membuffers.InternalMessage
 }
 
-func (x *SenderSignature) String() string {
+func (x *MessagesRequestContent) String() string {
 	if x == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("{MemberId:%s,Signature:%s,}", x.StringMemberId(), x.StringSignature())
+	return fmt.Sprintf("{SignedHeader:%s,Sender:%s,}", x.StringSignedHeader(), x.StringSender())
 }
 
-var _SenderSignature_Scheme = []membuffers.FieldType{membuffers.TypeBytes, membuffers.TypeBytes}
-var _SenderSignature_Unions = [][]membuffers.FieldType{}
+var _MessagesRequestContent_Scheme = []membuffers.FieldType{membuffers.TypeMessage, membuffers.TypeMessage}
+var _MessagesRequestContent_Unions = [][]membuffers.FieldType{}
 
-func SenderSignatureReader(buf []byte) *SenderSignature {
-	x := &SenderSignature{}
-	x._message.Init(buf, membuffers.Offset(len(buf)), _SenderSignature_Scheme, _SenderSignature_Unions)
+func MessagesRequestContentReader(buf []byte) *MessagesRequestContent {
+	x := &MessagesRequestContent{}
+	x._message.Init(buf, membuffers.Offset(len(buf)), _MessagesRequestContent_Scheme, _MessagesRequestContent_Unions)
 	return x
 }
 
-func (x *SenderSignature) IsValid() bool {
+func (x *MessagesRequestContent) IsValid() bool {
 	return x._message.IsValid()
 }
 
-func (x *SenderSignature) Raw() []byte {
+func (x *MessagesRequestContent) Raw() []byte {
 	return x._message.RawBuffer()
 }
 
-func (x *SenderSignature) Equal(y *SenderSignature) bool {
+func (x *MessagesRequestContent) Equal(y *MessagesRequestContent) bool {
 	if x == nil && y == nil {
 		return true
 	}
@@ -1222,51 +1573,1695 @@ func (x *SenderSignature) Equal(y *SenderSignature) bool {
 	return bytes.Equal(x.Raw(), y.Raw())
 }
 
-func (x *SenderSignature) MemberId() primitives.MemberId {
-	return primitives.MemberId(x._message.GetBytes(0))
+func (x *MessagesRequestContent) SignedHeader() *RetransmissionHeader {
+	b, s := x._message.GetMessage(0)
+	return RetransmissionHeaderReader(b[:s])
 }
 
-func (x *SenderSignature) RawMemberId() []byte {
+func (x *MessagesRequestContent) RawSignedHeader() []byte {
 	return x._message.RawBufferForField(0, 0)
 }
 
-func (x *SenderSignature) RawMemberIdWithHeader() []byte {
+func (x *MessagesRequestContent) RawSignedHeaderWithHeader() []byte {
 	return x._message.RawBufferWithHeaderForField(0, 0)
 }
 
-func (x *SenderSignature) MutateMemberId(v primitives.MemberId) error {
-	return x._message.SetBytes(0, []byte(v))
-}
-
-func (x *SenderSignature) StringMemberId() string {
-	return fmt.Sprintf("%s", x.MemberId())
+func (x *MessagesRequestContent) StringSignedHeader() string {
+	return x.SignedHeader().String()
 }
 
-func (x *SenderSignature) Signature() primitives.Signature {
-	return primitives.Signature(x._message.GetBytes(1))
+func (x *MessagesRequestContent) Sender() *SenderSignature {
+	b, s := x._message.GetMessage(1)
+	return SenderSignatureReader(b[:s])
 }
 
-func (x *SenderSignature) RawSignature() []byte {
+func (x *MessagesRequestContent) RawSender() []byte {
 	return x._message.RawBufferForField(1, 0)
 }
 
-func (x *SenderSignature) RawSignatureWithHeader() []byte {
+func (x *MessagesRequestContent) RawSenderWithHeader() []byte {
 	return x._message.RawBufferWithHeaderForField(1, 0)
 }
 
-func (x *SenderSignature) MutateSignature(v primitives.Signature) error {
+func (x *MessagesRequestContent) StringSender() string {
+	return x.Sender().String()
+}
+
+// builder
+
+type MessagesRequestContentBuilder struct {
+	SignedHeader *RetransmissionHeaderBuilder
+	Sender       *SenderSignatureBuilder
+
+	// internal
+	// implements membuffers.Builder
+	_builder               membuffers.InternalBuilder
+	_overrideWithRawBuffer []byte
+}
+
+func (w *MessagesRequestContentBuilder) Write(buf []byte) (err error) {
+	if w == nil {
+		return
+	}
+	w._builder.NotifyBuildStart()
+	defer w._builder.NotifyBuildEnd()
+	defer func() {
+		if r := recover(); r != nil {
+			err = &membuffers.ErrBufferOverrun{}
+		}
+	}()
+	if w._overrideWithRawBuffer != nil {
+		return w._builder.WriteOverrideWithRawBuffer(buf, w._overrideWithRawBuffer)
+	}
+	w._builder.Reset()
+	err = w._builder.WriteMessage(buf, w.SignedHeader)
+	if err != nil {
+		return
+	}
+	err = w._builder.WriteMessage(buf, w.Sender)
+	if err != nil {
+		return
+	}
+	return nil
+}
+
+func (w *MessagesRequestContentBuilder) HexDump(prefix string, offsetFromStart membuffers.Offset) (err error) {
+	if w == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = &membuffers.ErrBufferOverrun{}
+		}
+	}()
+	w._builder.Reset()
+	err = w._builder.HexDumpMessage(prefix, offsetFromStart, "MessagesRequestContent.SignedHeader", w.SignedHeader)
+	if err != nil {
+		return
+	}
+	err = w._builder.HexDumpMessage(prefix, offsetFromStart, "MessagesRequestContent.Sender", w.Sender)
+	if err != nil {
+		return
+	}
+	return nil
+}
+
+func (w *MessagesRequestContentBuilder) GetSize() membuffers.Offset {
+	if w == nil {
+		return 0
+	}
+	return w._builder.GetSize()
+}
+
+func (w *MessagesRequestContentBuilder) CalcRequiredSize() membuffers.Offset {
+	if w == nil {
+		return 0
+	}
+	w.Write(nil)
+	return w._builder.GetSize()
+}
+
+func (w *MessagesRequestContentBuilder) Build() *MessagesRequestContent {
+	buf := make([]byte, w.CalcRequiredSize())
+	if w.Write(buf) != nil {
+		return nil
+	}
+	return MessagesRequestContentReader(buf)
+}
+
+func MessagesRequestContentBuilderFromRaw(raw []byte) *MessagesRequestContentBuilder {
+	return &MessagesRequestContentBuilder{_overrideWithRawBuffer: raw}
+}
+
+/////////////////////////////////////////////////////////////////////////////
+// message MessagesResponseContent
+
+// reader
+
+type MessagesResponseContent struct {
+	// SignedHeader RetransmissionHeader
+	// Sender SenderSignature
+	// PrepareMessages []PrepareContent
+	// CommitMessages []CommitContent
+
+	// internal
+	// implements membuffers.Message
+	_message membuffers.InternalMessage
+}
+
+func (x *MessagesResponseContent) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("{SignedHeader:%s,Sender:%s,PrepareMessages:%s,CommitMessages:%s,}", x.StringSignedHeader(), x.StringSender(), x.StringPrepareMessages(), x.StringCommitMessages())
+}
+
+var _MessagesResponseContent_Scheme = []membuffers.FieldType{membuffers.TypeMessage, membuffers.TypeMessage, membuffers.TypeMessageArray, membuffers.TypeMessageArray}
+var _MessagesResponseContent_Unions = [][]membuffers.FieldType{}
+
+func MessagesResponseContentReader(buf []byte) *MessagesResponseContent {
+	x := &MessagesResponseContent{}
+	x._message.Init(buf, membuffers.Offset(len(buf)), _MessagesResponseContent_Scheme, _MessagesResponseContent_Unions)
+	return x
+}
+
+func (x *MessagesResponseContent) IsValid() bool {
+	return x._message.IsValid()
+}
+
+func (x *MessagesResponseContent) Raw() []byte {
+	return x._message.RawBuffer()
+}
+
+func (x *MessagesResponseContent) Equal(y *MessagesResponseContent) bool {
+	if x == nil && y == nil {
+		return true
+	}
+	if x == nil || y == nil {
+		return false
+	}
+	return bytes.Equal(x.Raw(), y.Raw())
+}
+
+func (x *MessagesResponseContent) SignedHeader() *RetransmissionHeader {
+	b, s := x._message.GetMessage(0)
+	return RetransmissionHeaderReader(b[:s])
+}
+
+func (x *MessagesResponseContent) RawSignedHeader() []byte {
+	return x._message.RawBufferForField(0, 0)
+}
+
+func (x *MessagesResponseContent) RawSignedHeaderWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(0, 0)
+}
+
+func (x *MessagesResponseContent) StringSignedHeader() string {
+	return x.SignedHeader().String()
+}
+
+func (x *MessagesResponseContent) Sender() *SenderSignature {
+	b, s := x._message.GetMessage(1)
+	return SenderSignatureReader(b[:s])
+}
+
+func (x *MessagesResponseContent) RawSender() []byte {
+	return x._message.RawBufferForField(1, 0)
+}
+
+func (x *MessagesResponseContent) RawSenderWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(1, 0)
+}
+
+func (x *MessagesResponseContent) StringSender() string {
+	return x.Sender().String()
+}
+
+func (x *MessagesResponseContent) PrepareMessagesIterator() *MessagesResponseContentPrepareMessagesIterator {
+	return &MessagesResponseContentPrepareMessagesIterator{iterator: x._message.GetMessageArrayIterator(2)}
+}
+
+type MessagesResponseContentPrepareMessagesIterator struct {
+	iterator *membuffers.Iterator
+}
+
+func (i *MessagesResponseContentPrepareMessagesIterator) HasNext() bool {
+	return i.iterator.HasNext()
+}
+
+func (i *MessagesResponseContentPrepareMessagesIterator) NextPrepareMessages() *PrepareContent {
+	b, s := i.iterator.NextMessage()
+	return PrepareContentReader(b[:s])
+}
+
+func (x *MessagesResponseContent) RawPrepareMessagesArray() []byte {
+	return x._message.RawBufferForField(2, 0)
+}
+
+func (x *MessagesResponseContent) RawPrepareMessagesArrayWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(2, 0)
+}
+
+func (x *MessagesResponseContent) StringPrepareMessages() (res string) {
+	res = "["
+	for i := x.PrepareMessagesIterator(); i.HasNext(); {
+		res += i.NextPrepareMessages().String() + ","
+	}
+	res += "]"
+	return
+}
+
+func (x *MessagesResponseContent) CommitMessagesIterator() *MessagesResponseContentCommitMessagesIterator {
+	return &MessagesResponseContentCommitMessagesIterator{iterator: x._message.GetMessageArrayIterator(3)}
+}
+
+type MessagesResponseContentCommitMessagesIterator struct {
+	iterator *membuffers.Iterator
+}
+
+func (i *MessagesResponseContentCommitMessagesIterator) HasNext() bool {
+	return i.iterator.HasNext()
+}
+
+func (i *MessagesResponseContentCommitMessagesIterator) NextCommitMessages() *CommitContent {
+	b, s := i.iterator.NextMessage()
+	return CommitContentReader(b[:s])
+}
+
+func (x *MessagesResponseContent) RawCommitMessagesArray() []byte {
+	return x._message.RawBufferForField(3, 0)
+}
+
+func (x *MessagesResponseContent) RawCommitMessagesArrayWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(3, 0)
+}
+
+func (x *MessagesResponseContent) StringCommitMessages() (res string) {
+	res = "["
+	for i := x.CommitMessagesIterator(); i.HasNext(); {
+		res += i.NextCommitMessages().String() + ","
+	}
+	res += "]"
+	return
+}
+
+// builder
+
+type MessagesResponseContentBuilder struct {
+	SignedHeader    *RetransmissionHeaderBuilder
+	Sender          *SenderSignatureBuilder
+	PrepareMessages []*PrepareContentBuilder
+	CommitMessages  []*CommitContentBuilder
+
+	// internal
+	// implements membuffers.Builder
+	_builder               membuffers.InternalBuilder
+	_overrideWithRawBuffer []byte
+}
+
+func (w *MessagesResponseContentBuilder) arrayOfPrepareMessages() []membuffers.MessageWriter {
+	res := make([]membuffers.MessageWriter, len(w.PrepareMessages))
+	for i, v := range w.PrepareMessages {
+		res[i] = v
+	}
+	return res
+}
+
+func (w *MessagesResponseContentBuilder) arrayOfCommitMessages() []membuffers.MessageWriter {
+	res := make([]membuffers.MessageWriter, len(w.CommitMessages))
+	for i, v := range w.CommitMessages {
+		res[i] = v
+	}
+	return res
+}
+
+func (w *MessagesResponseContentBuilder) Write(buf []byte) (err error) {
+	if w == nil {
+		return
+	}
+	w._builder.NotifyBuildStart()
+	defer w._builder.NotifyBuildEnd()
+	defer func() {
+		if r := recover(); r != nil {
+			err = &membuffers.ErrBufferOverrun{}
+		}
+	}()
+	if w._overrideWithRawBuffer != nil {
+		return w._builder.WriteOverrideWithRawBuffer(buf, w._overrideWithRawBuffer)
+	}
+	w._builder.Reset()
+	err = w._builder.WriteMessage(buf, w.SignedHeader)
+	if err != nil {
+		return
+	}
+	err = w._builder.WriteMessage(buf, w.Sender)
+	if err != nil {
+		return
+	}
+	err = w._builder.WriteMessageArray(buf, w.arrayOfPrepareMessages())
+	if err != nil {
+		return
+	}
+	err = w._builder.WriteMessageArray(buf, w.arrayOfCommitMessages())
+	if err != nil {
+		return
+	}
+	return nil
+}
+
+func (w *MessagesResponseContentBuilder) HexDump(prefix string, offsetFromStart membuffers.Offset) (err error) {
+	if w == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = &membuffers.ErrBufferOverrun{}
+		}
+	}()
+	w._builder.Reset()
+	err = w._builder.HexDumpMessage(prefix, offsetFromStart, "MessagesResponseContent.SignedHeader", w.SignedHeader)
+	if err != nil {
+		return
+	}
+	err = w._builder.HexDumpMessage(prefix, offsetFromStart, "MessagesResponseContent.Sender", w.Sender)
+	if err != nil {
+		return
+	}
+	err = w._builder.HexDumpMessageArray(prefix, offsetFromStart, "MessagesResponseContent.PrepareMessages", w.arrayOfPrepareMessages())
+	if err != nil {
+		return
+	}
+	err = w._builder.HexDumpMessageArray(prefix, offsetFromStart, "MessagesResponseContent.CommitMessages", w.arrayOfCommitMessages())
+	if err != nil {
+		return
+	}
+	return nil
+}
+
+func (w *MessagesResponseContentBuilder) GetSize() membuffers.Offset {
+	if w == nil {
+		return 0
+	}
+	return w._builder.GetSize()
+}
+
+func (w *MessagesResponseContentBuilder) CalcRequiredSize() membuffers.Offset {
+	if w == nil {
+		return 0
+	}
+	w.Write(nil)
+	return w._builder.GetSize()
+}
+
+func (w *MessagesResponseContentBuilder) Build() *MessagesResponseContent {
+	buf := make([]byte, w.CalcRequiredSize())
+	if w.Write(buf) != nil {
+		return nil
+	}
+	return MessagesResponseContentReader(buf)
+}
+
+func MessagesResponseContentBuilderFromRaw(raw []byte) *MessagesResponseContentBuilder {
+	return &MessagesResponseContentBuilder{_overrideWithRawBuffer: raw}
+}
+
+/////////////////////////////////////////////////////////////////////////////
+// message CommitCertificateContent
+
+// reader
+
+type CommitCertificateContent struct {
+	// SignedHeader RetransmissionHeader
+	// Sender SenderSignature
+	// BlockProof []byte
+
+	// internal
+	// implements membuffers.Message
+	_message membuffers.InternalMessage
+}
+
+func (x *CommitCertificateContent) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("{SignedHeader:%s,Sender:%s,BlockProof:%s,}", x.StringSignedHeader(), x.StringSender(), x.StringBlockProof())
+}
+
+var _CommitCertificateContent_Scheme = []membuffers.FieldType{membuffers.TypeMessage, membuffers.TypeMessage, membuffers.TypeBytes}
+var _CommitCertificateContent_Unions = [][]membuffers.FieldType{}
+
+func CommitCertificateContentReader(buf []byte) *CommitCertificateContent {
+	x := &CommitCertificateContent{}
+	x._message.Init(buf, membuffers.Offset(len(buf)), _CommitCertificateContent_Scheme, _CommitCertificateContent_Unions)
+	return x
+}
+
+func (x *CommitCertificateContent) IsValid() bool {
+	return x._message.IsValid()
+}
+
+func (x *CommitCertificateContent) Raw() []byte {
+	return x._message.RawBuffer()
+}
+
+func (x *CommitCertificateContent) Equal(y *CommitCertificateContent) bool {
+	if x == nil && y == nil {
+		return true
+	}
+	if x == nil || y == nil {
+		return false
+	}
+	return bytes.Equal(x.Raw(), y.Raw())
+}
+
+func (x *CommitCertificateContent) SignedHeader() *RetransmissionHeader {
+	b, s := x._message.GetMessage(0)
+	return RetransmissionHeaderReader(b[:s])
+}
+
+func (x *CommitCertificateContent) RawSignedHeader() []byte {
+	return x._message.RawBufferForField(0, 0)
+}
+
+func (x *CommitCertificateContent) RawSignedHeaderWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(0, 0)
+}
+
+func (x *CommitCertificateContent) StringSignedHeader() string {
+	return x.SignedHeader().String()
+}
+
+func (x *CommitCertificateContent) Sender() *SenderSignature {
+	b, s := x._message.GetMessage(1)
+	return SenderSignatureReader(b[:s])
+}
+
+func (x *CommitCertificateContent) RawSender() []byte {
+	return x._message.RawBufferForField(1, 0)
+}
+
+func (x *CommitCertificateContent) RawSenderWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(1, 0)
+}
+
+func (x *CommitCertificateContent) StringSender() string {
+	return x.Sender().String()
+}
+
+func (x *CommitCertificateContent) BlockProof() []byte {
+	return x._message.GetBytes(2)
+}
+
+func (x *CommitCertificateContent) RawBlockProof() []byte {
+	return x._message.RawBufferForField(2, 0)
+}
+
+func (x *CommitCertificateContent) RawBlockProofWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(2, 0)
+}
+
+func (x *CommitCertificateContent) MutateBlockProof(v []byte) error {
+	return x._message.SetBytes(2, v)
+}
+
+func (x *CommitCertificateContent) StringBlockProof() string {
+	return fmt.Sprintf("%x", x.BlockProof())
+}
+
+// builder
+
+type CommitCertificateContentBuilder struct {
+	SignedHeader *RetransmissionHeaderBuilder
+	Sender       *SenderSignatureBuilder
+	BlockProof   []byte
+
+	// internal
+	// implements membuffers.Builder
+	_builder               membuffers.InternalBuilder
+	_overrideWithRawBuffer []byte
+}
+
+func (w *CommitCertificateContentBuilder) Write(buf []byte) (err error) {
+	if w == nil {
+		return
+	}
+	w._builder.NotifyBuildStart()
+	defer w._builder.NotifyBuildEnd()
+	defer func() {
+		if r := recover(); r != nil {
+			err = &membuffers.ErrBufferOverrun{}
+		}
+	}()
+	if w._overrideWithRawBuffer != nil {
+		return w._builder.WriteOverrideWithRawBuffer(buf, w._overrideWithRawBuffer)
+	}
+	w._builder.Reset()
+	err = w._builder.WriteMessage(buf, w.SignedHeader)
+	if err != nil {
+		return
+	}
+	err = w._builder.WriteMessage(buf, w.Sender)
+	if err != nil {
+		return
+	}
+	w._builder.WriteBytes(buf, w.BlockProof)
+	return nil
+}
+
+func (w *CommitCertificateContentBuilder) HexDump(prefix string, offsetFromStart membuffers.Offset) (err error) {
+	if w == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = &membuffers.ErrBufferOverrun{}
+		}
+	}()
+	w._builder.Reset()
+	err = w._builder.HexDumpMessage(prefix, offsetFromStart, "CommitCertificateContent.SignedHeader", w.SignedHeader)
+	if err != nil {
+		return
+	}
+	err = w._builder.HexDumpMessage(prefix, offsetFromStart, "CommitCertificateContent.Sender", w.Sender)
+	if err != nil {
+		return
+	}
+	w._builder.HexDumpBytes(prefix, offsetFromStart, "CommitCertificateContent.BlockProof", w.BlockProof)
+	return nil
+}
+
+func (w *CommitCertificateContentBuilder) GetSize() membuffers.Offset {
+	if w == nil {
+		return 0
+	}
+	return w._builder.GetSize()
+}
+
+func (w *CommitCertificateContentBuilder) CalcRequiredSize() membuffers.Offset {
+	if w == nil {
+		return 0
+	}
+	w.Write(nil)
+	return w._builder.GetSize()
+}
+
+func (w *CommitCertificateContentBuilder) Build() *CommitCertificateContent {
+	buf := make([]byte, w.CalcRequiredSize())
+	if w.Write(buf) != nil {
+		return nil
+	}
+	return CommitCertificateContentReader(buf)
+}
+
+func CommitCertificateContentBuilderFromRaw(raw []byte) *CommitCertificateContentBuilder {
+	return &CommitCertificateContentBuilder{_overrideWithRawBuffer: raw}
+}
+
+/////////////////////////////////////////////////////////////////////////////
+// message BlockRequestContent
+
+// reader
+
+type BlockRequestContent struct {
+	// SignedHeader BlockRef
+	// Sender SenderSignature
+
+	// internal
+	// implements membuffers.Message
+	_message membuffers.InternalMessage
+}
+
+func (x *BlockRequestContent) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("{SignedHeader:%s,Sender:%s,}", x.StringSignedHeader(), x.StringSender())
+}
+
+var _BlockRequestContent_Scheme = []membuffers.FieldType{membuffers.TypeMessage, membuffers.TypeMessage}
+var _BlockRequestContent_Unions = [][]membuffers.FieldType{}
+
+func BlockRequestContentReader(buf []byte) *BlockRequestContent {
+	x := &BlockRequestContent{}
+	x._message.Init(buf, membuffers.Offset(len(buf)), _BlockRequestContent_Scheme, _BlockRequestContent_Unions)
+	return x
+}
+
+func (x *BlockRequestContent) IsValid() bool {
+	return x._message.IsValid()
+}
+
+func (x *BlockRequestContent) Raw() []byte {
+	return x._message.RawBuffer()
+}
+
+func (x *BlockRequestContent) Equal(y *BlockRequestContent) bool {
+	if x == nil && y == nil {
+		return true
+	}
+	if x == nil || y == nil {
+		return false
+	}
+	return bytes.Equal(x.Raw(), y.Raw())
+}
+
+func (x *BlockRequestContent) SignedHeader() *BlockRef {
+	b, s := x._message.GetMessage(0)
+	return BlockRefReader(b[:s])
+}
+
+func (x *BlockRequestContent) RawSignedHeader() []byte {
+	return x._message.RawBufferForField(0, 0)
+}
+
+func (x *BlockRequestContent) RawSignedHeaderWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(0, 0)
+}
+
+func (x *BlockRequestContent) StringSignedHeader() string {
+	return x.SignedHeader().String()
+}
+
+func (x *BlockRequestContent) Sender() *SenderSignature {
+	b, s := x._message.GetMessage(1)
+	return SenderSignatureReader(b[:s])
+}
+
+func (x *BlockRequestContent) RawSender() []byte {
+	return x._message.RawBufferForField(1, 0)
+}
+
+func (x *BlockRequestContent) RawSenderWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(1, 0)
+}
+
+func (x *BlockRequestContent) StringSender() string {
+	return x.Sender().String()
+}
+
+// builder
+
+type BlockRequestContentBuilder struct {
+	SignedHeader *BlockRefBuilder
+	Sender       *SenderSignatureBuilder
+
+	// internal
+	// implements membuffers.Builder
+	_builder               membuffers.InternalBuilder
+	_overrideWithRawBuffer []byte
+}
+
+func (w *BlockRequestContentBuilder) Write(buf []byte) (err error) {
+	if w == nil {
+		return
+	}
+	w._builder.NotifyBuildStart()
+	defer w._builder.NotifyBuildEnd()
+	defer func() {
+		if r := recover(); r != nil {
+			err = &membuffers.ErrBufferOverrun{}
+		}
+	}()
+	if w._overrideWithRawBuffer != nil {
+		return w._builder.WriteOverrideWithRawBuffer(buf, w._overrideWithRawBuffer)
+	}
+	w._builder.Reset()
+	err = w._builder.WriteMessage(buf, w.SignedHeader)
+	if err != nil {
+		return
+	}
+	err = w._builder.WriteMessage(buf, w.Sender)
+	if err != nil {
+		return
+	}
+	return nil
+}
+
+func (w *BlockRequestContentBuilder) HexDump(prefix string, offsetFromStart membuffers.Offset) (err error) {
+	if w == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = &membuffers.ErrBufferOverrun{}
+		}
+	}()
+	w._builder.Reset()
+	err = w._builder.HexDumpMessage(prefix, offsetFromStart, "BlockRequestContent.SignedHeader", w.SignedHeader)
+	if err != nil {
+		return
+	}
+	err = w._builder.HexDumpMessage(prefix, offsetFromStart, "BlockRequestContent.Sender", w.Sender)
+	if err != nil {
+		return
+	}
+	return nil
+}
+
+func (w *BlockRequestContentBuilder) GetSize() membuffers.Offset {
+	if w == nil {
+		return 0
+	}
+	return w._builder.GetSize()
+}
+
+func (w *BlockRequestContentBuilder) CalcRequiredSize() membuffers.Offset {
+	if w == nil {
+		return 0
+	}
+	w.Write(nil)
+	return w._builder.GetSize()
+}
+
+func (w *BlockRequestContentBuilder) Build() *BlockRequestContent {
+	buf := make([]byte, w.CalcRequiredSize())
+	if w.Write(buf) != nil {
+		return nil
+	}
+	return BlockRequestContentReader(buf)
+}
+
+func BlockRequestContentBuilderFromRaw(raw []byte) *BlockRequestContentBuilder {
+	return &BlockRequestContentBuilder{_overrideWithRawBuffer: raw}
+}
+
+/////////////////////////////////////////////////////////////////////////////
+// message BlockResponseContent
+
+// reader
+
+type BlockResponseContent struct {
+	// SignedHeader BlockRef
+	// Sender SenderSignature
+
+	// internal
+	// implements membuffers.Message
+	_message membuffers.InternalMessage
+}
+
+func (x *BlockResponseContent) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("{SignedHeader:%s,Sender:%s,}", x.StringSignedHeader(), x.StringSender())
+}
+
+var _BlockResponseContent_Scheme = []membuffers.FieldType{membuffers.TypeMessage, membuffers.TypeMessage}
+var _BlockResponseContent_Unions = [][]membuffers.FieldType{}
+
+func BlockResponseContentReader(buf []byte) *BlockResponseContent {
+	x := &BlockResponseContent{}
+	x._message.Init(buf, membuffers.Offset(len(buf)), _BlockResponseContent_Scheme, _BlockResponseContent_Unions)
+	return x
+}
+
+func (x *BlockResponseContent) IsValid() bool {
+	return x._message.IsValid()
+}
+
+func (x *BlockResponseContent) Raw() []byte {
+	return x._message.RawBuffer()
+}
+
+func (x *BlockResponseContent) Equal(y *BlockResponseContent) bool {
+	if x == nil && y == nil {
+		return true
+	}
+	if x == nil || y == nil {
+		return false
+	}
+	return bytes.Equal(x.Raw(), y.Raw())
+}
+
+func (x *BlockResponseContent) SignedHeader() *BlockRef {
+	b, s := x._message.GetMessage(0)
+	return BlockRefReader(b[:s])
+}
+
+func (x *BlockResponseContent) RawSignedHeader() []byte {
+	return x._message.RawBufferForField(0, 0)
+}
+
+func (x *BlockResponseContent) RawSignedHeaderWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(0, 0)
+}
+
+func (x *BlockResponseContent) StringSignedHeader() string {
+	return x.SignedHeader().String()
+}
+
+func (x *BlockResponseContent) Sender() *SenderSignature {
+	b, s := x._message.GetMessage(1)
+	return SenderSignatureReader(b[:s])
+}
+
+func (x *BlockResponseContent) RawSender() []byte {
+	return x._message.RawBufferForField(1, 0)
+}
+
+func (x *BlockResponseContent) RawSenderWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(1, 0)
+}
+
+func (x *BlockResponseContent) StringSender() string {
+	return x.Sender().String()
+}
+
+// builder
+
+type BlockResponseContentBuilder struct {
+	SignedHeader *BlockRefBuilder
+	Sender       *SenderSignatureBuilder
+
+	// internal
+	// implements membuffers.Builder
+	_builder               membuffers.InternalBuilder
+	_overrideWithRawBuffer []byte
+}
+
+func (w *BlockResponseContentBuilder) Write(buf []byte) (err error) {
+	if w == nil {
+		return
+	}
+	w._builder.NotifyBuildStart()
+	defer w._builder.NotifyBuildEnd()
+	defer func() {
+		if r := recover(); r != nil {
+			err = &membuffers.ErrBufferOverrun{}
+		}
+	}()
+	if w._overrideWithRawBuffer != nil {
+		return w._builder.WriteOverrideWithRawBuffer(buf, w._overrideWithRawBuffer)
+	}
+	w._builder.Reset()
+	err = w._builder.WriteMessage(buf, w.SignedHeader)
+	if err != nil {
+		return
+	}
+	err = w._builder.WriteMessage(buf, w.Sender)
+	if err != nil {
+		return
+	}
+	return nil
+}
+
+func (w *BlockResponseContentBuilder) HexDump(prefix string, offsetFromStart membuffers.Offset) (err error) {
+	if w == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = &membuffers.ErrBufferOverrun{}
+		}
+	}()
+	w._builder.Reset()
+	err = w._builder.HexDumpMessage(prefix, offsetFromStart, "BlockResponseContent.SignedHeader", w.SignedHeader)
+	if err != nil {
+		return
+	}
+	err = w._builder.HexDumpMessage(prefix, offsetFromStart, "BlockResponseContent.Sender", w.Sender)
+	if err != nil {
+		return
+	}
+	return nil
+}
+
+func (w *BlockResponseContentBuilder) GetSize() membuffers.Offset {
+	if w == nil {
+		return 0
+	}
+	return w._builder.GetSize()
+}
+
+func (w *BlockResponseContentBuilder) CalcRequiredSize() membuffers.Offset {
+	if w == nil {
+		return 0
+	}
+	w.Write(nil)
+	return w._builder.GetSize()
+}
+
+func (w *BlockResponseContentBuilder) Build() *BlockResponseContent {
+	buf := make([]byte, w.CalcRequiredSize())
+	if w.Write(buf) != nil {
+		return nil
+	}
+	return BlockResponseContentReader(buf)
+}
+
+func BlockResponseContentBuilderFromRaw(raw []byte) *BlockResponseContentBuilder {
+	return &BlockResponseContentBuilder{_overrideWithRawBuffer: raw}
+}
+
+/////////////////////////////////////////////////////////////////////////////
+// message BlockChunkContent
+
+// reader
+
+type BlockChunkContent struct {
+	// SignedHeader BlockChunkHeader
+	// Sender SenderSignature
+	// Index uint32
+	// Chunk []byte
+	// MerkleProof [][]byte
+
+	// internal
+	// implements membuffers.Message
+	_message membuffers.InternalMessage
+}
+
+func (x *BlockChunkContent) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("{SignedHeader:%s,Sender:%s,Index:%s,Chunk:%s,MerkleProof:%s,}", x.StringSignedHeader(), x.StringSender(), x.StringIndex(), x.StringChunk(), x.StringMerkleProof())
+}
+
+var _BlockChunkContent_Scheme = []membuffers.FieldType{membuffers.TypeMessage, membuffers.TypeMessage, membuffers.TypeUint32, membuffers.TypeBytes, membuffers.TypeBytesArray}
+var _BlockChunkContent_Unions = [][]membuffers.FieldType{}
+
+func BlockChunkContentReader(buf []byte) *BlockChunkContent {
+	x := &BlockChunkContent{}
+	x._message.Init(buf, membuffers.Offset(len(buf)), _BlockChunkContent_Scheme, _BlockChunkContent_Unions)
+	return x
+}
+
+func (x *BlockChunkContent) IsValid() bool {
+	return x._message.IsValid()
+}
+
+func (x *BlockChunkContent) Raw() []byte {
+	return x._message.RawBuffer()
+}
+
+func (x *BlockChunkContent) Equal(y *BlockChunkContent) bool {
+	if x == nil && y == nil {
+		return true
+	}
+	if x == nil || y == nil {
+		return false
+	}
+	return bytes.Equal(x.Raw(), y.Raw())
+}
+
+func (x *BlockChunkContent) SignedHeader() *BlockChunkHeader {
+	b, s := x._message.GetMessage(0)
+	return BlockChunkHeaderReader(b[:s])
+}
+
+func (x *BlockChunkContent) RawSignedHeader() []byte {
+	return x._message.RawBufferForField(0, 0)
+}
+
+func (x *BlockChunkContent) RawSignedHeaderWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(0, 0)
+}
+
+func (x *BlockChunkContent) StringSignedHeader() string {
+	return x.SignedHeader().String()
+}
+
+func (x *BlockChunkContent) Sender() *SenderSignature {
+	b, s := x._message.GetMessage(1)
+	return SenderSignatureReader(b[:s])
+}
+
+func (x *BlockChunkContent) RawSender() []byte {
+	return x._message.RawBufferForField(1, 0)
+}
+
+func (x *BlockChunkContent) RawSenderWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(1, 0)
+}
+
+func (x *BlockChunkContent) StringSender() string {
+	return x.Sender().String()
+}
+
+func (x *BlockChunkContent) Index() uint32 {
+	return x._message.GetUint32(2)
+}
+
+func (x *BlockChunkContent) RawIndex() []byte {
+	return x._message.RawBufferForField(2, 0)
+}
+
+func (x *BlockChunkContent) MutateIndex(v uint32) error {
+	return x._message.SetUint32(2, v)
+}
+
+func (x *BlockChunkContent) StringIndex() string {
+	return fmt.Sprintf("%x", x.Index())
+}
+
+func (x *BlockChunkContent) Chunk() []byte {
+	return x._message.GetBytes(3)
+}
+
+func (x *BlockChunkContent) RawChunk() []byte {
+	return x._message.RawBufferForField(3, 0)
+}
+
+func (x *BlockChunkContent) RawChunkWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(3, 0)
+}
+
+func (x *BlockChunkContent) MutateChunk(v []byte) error {
+	return x._message.SetBytes(3, v)
+}
+
+func (x *BlockChunkContent) StringChunk() string {
+	return fmt.Sprintf("%x", x.Chunk())
+}
+
+func (x *BlockChunkContent) MerkleProofIterator() *BlockChunkContentMerkleProofIterator {
+	return &BlockChunkContentMerkleProofIterator{iterator: x._message.GetBytesArrayIterator(4)}
+}
+
+type BlockChunkContentMerkleProofIterator struct {
+	iterator *membuffers.Iterator
+}
+
+func (i *BlockChunkContentMerkleProofIterator) HasNext() bool {
+	return i.iterator.HasNext()
+}
+
+func (i *BlockChunkContentMerkleProofIterator) NextMerkleProof() []byte {
+	return i.iterator.NextBytes()
+}
+
+func (x *BlockChunkContent) RawMerkleProofArray() []byte {
+	return x._message.RawBufferForField(4, 0)
+}
+
+func (x *BlockChunkContent) RawMerkleProofArrayWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(4, 0)
+}
+
+func (x *BlockChunkContent) StringMerkleProof() (res string) {
+	res = "["
+	for i := x.MerkleProofIterator(); i.HasNext(); {
+		res += fmt.Sprintf("%x", i.NextMerkleProof()) + ","
+	}
+	res += "]"
+	return
+}
+
+// builder
+
+type BlockChunkContentBuilder struct {
+	SignedHeader *BlockChunkHeaderBuilder
+	Sender       *SenderSignatureBuilder
+	Index        uint32
+	Chunk        []byte
+	MerkleProof  [][]byte
+
+	// internal
+	// implements membuffers.Builder
+	_builder               membuffers.InternalBuilder
+	_overrideWithRawBuffer []byte
+}
+
+func (w *BlockChunkContentBuilder) Write(buf []byte) (err error) {
+	if w == nil {
+		return
+	}
+	w._builder.NotifyBuildStart()
+	defer w._builder.NotifyBuildEnd()
+	defer func() {
+		if r := recover(); r != nil {
+			err = &membuffers.ErrBufferOverrun{}
+		}
+	}()
+	if w._overrideWithRawBuffer != nil {
+		return w._builder.WriteOverrideWithRawBuffer(buf, w._overrideWithRawBuffer)
+	}
+	w._builder.Reset()
+	err = w._builder.WriteMessage(buf, w.SignedHeader)
+	if err != nil {
+		return
+	}
+	err = w._builder.WriteMessage(buf, w.Sender)
+	if err != nil {
+		return
+	}
+	w._builder.WriteUint32(buf, w.Index)
+	w._builder.WriteBytes(buf, w.Chunk)
+	w._builder.WriteBytesArray(buf, w.MerkleProof)
+	return nil
+}
+
+func (w *BlockChunkContentBuilder) HexDump(prefix string, offsetFromStart membuffers.Offset) (err error) {
+	if w == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = &membuffers.ErrBufferOverrun{}
+		}
+	}()
+	w._builder.Reset()
+	err = w._builder.HexDumpMessage(prefix, offsetFromStart, "BlockChunkContent.SignedHeader", w.SignedHeader)
+	if err != nil {
+		return
+	}
+	err = w._builder.HexDumpMessage(prefix, offsetFromStart, "BlockChunkContent.Sender", w.Sender)
+	if err != nil {
+		return
+	}
+	w._builder.HexDumpUint32(prefix, offsetFromStart, "BlockChunkContent.Index", w.Index)
+	w._builder.HexDumpBytes(prefix, offsetFromStart, "BlockChunkContent.Chunk", w.Chunk)
+	w._builder.HexDumpBytesArray(prefix, offsetFromStart, "BlockChunkContent.MerkleProof", w.MerkleProof)
+	return nil
+}
+
+func (w *BlockChunkContentBuilder) GetSize() membuffers.Offset {
+	if w == nil {
+		return 0
+	}
+	return w._builder.GetSize()
+}
+
+func (w *BlockChunkContentBuilder) CalcRequiredSize() membuffers.Offset {
+	if w == nil {
+		return 0
+	}
+	w.Write(nil)
+	return w._builder.GetSize()
+}
+
+func (w *BlockChunkContentBuilder) Build() *BlockChunkContent {
+	buf := make([]byte, w.CalcRequiredSize())
+	if w.Write(buf) != nil {
+		return nil
+	}
+	return BlockChunkContentReader(buf)
+}
+
+func BlockChunkContentBuilderFromRaw(raw []byte) *BlockChunkContentBuilder {
+	return &BlockChunkContentBuilder{_overrideWithRawBuffer: raw}
+}
+
+/////////////////////////////////////////////////////////////////////////////
+// message SenderSignature
+
+// reader
+
+type SenderSignature struct {
+	// MemberId primitives.MemberId
+	// Signature primitives.Signature
+
+	// internal
+	// implements membuffers.Message
+	_message membuffers.InternalMessage
+}
+
+func (x *SenderSignature) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("{MemberId:%s,Signature:%s,}", x.StringMemberId(), x.StringSignature())
+}
+
+var _SenderSignature_Scheme = []membuffers.FieldType{membuffers.TypeBytes, membuffers.TypeBytes}
+var _SenderSignature_Unions = [][]membuffers.FieldType{}
+
+func SenderSignatureReader(buf []byte) *SenderSignature {
+	x := &SenderSignature{}
+	x._message.Init(buf, membuffers.Offset(len(buf)), _SenderSignature_Scheme, _SenderSignature_Unions)
+	return x
+}
+
+func (x *SenderSignature) IsValid() bool {
+	return x._message.IsValid()
+}
+
+func (x *SenderSignature) Raw() []byte {
+	return x._message.RawBuffer()
+}
+
+func (x *SenderSignature) Equal(y *SenderSignature) bool {
+	if x == nil && y == nil {
+		return true
+	}
+	if x == nil || y == nil {
+		return false
+	}
+	return bytes.Equal(x.Raw(), y.Raw())
+}
+
+func (x *SenderSignature) MemberId() primitives.MemberId {
+	return primitives.MemberId(x._message.GetBytes(0))
+}
+
+func (x *SenderSignature) RawMemberId() []byte {
+	return x._message.RawBufferForField(0, 0)
+}
+
+func (x *SenderSignature) RawMemberIdWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(0, 0)
+}
+
+func (x *SenderSignature) MutateMemberId(v primitives.MemberId) error {
+	return x._message.SetBytes(0, []byte(v))
+}
+
+func (x *SenderSignature) StringMemberId() string {
+	return fmt.Sprintf("%s", x.MemberId())
+}
+
+func (x *SenderSignature) Signature() primitives.Signature {
+	return primitives.Signature(x._message.GetBytes(1))
+}
+
+func (x *SenderSignature) RawSignature() []byte {
+	return x._message.RawBufferForField(1, 0)
+}
+
+func (x *SenderSignature) RawSignatureWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(1, 0)
+}
+
+func (x *SenderSignature) MutateSignature(v primitives.Signature) error {
 	return x._message.SetBytes(1, []byte(v))
 }
 
-func (x *SenderSignature) StringSignature() string {
-	return fmt.Sprintf("%s", x.Signature())
+func (x *SenderSignature) StringSignature() string {
+	return fmt.Sprintf("%s", x.Signature())
+}
+
+// builder
+
+type SenderSignatureBuilder struct {
+	MemberId  primitives.MemberId
+	Signature primitives.Signature
+
+	// internal
+	// implements membuffers.Builder
+	_builder               membuffers.InternalBuilder
+	_overrideWithRawBuffer []byte
+}
+
+func (w *SenderSignatureBuilder) Write(buf []byte) (err error) {
+	if w == nil {
+		return
+	}
+	w._builder.NotifyBuildStart()
+	defer w._builder.NotifyBuildEnd()
+	defer func() {
+		if r := recover(); r != nil {
+			err = &membuffers.ErrBufferOverrun{}
+		}
+	}()
+	if w._overrideWithRawBuffer != nil {
+		return w._builder.WriteOverrideWithRawBuffer(buf, w._overrideWithRawBuffer)
+	}
+	w._builder.Reset()
+	w._builder.WriteBytes(buf, []byte(w.MemberId))
+	w._builder.WriteBytes(buf, []byte(w.Signature))
+	return nil
+}
+
+func (w *SenderSignatureBuilder) HexDump(prefix string, offsetFromStart membuffers.Offset) (err error) {
+	if w == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = &membuffers.ErrBufferOverrun{}
+		}
+	}()
+	w._builder.Reset()
+	w._builder.HexDumpBytes(prefix, offsetFromStart, "SenderSignature.MemberId", []byte(w.MemberId))
+	w._builder.HexDumpBytes(prefix, offsetFromStart, "SenderSignature.Signature", []byte(w.Signature))
+	return nil
+}
+
+func (w *SenderSignatureBuilder) GetSize() membuffers.Offset {
+	if w == nil {
+		return 0
+	}
+	return w._builder.GetSize()
+}
+
+func (w *SenderSignatureBuilder) CalcRequiredSize() membuffers.Offset {
+	if w == nil {
+		return 0
+	}
+	w.Write(nil)
+	return w._builder.GetSize()
+}
+
+func (w *SenderSignatureBuilder) Build() *SenderSignature {
+	buf := make([]byte, w.CalcRequiredSize())
+	if w.Write(buf) != nil {
+		return nil
+	}
+	return SenderSignatureReader(buf)
+}
+
+func SenderSignatureBuilderFromRaw(raw []byte) *SenderSignatureBuilder {
+	return &SenderSignatureBuilder{_overrideWithRawBuffer: raw}
+}
+
+/////////////////////////////////////////////////////////////////////////////
+// message BlockRef
+
+// reader
+
+type BlockRef struct {
+	// InstanceId primitives.InstanceId
+	// MessageType MessageType
+	// BlockHeight primitives.BlockHeight
+	// View primitives.View
+	// BlockHash primitives.BlockHash
+
+	// internal
+	// implements membuffers.Message
+	_message membuffers.InternalMessage
+}
+
+func (x *BlockRef) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("{InstanceId:%s,MessageType:%s,BlockHeight:%s,View:%s,BlockHash:%s,}", x.StringInstanceId(), x.StringMessageType(), x.StringBlockHeight(), x.StringView(), x.StringBlockHash())
+}
+
+var _BlockRef_Scheme = []membuffers.FieldType{membuffers.TypeUint64, membuffers.TypeUint16, membuffers.TypeUint64, membuffers.TypeUint64, membuffers.TypeBytes}
+var _BlockRef_Unions = [][]membuffers.FieldType{}
+
+func BlockRefReader(buf []byte) *BlockRef {
+	x := &BlockRef{}
+	x._message.Init(buf, membuffers.Offset(len(buf)), _BlockRef_Scheme, _BlockRef_Unions)
+	return x
+}
+
+func (x *BlockRef) IsValid() bool {
+	return x._message.IsValid()
+}
+
+func (x *BlockRef) Raw() []byte {
+	return x._message.RawBuffer()
+}
+
+func (x *BlockRef) Equal(y *BlockRef) bool {
+	if x == nil && y == nil {
+		return true
+	}
+	if x == nil || y == nil {
+		return false
+	}
+	return bytes.Equal(x.Raw(), y.Raw())
+}
+
+func (x *BlockRef) InstanceId() primitives.InstanceId {
+	return primitives.InstanceId(x._message.GetUint64(0))
+}
+
+func (x *BlockRef) RawInstanceId() []byte {
+	return x._message.RawBufferForField(0, 0)
+}
+
+func (x *BlockRef) MutateInstanceId(v primitives.InstanceId) error {
+	return x._message.SetUint64(0, uint64(v))
+}
+
+func (x *BlockRef) StringInstanceId() string {
+	return fmt.Sprintf("%s", x.InstanceId())
+}
+
+func (x *BlockRef) MessageType() MessageType {
+	return MessageType(x._message.GetUint16(1))
+}
+
+func (x *BlockRef) RawMessageType() []byte {
+	return x._message.RawBufferForField(1, 0)
+}
+
+func (x *BlockRef) MutateMessageType(v MessageType) error {
+	return x._message.SetUint16(1, uint16(v))
+}
+
+func (x *BlockRef) StringMessageType() string {
+	return x.MessageType().String()
+}
+
+func (x *BlockRef) BlockHeight() primitives.BlockHeight {
+	return primitives.BlockHeight(x._message.GetUint64(2))
+}
+
+func (x *BlockRef) RawBlockHeight() []byte {
+	return x._message.RawBufferForField(2, 0)
+}
+
+func (x *BlockRef) MutateBlockHeight(v primitives.BlockHeight) error {
+	return x._message.SetUint64(2, uint64(v))
+}
+
+func (x *BlockRef) StringBlockHeight() string {
+	return fmt.Sprintf("%s", x.BlockHeight())
+}
+
+func (x *BlockRef) View() primitives.View {
+	return primitives.View(x._message.GetUint64(3))
+}
+
+func (x *BlockRef) RawView() []byte {
+	return x._message.RawBufferForField(3, 0)
+}
+
+func (x *BlockRef) MutateView(v primitives.View) error {
+	return x._message.SetUint64(3, uint64(v))
+}
+
+func (x *BlockRef) StringView() string {
+	return fmt.Sprintf("%s", x.View())
+}
+
+func (x *BlockRef) BlockHash() primitives.BlockHash {
+	return primitives.BlockHash(x._message.GetBytes(4))
+}
+
+func (x *BlockRef) RawBlockHash() []byte {
+	return x._message.RawBufferForField(4, 0)
+}
+
+func (x *BlockRef) RawBlockHashWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(4, 0)
+}
+
+func (x *BlockRef) MutateBlockHash(v primitives.BlockHash) error {
+	return x._message.SetBytes(4, []byte(v))
+}
+
+func (x *BlockRef) StringBlockHash() string {
+	return fmt.Sprintf("%s", x.BlockHash())
+}
+
+// builder
+
+type BlockRefBuilder struct {
+	InstanceId  primitives.InstanceId
+	MessageType MessageType
+	BlockHeight primitives.BlockHeight
+	View        primitives.View
+	BlockHash   primitives.BlockHash
+
+	// internal
+	// implements membuffers.Builder
+	_builder               membuffers.InternalBuilder
+	_overrideWithRawBuffer []byte
+}
+
+func (w *BlockRefBuilder) Write(buf []byte) (err error) {
+	if w == nil {
+		return
+	}
+	w._builder.NotifyBuildStart()
+	defer w._builder.NotifyBuildEnd()
+	defer func() {
+		if r := recover(); r != nil {
+			err = &membuffers.ErrBufferOverrun{}
+		}
+	}()
+	if w._overrideWithRawBuffer != nil {
+		return w._builder.WriteOverrideWithRawBuffer(buf, w._overrideWithRawBuffer)
+	}
+	w._builder.Reset()
+	w._builder.WriteUint64(buf, uint64(w.InstanceId))
+	w._builder.WriteUint16(buf, uint16(w.MessageType))
+	w._builder.WriteUint64(buf, uint64(w.BlockHeight))
+	w._builder.WriteUint64(buf, uint64(w.View))
+	w._builder.WriteBytes(buf, []byte(w.BlockHash))
+	return nil
+}
+
+func (w *BlockRefBuilder) HexDump(prefix string, offsetFromStart membuffers.Offset) (err error) {
+	if w == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = &membuffers.ErrBufferOverrun{}
+		}
+	}()
+	w._builder.Reset()
+	w._builder.HexDumpUint64(prefix, offsetFromStart, "BlockRef.InstanceId", uint64(w.InstanceId))
+	w._builder.HexDumpUint16(prefix, offsetFromStart, "BlockRef.MessageType", uint16(w.MessageType))
+	w._builder.HexDumpUint64(prefix, offsetFromStart, "BlockRef.BlockHeight", uint64(w.BlockHeight))
+	w._builder.HexDumpUint64(prefix, offsetFromStart, "BlockRef.View", uint64(w.View))
+	w._builder.HexDumpBytes(prefix, offsetFromStart, "BlockRef.BlockHash", []byte(w.BlockHash))
+	return nil
+}
+
+func (w *BlockRefBuilder) GetSize() membuffers.Offset {
+	if w == nil {
+		return 0
+	}
+	return w._builder.GetSize()
+}
+
+func (w *BlockRefBuilder) CalcRequiredSize() membuffers.Offset {
+	if w == nil {
+		return 0
+	}
+	w.Write(nil)
+	return w._builder.GetSize()
+}
+
+func (w *BlockRefBuilder) Build() *BlockRef {
+	buf := make([]byte, w.CalcRequiredSize())
+	if w.Write(buf) != nil {
+		return nil
+	}
+	return BlockRefReader(buf)
+}
+
+func BlockRefBuilderFromRaw(raw []byte) *BlockRefBuilder {
+	return &BlockRefBuilder{_overrideWithRawBuffer: raw}
+}
+
+/////////////////////////////////////////////////////////////////////////////
+// message ViewChangeHeader
+
+// reader
+
+type ViewChangeHeader struct {
+	// InstanceId primitives.InstanceId
+	// MessageType MessageType
+	// BlockHeight primitives.BlockHeight
+	// View primitives.View
+	// PreparedProof PreparedProof
+
+	// internal
+	// implements membuffers.Message
+	_message membuffers.InternalMessage
+}
+
+func (x *ViewChangeHeader) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("{InstanceId:%s,MessageType:%s,BlockHeight:%s,View:%s,PreparedProof:%s,}", x.StringInstanceId(), x.StringMessageType(), x.StringBlockHeight(), x.StringView(), x.StringPreparedProof())
+}
+
+var _ViewChangeHeader_Scheme = []membuffers.FieldType{membuffers.TypeUint64, membuffers.TypeUint16, membuffers.TypeUint64, membuffers.TypeUint64, membuffers.TypeMessage}
+var _ViewChangeHeader_Unions = [][]membuffers.FieldType{}
+
+func ViewChangeHeaderReader(buf []byte) *ViewChangeHeader {
+	x := &ViewChangeHeader{}
+	x._message.Init(buf, membuffers.Offset(len(buf)), _ViewChangeHeader_Scheme, _ViewChangeHeader_Unions)
+	return x
+}
+
+func (x *ViewChangeHeader) IsValid() bool {
+	return x._message.IsValid()
+}
+
+func (x *ViewChangeHeader) Raw() []byte {
+	return x._message.RawBuffer()
+}
+
+func (x *ViewChangeHeader) Equal(y *ViewChangeHeader) bool {
+	if x == nil && y == nil {
+		return true
+	}
+	if x == nil || y == nil {
+		return false
+	}
+	return bytes.Equal(x.Raw(), y.Raw())
+}
+
+func (x *ViewChangeHeader) InstanceId() primitives.InstanceId {
+	return primitives.InstanceId(x._message.GetUint64(0))
+}
+
+func (x *ViewChangeHeader) RawInstanceId() []byte {
+	return x._message.RawBufferForField(0, 0)
+}
+
+func (x *ViewChangeHeader) MutateInstanceId(v primitives.InstanceId) error {
+	return x._message.SetUint64(0, uint64(v))
+}
+
+func (x *ViewChangeHeader) StringInstanceId() string {
+	return fmt.Sprintf("%s", x.InstanceId())
+}
+
+func (x *ViewChangeHeader) MessageType() MessageType {
+	return MessageType(x._message.GetUint16(1))
+}
+
+func (x *ViewChangeHeader) RawMessageType() []byte {
+	return x._message.RawBufferForField(1, 0)
+}
+
+func (x *ViewChangeHeader) MutateMessageType(v MessageType) error {
+	return x._message.SetUint16(1, uint16(v))
+}
+
+func (x *ViewChangeHeader) StringMessageType() string {
+	return x.MessageType().String()
+}
+
+func (x *ViewChangeHeader) BlockHeight() primitives.BlockHeight {
+	return primitives.BlockHeight(x._message.GetUint64(2))
+}
+
+func (x *ViewChangeHeader) RawBlockHeight() []byte {
+	return x._message.RawBufferForField(2, 0)
+}
+
+func (x *ViewChangeHeader) MutateBlockHeight(v primitives.BlockHeight) error {
+	return x._message.SetUint64(2, uint64(v))
+}
+
+func (x *ViewChangeHeader) StringBlockHeight() string {
+	return fmt.Sprintf("%s", x.BlockHeight())
+}
+
+func (x *ViewChangeHeader) View() primitives.View {
+	return primitives.View(x._message.GetUint64(3))
+}
+
+func (x *ViewChangeHeader) RawView() []byte {
+	return x._message.RawBufferForField(3, 0)
+}
+
+func (x *ViewChangeHeader) MutateView(v primitives.View) error {
+	return x._message.SetUint64(3, uint64(v))
+}
+
+func (x *ViewChangeHeader) StringView() string {
+	return fmt.Sprintf("%s", x.View())
+}
+
+func (x *ViewChangeHeader) PreparedProof() *PreparedProof {
+	b, s := x._message.GetMessage(4)
+	return PreparedProofReader(b[:s])
+}
+
+func (x *ViewChangeHeader) RawPreparedProof() []byte {
+	return x._message.RawBufferForField(4, 0)
+}
+
+func (x *ViewChangeHeader) RawPreparedProofWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(4, 0)
+}
+
+func (x *ViewChangeHeader) StringPreparedProof() string {
+	return x.PreparedProof().String()
 }
 
 // builder
 
-type SenderSignatureBuilder struct {
-	MemberId  primitives.MemberId
-	Signature primitives.Signature
+type ViewChangeHeaderBuilder struct {
+	InstanceId    primitives.InstanceId
+	MessageType   MessageType
+	BlockHeight   primitives.BlockHeight
+	View          primitives.View
+	PreparedProof *PreparedProofBuilder
 
 	// internal
 	// implements membuffers.Builder
@@ -1274,7 +3269,7 @@ type SenderSignatureBuilder struct {
 	_overrideWithRawBuffer []byte
 }
 
-func (w *SenderSignatureBuilder) Write(buf []byte) (err error) {
+func (w *ViewChangeHeaderBuilder) Write(buf []byte) (err error) {
 	if w == nil {
 		return
 	}
@@ -1289,12 +3284,18 @@ func (w *SenderSignatureBuilder) Write(buf []byte) (err error) {
 		return w._builder.WriteOverrideWithRawBuffer(buf, w._overrideWithRawBuffer)
 	}
 	w._builder.Reset()
-	w._builder.WriteBytes(buf, []byte(w.MemberId))
-	w._builder.WriteBytes(buf, []byte(w.Signature))
+	w._builder.WriteUint64(buf, uint64(w.InstanceId))
+	w._builder.WriteUint16(buf, uint16(w.MessageType))
+	w._builder.WriteUint64(buf, uint64(w.BlockHeight))
+	w._builder.WriteUint64(buf, uint64(w.View))
+	err = w._builder.WriteMessage(buf, w.PreparedProof)
+	if err != nil {
+		return
+	}
 	return nil
 }
 
-func (w *SenderSignatureBuilder) HexDump(prefix string, offsetFromStart membuffers.Offset) (err error) {
+func (w *ViewChangeHeaderBuilder) HexDump(prefix string, offsetFromStart membuffers.Offset) (err error) {
 	if w == nil {
 		return
 	}
@@ -1304,19 +3305,25 @@ func (w *SenderSignatureBuilder) HexDump(prefix string, offsetFromStart membuffe
 		}
 	}()
 	w._builder.Reset()
-	w._builder.HexDumpBytes(prefix, offsetFromStart, "SenderSignature.MemberId", []byte(w.MemberId))
-	w._builder.HexDumpBytes(prefix, offsetFromStart, "SenderSignature.Signature", []byte(w.Signature))
+	w._builder.HexDumpUint64(prefix, offsetFromStart, "ViewChangeHeader.InstanceId", uint64(w.InstanceId))
+	w._builder.HexDumpUint16(prefix, offsetFromStart, "ViewChangeHeader.MessageType", uint16(w.MessageType))
+	w._builder.HexDumpUint64(prefix, offsetFromStart, "ViewChangeHeader.BlockHeight", uint64(w.BlockHeight))
+	w._builder.HexDumpUint64(prefix, offsetFromStart, "ViewChangeHeader.View", uint64(w.View))
+	err = w._builder.HexDumpMessage(prefix, offsetFromStart, "ViewChangeHeader.PreparedProof", w.PreparedProof)
+	if err != nil {
+		return
+	}
 	return nil
 }
 
-func (w *SenderSignatureBuilder) GetSize() membuffers.Offset {
+func (w *ViewChangeHeaderBuilder) GetSize() membuffers.Offset {
 	if w == nil {
 		return 0
 	}
 	return w._builder.GetSize()
 }
 
-func (w *SenderSignatureBuilder) CalcRequiredSize() membuffers.Offset {
+func (w *ViewChangeHeaderBuilder) CalcRequiredSize() membuffers.Offset {
 	if w == nil {
 		return 0
 	}
@@ -1324,60 +3331,59 @@ func (w *SenderSignatureBuilder) CalcRequiredSize() membuffers.Offset {
 	return w._builder.GetSize()
 }
 
-func (w *SenderSignatureBuilder) Build() *SenderSignature {
+func (w *ViewChangeHeaderBuilder) Build() *ViewChangeHeader {
 	buf := make([]byte, w.CalcRequiredSize())
 	if w.Write(buf) != nil {
 		return nil
 	}
-	return SenderSignatureReader(buf)
+	return ViewChangeHeaderReader(buf)
 }
 
-func SenderSignatureBuilderFromRaw(raw []byte) *SenderSignatureBuilder {
-	return &SenderSignatureBuilder{_overrideWithRawBuffer: raw}
+func ViewChangeHeaderBuilderFromRaw(raw []byte) *ViewChangeHeaderBuilder {
+	return &ViewChangeHeaderBuilder{_overrideWithRawBuffer: raw}
 }
 
 /////////////////////////////////////////////////////////////////////////////
-// message BlockRef
+// message RetransmissionHeader
 
 // reader
 
-type BlockRef struct {
+type RetransmissionHeader struct {
 	// InstanceId primitives.InstanceId
 	// MessageType MessageType
 	// BlockHeight primitives.BlockHeight
 	// View primitives.View
-	// BlockHash primitives.BlockHash
 
 	// internal
 	// implements membuffers.Message
 	_message membuffers.InternalMessage
 }
 
-func (x *BlockRef) String() string {
+func (x *RetransmissionHeader) String() string {
 	if x == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("{InstanceId:%s,MessageType:%s,BlockHeight:%s,View:%s,BlockHash:%s,}", x.StringInstanceId(), x.StringMessageType(), x.StringBlockHeight(), x.StringView(), x.StringBlockHash())
+	return fmt.Sprintf("{InstanceId:%s,MessageType:%s,BlockHeight:%s,View:%s,}", x.StringInstanceId(), x.StringMessageType(), x.StringBlockHeight(), x.StringView())
 }
 
-var _BlockRef_Scheme = []membuffers.FieldType{membuffers.TypeUint64, membuffers.TypeUint16, membuffers.TypeUint64, membuffers.TypeUint64, membuffers.TypeBytes}
-var _BlockRef_Unions = [][]membuffers.FieldType{}
+var _RetransmissionHeader_Scheme = []membuffers.FieldType{membuffers.TypeUint64, membuffers.TypeUint16, membuffers.TypeUint64, membuffers.TypeUint64}
+var _RetransmissionHeader_Unions = [][]membuffers.FieldType{}
 
-func BlockRefReader(buf []byte) *BlockRef {
-	x := &BlockRef{}
-	x._message.Init(buf, membuffers.Offset(len(buf)), _BlockRef_Scheme, _BlockRef_Unions)
+func RetransmissionHeaderReader(buf []byte) *RetransmissionHeader {
+	x := &RetransmissionHeader{}
+	x._message.Init(buf, membuffers.Offset(len(buf)), _RetransmissionHeader_Scheme, _RetransmissionHeader_Unions)
 	return x
 }
 
-func (x *BlockRef) IsValid() bool {
+func (x *RetransmissionHeader) IsValid() bool {
 	return x._message.IsValid()
 }
 
-func (x *BlockRef) Raw() []byte {
+func (x *RetransmissionHeader) Raw() []byte {
 	return x._message.RawBuffer()
 }
 
-func (x *BlockRef) Equal(y *BlockRef) bool {
+func (x *RetransmissionHeader) Equal(y *RetransmissionHeader) bool {
 	if x == nil && y == nil {
 		return true
 	}
@@ -1387,98 +3393,77 @@ func (x *BlockRef) Equal(y *BlockRef) bool {
 	return bytes.Equal(x.Raw(), y.Raw())
 }
 
-func (x *BlockRef) InstanceId() primitives.InstanceId {
+func (x *RetransmissionHeader) InstanceId() primitives.InstanceId {
 	return primitives.InstanceId(x._message.GetUint64(0))
 }
 
-func (x *BlockRef) RawInstanceId() []byte {
+func (x *RetransmissionHeader) RawInstanceId() []byte {
 	return x._message.RawBufferForField(0, 0)
 }
 
-func (x *BlockRef) MutateInstanceId(v primitives.InstanceId) error {
+func (x *RetransmissionHeader) MutateInstanceId(v primitives.InstanceId) error {
 	return x._message.SetUint64(0, uint64(v))
 }
 
-func (x *BlockRef) StringInstanceId() string {
+func (x *RetransmissionHeader) StringInstanceId() string {
 	return fmt.Sprintf("%s", x.InstanceId())
 }
 
-func (x *BlockRef) MessageType() MessageType {
+func (x *RetransmissionHeader) MessageType() MessageType {
 	return MessageType(x._message.GetUint16(1))
 }
 
-func (x *BlockRef) RawMessageType() []byte {
+func (x *RetransmissionHeader) RawMessageType() []byte {
 	return x._message.RawBufferForField(1, 0)
 }
 
-func (x *BlockRef) MutateMessageType(v MessageType) error {
+func (x *RetransmissionHeader) MutateMessageType(v MessageType) error {
 	return x._message.SetUint16(1, uint16(v))
 }
 
-func (x *BlockRef) StringMessageType() string {
+func (x *RetransmissionHeader) StringMessageType() string {
 	return x.MessageType().String()
 }
 
-func (x *BlockRef) BlockHeight() primitives.BlockHeight {
+func (x *RetransmissionHeader) BlockHeight() primitives.BlockHeight {
 	return primitives.BlockHeight(x._message.GetUint64(2))
 }
 
-func (x *BlockRef) RawBlockHeight() []byte {
+func (x *RetransmissionHeader) RawBlockHeight() []byte {
 	return x._message.RawBufferForField(2, 0)
 }
 
-func (x *BlockRef) MutateBlockHeight(v primitives.BlockHeight) error {
+func (x *RetransmissionHeader) MutateBlockHeight(v primitives.BlockHeight) error {
 	return x._message.SetUint64(2, uint64(v))
 }
 
-func (x *BlockRef) StringBlockHeight() string {
+func (x *RetransmissionHeader) StringBlockHeight() string {
 	return fmt.Sprintf("%s", x.BlockHeight())
 }
 
-func (x *BlockRef) View() primitives.View {
+func (x *RetransmissionHeader) View() primitives.View {
 	return primitives.View(x._message.GetUint64(3))
 }
 
-func (x *BlockRef) RawView() []byte {
+func (x *RetransmissionHeader) RawView() []byte {
 	return x._message.RawBufferForField(3, 0)
 }
 
-func (x *BlockRef) MutateView(v primitives.View) error {
+func (x *RetransmissionHeader) MutateView(v primitives.View) error {
 	return x._message.SetUint64(3, uint64(v))
 }
 
-func (x *BlockRef) StringView() string {
+func (x *RetransmissionHeader) StringView() string {
 	return fmt.Sprintf("%s", x.View())
 }
 
-func (x *BlockRef) BlockHash() primitives.BlockHash {
-	return primitives.BlockHash(x._message.GetBytes(4))
-}
-
-func (x *BlockRef) RawBlockHash() []byte {
-	return x._message.RawBufferForField(4, 0)
-}
-
-func (x *BlockRef) RawBlockHashWithHeader() []byte {
-	return x._message.RawBufferWithHeaderForField(4, 0)
-}
-
-func (x *BlockRef) MutateBlockHash(v primitives.BlockHash) error {
-	return x._message.SetBytes(4, []byte(v))
-}
-
-func (x *BlockRef) StringBlockHash() string {
-	return fmt.Sprintf("%s", x.BlockHash())
-}
-
 // builder
 
-type BlockRefBuilder struct {
+type RetransmissionHeaderBuilder struct {
 	InstanceId  primitives.InstanceId
 	MessageType MessageType
 	BlockHeight primitives.BlockHeight
 	View        primitives.View
-	BlockHash   primitives.BlockHash
 
 	// internal
 	// implements membuffers.Builder
@@ -1486,7 +3471,7 @@ type BlockRefBuilder struct {
 	_overrideWithRawBuffer []byte
 }
 
-func (w *BlockRefBuilder) Write(buf []byte) (err error) {
+func (w *RetransmissionHeaderBuilder) Write(buf []byte) (err error) {
 	if w == nil {
 		return
 	}
@@ -1505,11 +3490,10 @@ func (w *BlockRefBuilder) Write(buf []byte) (err error) {
 	w._builder.WriteUint16(buf, uint16(w.MessageType))
 	w._builder.WriteUint64(buf, uint64(w.BlockHeight))
 	w._builder.WriteUint64(buf, uint64(w.View))
-	w._builder.WriteBytes(buf, []byte(w.BlockHash))
 	return nil
 }
 
-func (w *BlockRefBuilder) HexDump(prefix string, offsetFromStart membuffers.Offset) (err error) {
+func (w *RetransmissionHeaderBuilder) HexDump(prefix string, offsetFromStart membuffers.Offset) (err error) {
 	if w == nil {
 		return
 	}
@@ -1519,22 +3503,21 @@ func (w *BlockRefBuilder) HexDump(prefix string, offsetFromStart membuffers.Offs
 		}
 	}()
 	w._builder.Reset()
-	w._builder.HexDumpUint64(prefix, offsetFromStart, "BlockRef.InstanceId", uint64(w.InstanceId))
-	w._builder.HexDumpUint16(prefix, offsetFromStart, "BlockRef.MessageType", uint16(w.MessageType))
-	w._builder.HexDumpUint64(prefix, offsetFromStart, "BlockRef.BlockHeight", uint64(w.BlockHeight))
-	w._builder.HexDumpUint64(prefix, offsetFromStart, "BlockRef.View", uint64(w.View))
-	w._builder.HexDumpBytes(prefix, offsetFromStart, "BlockRef.BlockHash", []byte(w.BlockHash))
+	w._builder.HexDumpUint64(prefix, offsetFromStart, "RetransmissionHeader.InstanceId", uint64(w.InstanceId))
+	w._builder.HexDumpUint16(prefix, offsetFromStart, "RetransmissionHeader.MessageType", uint16(w.MessageType))
+	w._builder.HexDumpUint64(prefix, offsetFromStart, "RetransmissionHeader.BlockHeight", uint64(w.BlockHeight))
+	w._builder.HexDumpUint64(prefix, offsetFromStart, "RetransmissionHeader.View", uint64(w.View))
 	return nil
 }
 
-func (w *BlockRefBuilder) GetSize() membuffers.Offset {
+func (w *RetransmissionHeaderBuilder) GetSize() membuffers.Offset {
 	if w == nil {
 		return 0
 	}
 	return w._builder.GetSize()
 }
 
-func (w *BlockRefBuilder) CalcRequiredSize() membuffers.Offset {
+func (w *RetransmissionHeaderBuilder) CalcRequiredSize() membuffers.Offset {
 	if w == nil {
 		return 0
 	}
@@ -1542,60 +3525,64 @@ func (w *BlockRefBuilder) CalcRequiredSize() membuffers.Offset {
 	return w._builder.GetSize()
 }
 
-func (w *BlockRefBuilder) Build() *BlockRef {
+func (w *RetransmissionHeaderBuilder) Build() *RetransmissionHeader {
 	buf := make([]byte, w.CalcRequiredSize())
 	if w.Write(buf) != nil {
 		return nil
 	}
-	return BlockRefReader(buf)
+	return RetransmissionHeaderReader(buf)
 }
 
-func BlockRefBuilderFromRaw(raw []byte) *BlockRefBuilder {
-	return &BlockRefBuilder{_overrideWithRawBuffer: raw}
+func RetransmissionHeaderBuilderFromRaw(raw []byte) *RetransmissionHeaderBuilder {
+	return &RetransmissionHeaderBuilder{_overrideWithRawBuffer: raw}
 }
 
 /////////////////////////////////////////////////////////////////////////////
-// message ViewChangeHeader
+// message BlockChunkHeader
 
 // reader
 
-type ViewChangeHeader struct {
+type BlockChunkHeader struct {
 	// InstanceId primitives.InstanceId
 	// MessageType MessageType
 	// BlockHeight primitives.BlockHeight
 	// View primitives.View
-	// PreparedProof PreparedProof
+	// BlockHash primitives.BlockHash
+	// ChunksRoot []byte
+	// DataChunks uint32
+	// TotalChunks uint32
+	// BlockSize uint32
 
 	// internal
 	// implements membuffers.Message
 	_message membuffers.InternalMessage
 }
 
-func (x *ViewChangeHeader) String() string {
+func (x *BlockChunkHeader) String() string {
 	if x == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("{InstanceId:%s,MessageType:%s,BlockHeight:%s,View:%s,PreparedProof:%s,}", x.StringInstanceId(), x.StringMessageType(), x.StringBlockHeight(), x.StringView(), x.StringPreparedProof())
+	return fmt.Sprintf("{InstanceId:%s,MessageType:%s,BlockHeight:%s,View:%s,BlockHash:%s,ChunksRoot:%s,DataChunks:%s,TotalChunks:%s,BlockSize:%s,}", x.StringInstanceId(), x.StringMessageType(), x.StringBlockHeight(), x.StringView(), x.StringBlockHash(), x.StringChunksRoot(), x.StringDataChunks(), x.StringTotalChunks(), x.StringBlockSize())
 }
 
-var _ViewChangeHeader_Scheme = []membuffers.FieldType{membuffers.TypeUint64, membuffers.TypeUint16, membuffers.TypeUint64, membuffers.TypeUint64, membuffers.TypeMessage}
-var _ViewChangeHeader_Unions = [][]membuffers.FieldType{}
+var _BlockChunkHeader_Scheme = []membuffers.FieldType{membuffers.TypeUint64, membuffers.TypeUint16, membuffers.TypeUint64, membuffers.TypeUint64, membuffers.TypeBytes, membuffers.TypeBytes, membuffers.TypeUint32, membuffers.TypeUint32, membuffers.TypeUint32}
+var _BlockChunkHeader_Unions = [][]membuffers.FieldType{}
 
-func ViewChangeHeaderReader(buf []byte) *ViewChangeHeader {
-	x := &ViewChangeHeader{}
-	x._message.Init(buf, membuffers.Offset(len(buf)), _ViewChangeHeader_Scheme, _ViewChangeHeader_Unions)
+func BlockChunkHeaderReader(buf []byte) *BlockChunkHeader {
+	x := &BlockChunkHeader{}
+	x._message.Init(buf, membuffers.Offset(len(buf)), _BlockChunkHeader_Scheme, _BlockChunkHeader_Unions)
 	return x
 }
 
-func (x *ViewChangeHeader) IsValid() bool {
+func (x *BlockChunkHeader) IsValid() bool {
 	return x._message.IsValid()
 }
 
-func (x *ViewChangeHeader) Raw() []byte {
+func (x *BlockChunkHeader) Raw() []byte {
 	return x._message.RawBuffer()
 }
 
-func (x *ViewChangeHeader) Equal(y *ViewChangeHeader) bool {
+func (x *BlockChunkHeader) Equal(y *BlockChunkHeader) bool {
 	if x == nil && y == nil {
 		return true
 	}
@@ -1605,95 +3592,170 @@ func (x *ViewChangeHeader) Equal(y *ViewChangeHeader) bool {
 	return bytes.Equal(x.Raw(), y.Raw())
 }
 
-func (x *ViewChangeHeader) InstanceId() primitives.InstanceId {
+func (x *BlockChunkHeader) InstanceId() primitives.InstanceId {
 	return primitives.InstanceId(x._message.GetUint64(0))
 }
 
-func (x *ViewChangeHeader) RawInstanceId() []byte {
+func (x *BlockChunkHeader) RawInstanceId() []byte {
 	return x._message.RawBufferForField(0, 0)
 }
 
-func (x *ViewChangeHeader) MutateInstanceId(v primitives.InstanceId) error {
+func (x *BlockChunkHeader) MutateInstanceId(v primitives.InstanceId) error {
 	return x._message.SetUint64(0, uint64(v))
 }
 
-func (x *ViewChangeHeader) StringInstanceId() string {
+func (x *BlockChunkHeader) StringInstanceId() string {
 	return fmt.Sprintf("%s", x.InstanceId())
 }
 
-func (x *ViewChangeHeader) MessageType() MessageType {
+func (x *BlockChunkHeader) MessageType() MessageType {
 	return MessageType(x._message.GetUint16(1))
 }
 
-func (x *ViewChangeHeader) RawMessageType() []byte {
+func (x *BlockChunkHeader) RawMessageType() []byte {
 	return x._message.RawBufferForField(1, 0)
 }
 
-func (x *ViewChangeHeader) MutateMessageType(v MessageType) error {
+func (x *BlockChunkHeader) MutateMessageType(v MessageType) error {
 	return x._message.SetUint16(1, uint16(v))
 }
 
-func (x *ViewChangeHeader) StringMessageType() string {
+func (x *BlockChunkHeader) StringMessageType() string {
 	return x.MessageType().String()
 }
 
-func (x *ViewChangeHeader) BlockHeight() primitives.BlockHeight {
+func (x *BlockChunkHeader) BlockHeight() primitives.BlockHeight {
 	return primitives.BlockHeight(x._message.GetUint64(2))
 }
 
-func (x *ViewChangeHeader) RawBlockHeight() []byte {
+func (x *BlockChunkHeader) RawBlockHeight() []byte {
 	return x._message.RawBufferForField(2, 0)
 }
 
-func (x *ViewChangeHeader) MutateBlockHeight(v primitives.BlockHeight) error {
+func (x *BlockChunkHeader) MutateBlockHeight(v primitives.BlockHeight) error {
 	return x._message.SetUint64(2, uint64(v))
 }
 
-func (x *ViewChangeHeader) StringBlockHeight() string {
+func (x *BlockChunkHeader) StringBlockHeight() string {
 	return fmt.Sprintf("%s", x.BlockHeight())
 }
 
-func (x *ViewChangeHeader) View() primitives.View {
+func (x *BlockChunkHeader) View() primitives.View {
 	return primitives.View(x._message.GetUint64(3))
 }
 
-func (x *ViewChangeHeader) RawView() []byte {
+func (x *BlockChunkHeader) RawView() []byte {
 	return x._message.RawBufferForField(3, 0)
 }
 
-func (x *ViewChangeHeader) MutateView(v primitives.View) error {
+func (x *BlockChunkHeader) MutateView(v primitives.View) error {
 	return x._message.SetUint64(3, uint64(v))
 }
 
-func (x *ViewChangeHeader) StringView() string {
+func (x *BlockChunkHeader) StringView() string {
 	return fmt.Sprintf("%s", x.View())
 }
 
-func (x *ViewChangeHeader) PreparedProof() *PreparedProof {
-	b, s := x._message.GetMessage(4)
-	return PreparedProofReader(b[:s])
+func (x *BlockChunkHeader) BlockHash() primitives.BlockHash {
+	return primitives.BlockHash(x._message.GetBytes(4))
+}
+
+func (x *BlockChunkHeader) RawBlockHash() []byte {
+	return x._message.RawBufferForField(4, 0)
+}
+
+func (x *BlockChunkHeader) RawBlockHashWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(4, 0)
+}
+
+func (x *BlockChunkHeader) MutateBlockHash(v primitives.BlockHash) error {
+	return x._message.SetBytes(4, []byte(v))
+}
+
+func (x *BlockChunkHeader) StringBlockHash() string {
+	return fmt.Sprintf("%s", x.BlockHash())
+}
+
+func (x *BlockChunkHeader) ChunksRoot() []byte {
+	return x._message.GetBytes(5)
+}
+
+func (x *BlockChunkHeader) RawChunksRoot() []byte {
+	return x._message.RawBufferForField(5, 0)
+}
+
+func (x *BlockChunkHeader) RawChunksRootWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(5, 0)
+}
+
+func (x *BlockChunkHeader) MutateChunksRoot(v []byte) error {
+	return x._message.SetBytes(5, v)
+}
+
+func (x *BlockChunkHeader) StringChunksRoot() string {
+	return fmt.Sprintf("%x", x.ChunksRoot())
+}
+
+func (x *BlockChunkHeader) DataChunks() uint32 {
+	return x._message.GetUint32(6)
+}
+
+func (x *BlockChunkHeader) RawDataChunks() []byte {
+	return x._message.RawBufferForField(6, 0)
+}
+
+func (x *BlockChunkHeader) MutateDataChunks(v uint32) error {
+	return x._message.SetUint32(6, v)
+}
+
+func (x *BlockChunkHeader) StringDataChunks() string {
+	return fmt.Sprintf("%x", x.DataChunks())
+}
+
+func (x *BlockChunkHeader) TotalChunks() uint32 {
+	return x._message.GetUint32(7)
+}
+
+func (x *BlockChunkHeader) RawTotalChunks() []byte {
+	return x._message.RawBufferForField(7, 0)
+}
+
+func (x *BlockChunkHeader) MutateTotalChunks(v uint32) error {
+	return x._message.SetUint32(7, v)
+}
+
+func (x *BlockChunkHeader) StringTotalChunks() string {
+	return fmt.Sprintf("%x", x.TotalChunks())
+}
+
+func (x *BlockChunkHeader) BlockSize() uint32 {
+	return x._message.GetUint32(8)
 }
 
-func (x *ViewChangeHeader) RawPreparedProof() []byte {
-	return x._message.RawBufferForField(4, 0)
+func (x *BlockChunkHeader) RawBlockSize() []byte {
+	return x._message.RawBufferForField(8, 0)
 }
 
-func (x *ViewChangeHeader) RawPreparedProofWithHeader() []byte {
-	return x._message.RawBufferWithHeaderForField(4, 0)
+func (x *BlockChunkHeader) MutateBlockSize(v uint32) error {
+	return x._message.SetUint32(8, v)
 }
 
-func (x *ViewChangeHeader) StringPreparedProof() string {
-	return x.PreparedProof().String()
+func (x *BlockChunkHeader) StringBlockSize() string {
+	return fmt.Sprintf("%x", x.BlockSize())
 }
 
 // builder
 
-type ViewChangeHeaderBuilder struct {
-	InstanceId    primitives.InstanceId
-	MessageType   MessageType
-	BlockHeight   primitives.BlockHeight
-	View          primitives.View
-	PreparedProof *PreparedProofBuilder
+type BlockChunkHeaderBuilder struct {
+	InstanceId  primitives.InstanceId
+	MessageType MessageType
+	BlockHeight primitives.BlockHeight
+	View        primitives.View
+	BlockHash   primitives.BlockHash
+	ChunksRoot  []byte
+	DataChunks  uint32
+	TotalChunks uint32
+	BlockSize   uint32
 
 	// internal
 	// implements membuffers.Builder
@@ -1701,7 +3763,7 @@ type ViewChangeHeaderBuilder struct {
 	_overrideWithRawBuffer []byte
 }
 
-func (w *ViewChangeHeaderBuilder) Write(buf []byte) (err error) {
+func (w *BlockChunkHeaderBuilder) Write(buf []byte) (err error) {
 	if w == nil {
 		return
 	}
@@ -1720,14 +3782,15 @@ func (w *ViewChangeHeaderBuilder) Write(buf []byte) (err error) {
 	w._builder.WriteUint16(buf, uint16(w.MessageType))
 	w._builder.WriteUint64(buf, uint64(w.BlockHeight))
 	w._builder.WriteUint64(buf, uint64(w.View))
-	err = w._builder.WriteMessage(buf, w.PreparedProof)
-	if err != nil {
-		return
-	}
+	w._builder.WriteBytes(buf, []byte(w.BlockHash))
+	w._builder.WriteBytes(buf, w.ChunksRoot)
+	w._builder.WriteUint32(buf, w.DataChunks)
+	w._builder.WriteUint32(buf, w.TotalChunks)
+	w._builder.WriteUint32(buf, w.BlockSize)
 	return nil
 }
 
-func (w *ViewChangeHeaderBuilder) HexDump(prefix string, offsetFromStart membuffers.Offset) (err error) {
+func (w *BlockChunkHeaderBuilder) HexDump(prefix string, offsetFromStart membuffers.Offset) (err error) {
 	if w == nil {
 		return
 	}
@@ -1737,25 +3800,26 @@ func (w *ViewChangeHeaderBuilder) HexDump(prefix string, offsetFromStart membuff
 		}
 	}()
 	w._builder.Reset()
-	w._builder.HexDumpUint64(prefix, offsetFromStart, "ViewChangeHeader.InstanceId", uint64(w.InstanceId))
-	w._builder.HexDumpUint16(prefix, offsetFromStart, "ViewChangeHeader.MessageType", uint16(w.MessageType))
-	w._builder.HexDumpUint64(prefix, offsetFromStart, "ViewChangeHeader.BlockHeight", uint64(w.BlockHeight))
-	w._builder.HexDumpUint64(prefix, offsetFromStart, "ViewChangeHeader.View", uint64(w.View))
-	err = w._builder.HexDumpMessage(prefix, offsetFromStart, "ViewChangeHeader.PreparedProof", w.PreparedProof)
-	if err != nil {
-		return
-	}
+	w._builder.HexDumpUint64(prefix, offsetFromStart, "BlockChunkHeader.InstanceId", uint64(w.InstanceId))
+	w._builder.HexDumpUint16(prefix, offsetFromStart, "BlockChunkHeader.MessageType", uint16(w.MessageType))
+	w._builder.HexDumpUint64(prefix, offsetFromStart, "BlockChunkHeader.BlockHeight", uint64(w.BlockHeight))
+	w._builder.HexDumpUint64(prefix, offsetFromStart, "BlockChunkHeader.View", uint64(w.View))
+	w._builder.HexDumpBytes(prefix, offsetFromStart, "BlockChunkHeader.BlockHash", []byte(w.BlockHash))
+	w._builder.HexDumpBytes(prefix, offsetFromStart, "BlockChunkHeader.ChunksRoot", w.ChunksRoot)
+	w._builder.HexDumpUint32(prefix, offsetFromStart, "BlockChunkHeader.DataChunks", w.DataChunks)
+	w._builder.HexDumpUint32(prefix, offsetFromStart, "BlockChunkHeader.TotalChunks", w.TotalChunks)
+	w._builder.HexDumpUint32(prefix, offsetFromStart, "BlockChunkHeader.BlockSize", w.BlockSize)
 	return nil
 }
 
-func (w *ViewChangeHeaderBuilder) GetSize() membuffers.Offset {
+func (w *BlockChunkHeaderBuilder) GetSize() membuffers.Offset {
 	if w == nil {
 		return 0
 	}
 	return w._builder.GetSize()
 }
 
-func (w *ViewChangeHeaderBuilder) CalcRequiredSize() membuffers.Offset {
+func (w *BlockChunkHeaderBuilder) CalcRequiredSize() membuffers.Offset {
 	if w == nil {
 		return 0
 	}
@@ -1763,16 +3827,16 @@ func (w *ViewChangeHeaderBuilder) CalcRequiredSize() membuffers.Offset {
 	return w._builder.GetSize()
 }
 
-func (w *ViewChangeHeaderBuilder) Build() *ViewChangeHeader {
+func (w *BlockChunkHeaderBuilder) Build() *BlockChunkHeader {
 	buf := make([]byte, w.CalcRequiredSize())
 	if w.Write(buf) != nil {
 		return nil
 	}
-	return ViewChangeHeaderReader(buf)
+	return BlockChunkHeaderReader(buf)
 }
 
-func ViewChangeHeaderBuilderFromRaw(raw []byte) *ViewChangeHeaderBuilder {
-	return &ViewChangeHeaderBuilder{_overrideWithRawBuffer: raw}
+func BlockChunkHeaderBuilderFromRaw(raw []byte) *BlockChunkHeaderBuilder {
+	return &BlockChunkHeaderBuilder{_overrideWithRawBuffer: raw}
 }
 
 /////////////////////////////////////////////////////////////////////////////
@@ -2022,6 +4086,244 @@ func PreparedProofBuilderFromRaw(raw []byte) *PreparedProofBuilder {
 	return &PreparedProofBuilder{_overrideWithRawBuffer: raw}
 }
 
+/////////////////////////////////////////////////////////////////////////////
+// message ViewChangeSummaryHeader
+
+// reader
+
+type ViewChangeSummaryHeader struct {
+	// InstanceId primitives.InstanceId
+	// MessageType MessageType
+	// BlockHeight primitives.BlockHeight
+	// View primitives.View
+	// PreparedView primitives.View
+	// PreparedBlockHash primitives.BlockHash
+
+	// internal
+	// implements membuffers.Message
+	_message membuffers.InternalMessage
+}
+
+func (x *ViewChangeSummaryHeader) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("{InstanceId:%s,MessageType:%s,BlockHeight:%s,View:%s,PreparedView:%s,PreparedBlockHash:%s,}", x.StringInstanceId(), x.StringMessageType(), x.StringBlockHeight(), x.StringView(), x.StringPreparedView(), x.StringPreparedBlockHash())
+}
+
+var _ViewChangeSummaryHeader_Scheme = []membuffers.FieldType{membuffers.TypeUint64, membuffers.TypeUint16, membuffers.TypeUint64, membuffers.TypeUint64, membuffers.TypeUint64, membuffers.TypeBytes}
+var _ViewChangeSummaryHeader_Unions = [][]membuffers.FieldType{}
+
+func ViewChangeSummaryHeaderReader(buf []byte) *ViewChangeSummaryHeader {
+	x := &ViewChangeSummaryHeader{}
+	x._message.Init(buf, membuffers.Offset(len(buf)), _ViewChangeSummaryHeader_Scheme, _ViewChangeSummaryHeader_Unions)
+	return x
+}
+
+func (x *ViewChangeSummaryHeader) IsValid() bool {
+	return x._message.IsValid()
+}
+
+func (x *ViewChangeSummaryHeader) Raw() []byte {
+	return x._message.RawBuffer()
+}
+
+func (x *ViewChangeSummaryHeader) Equal(y *ViewChangeSummaryHeader) bool {
+	if x == nil && y == nil {
+		return true
+	}
+	if x == nil || y == nil {
+		return false
+	}
+	return bytes.Equal(x.Raw(), y.Raw())
+}
+
+func (x *ViewChangeSummaryHeader) InstanceId() primitives.InstanceId {
+	return primitives.InstanceId(x._message.GetUint64(0))
+}
+
+func (x *ViewChangeSummaryHeader) RawInstanceId() []byte {
+	return x._message.RawBufferForField(0, 0)
+}
+
+func (x *ViewChangeSummaryHeader) MutateInstanceId(v primitives.InstanceId) error {
+	return x._message.SetUint64(0, uint64(v))
+}
+
+func (x *ViewChangeSummaryHeader) StringInstanceId() string {
+	return fmt.Sprintf("%s", x.InstanceId())
+}
+
+func (x *ViewChangeSummaryHeader) MessageType() MessageType {
+	return MessageType(x._message.GetUint16(1))
+}
+
+func (x *ViewChangeSummaryHeader) RawMessageType() []byte {
+	return x._message.RawBufferForField(1, 0)
+}
+
+func (x *ViewChangeSummaryHeader) MutateMessageType(v MessageType) error {
+	return x._message.SetUint16(1, uint16(v))
+}
+
+func (x *ViewChangeSummaryHeader) StringMessageType() string {
+	return x.MessageType().String()
+}
+
+func (x *ViewChangeSummaryHeader) BlockHeight() primitives.BlockHeight {
+	return primitives.BlockHeight(x._message.GetUint64(2))
+}
+
+func (x *ViewChangeSummaryHeader) RawBlockHeight() []byte {
+	return x._message.RawBufferForField(2, 0)
+}
+
+func (x *ViewChangeSummaryHeader) MutateBlockHeight(v primitives.BlockHeight) error {
+	return x._message.SetUint64(2, uint64(v))
+}
+
+func (x *ViewChangeSummaryHeader) StringBlockHeight() string {
+	return fmt.Sprintf("%s", x.BlockHeight())
+}
+
+func (x *ViewChangeSummaryHeader) View() primitives.View {
+	return primitives.View(x._message.GetUint64(3))
+}
+
+func (x *ViewChangeSummaryHeader) RawView() []byte {
+	return x._message.RawBufferForField(3, 0)
+}
+
+func (x *ViewChangeSummaryHeader) MutateView(v primitives.View) error {
+	return x._message.SetUint64(3, uint64(v))
+}
+
+func (x *ViewChangeSummaryHeader) StringView() string {
+	return fmt.Sprintf("%s", x.View())
+}
+
+func (x *ViewChangeSummaryHeader) PreparedView() primitives.View {
+	return primitives.View(x._message.GetUint64(4))
+}
+
+func (x *ViewChangeSummaryHeader) RawPreparedView() []byte {
+	return x._message.RawBufferForField(4, 0)
+}
+
+func (x *ViewChangeSummaryHeader) MutatePreparedView(v primitives.View) error {
+	return x._message.SetUint64(4, uint64(v))
+}
+
+func (x *ViewChangeSummaryHeader) StringPreparedView() string {
+	return fmt.Sprintf("%s", x.PreparedView())
+}
+
+func (x *ViewChangeSummaryHeader) PreparedBlockHash() primitives.BlockHash {
+	return primitives.BlockHash(x._message.GetBytes(5))
+}
+
+func (x *ViewChangeSummaryHeader) RawPreparedBlockHash() []byte {
+	return x._message.RawBufferForField(5, 0)
+}
+
+func (x *ViewChangeSummaryHeader) RawPreparedBlockHashWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(5, 0)
+}
+
+func (x *ViewChangeSummaryHeader) MutatePreparedBlockHash(v primitives.BlockHash) error {
+	return x._message.SetBytes(5, []byte(v))
+}
+
+func (x *ViewChangeSummaryHeader) StringPreparedBlockHash() string {
+	return fmt.Sprintf("%s", x.PreparedBlockHash())
+}
+
+// builder
+
+type ViewChangeSummaryHeaderBuilder struct {
+	InstanceId        primitives.InstanceId
+	MessageType       MessageType
+	BlockHeight       primitives.BlockHeight
+	View              primitives.View
+	PreparedView      primitives.View
+	PreparedBlockHash primitives.BlockHash
+
+	// internal
+	// implements membuffers.Builder
+	_builder               membuffers.InternalBuilder
+	_overrideWithRawBuffer []byte
+}
+
+func (w *ViewChangeSummaryHeaderBuilder) Write(buf []byte) (err error) {
+	if w == nil {
+		return
+	}
+	w._builder.NotifyBuildStart()
+	defer w._builder.NotifyBuildEnd()
+	defer func() {
+		if r := recover(); r != nil {
+			err = &membuffers.ErrBufferOverrun{}
+		}
+	}()
+	if w._overrideWithRawBuffer != nil {
+		return w._builder.WriteOverrideWithRawBuffer(buf, w._overrideWithRawBuffer)
+	}
+	w._builder.Reset()
+	w._builder.WriteUint64(buf, uint64(w.InstanceId))
+	w._builder.WriteUint16(buf, uint16(w.MessageType))
+	w._builder.WriteUint64(buf, uint64(w.BlockHeight))
+	w._builder.WriteUint64(buf, uint64(w.View))
+	w._builder.WriteUint64(buf, uint64(w.PreparedView))
+	w._builder.WriteBytes(buf, []byte(w.PreparedBlockHash))
+	return nil
+}
+
+func (w *ViewChangeSummaryHeaderBuilder) HexDump(prefix string, offsetFromStart membuffers.Offset) (err error) {
+	if w == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = &membuffers.ErrBufferOverrun{}
+		}
+	}()
+	w._builder.Reset()
+	w._builder.HexDumpUint64(prefix, offsetFromStart, "ViewChangeSummaryHeader.InstanceId", uint64(w.InstanceId))
+	w._builder.HexDumpUint16(prefix, offsetFromStart, "ViewChangeSummaryHeader.MessageType", uint16(w.MessageType))
+	w._builder.HexDumpUint64(prefix, offsetFromStart, "ViewChangeSummaryHeader.BlockHeight", uint64(w.BlockHeight))
+	w._builder.HexDumpUint64(prefix, offsetFromStart, "ViewChangeSummaryHeader.View", uint64(w.View))
+	w._builder.HexDumpUint64(prefix, offsetFromStart, "ViewChangeSummaryHeader.PreparedView", uint64(w.PreparedView))
+	w._builder.HexDumpBytes(prefix, offsetFromStart, "ViewChangeSummaryHeader.PreparedBlockHash", []byte(w.PreparedBlockHash))
+	return nil
+}
+
+func (w *ViewChangeSummaryHeaderBuilder) GetSize() membuffers.Offset {
+	if w == nil {
+		return 0
+	}
+	return w._builder.GetSize()
+}
+
+func (w *ViewChangeSummaryHeaderBuilder) CalcRequiredSize() membuffers.Offset {
+	if w == nil {
+		return 0
+	}
+	w.Write(nil)
+	return w._builder.GetSize()
+}
+
+func (w *ViewChangeSummaryHeaderBuilder) Build() *ViewChangeSummaryHeader {
+	buf := make([]byte, w.CalcRequiredSize())
+	if w.Write(buf) != nil {
+		return nil
+	}
+	return ViewChangeSummaryHeaderReader(buf)
+}
+
+func ViewChangeSummaryHeaderBuilderFromRaw(raw []byte) *ViewChangeSummaryHeaderBuilder {
+	return &ViewChangeSummaryHeaderBuilder{_overrideWithRawBuffer: raw}
+}
+
 /////////////////////////////////////////////////////////////////////////////
 // message NewViewHeader
 
@@ -2033,6 +4335,8 @@ type NewViewHeader struct {
 	// BlockHeight primitives.BlockHeight
 	// View primitives.View
 	// ViewChangeConfirmations []ViewChangeMessageContent
+	// CompactConfirmations []ViewChangeSummaryContent
+	// HighestPreparedProof PreparedProof
 
 	// internal
 	// implements membuffers.Message
@@ -2043,10 +4347,10 @@ func (x *NewViewHeader) String() string {
 	if x == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("{InstanceId:%s,MessageType:%s,BlockHeight:%s,View:%s,ViewChangeConfirmations:%s,}", x.StringInstanceId(), x.StringMessageType(), x.StringBlockHeight(), x.StringView(), x.StringViewChangeConfirmations())
+	return fmt.Sprintf("{InstanceId:%s,MessageType:%s,BlockHeight:%s,View:%s,ViewChangeConfirmations:%s,CompactConfirmations:%s,HighestPreparedProof:%s,}", x.StringInstanceId(), x.StringMessageType(), x.StringBlockHeight(), x.StringView(), x.StringViewChangeConfirmations(), x.StringCompactConfirmations(), x.StringHighestPreparedProof())
 }
 
-var _NewViewHeader_Scheme = []membuffers.FieldType{membuffers.TypeUint64, membuffers.TypeUint16, membuffers.TypeUint64, membuffers.TypeUint64, membuffers.TypeMessageArray}
+var _NewViewHeader_Scheme = []membuffers.FieldType{membuffers.TypeUint64, membuffers.TypeUint16, membuffers.TypeUint64, membuffers.TypeUint64, membuffers.TypeMessageArray, membuffers.TypeMessageArray, membuffers.TypeMessage}
 var _NewViewHeader_Unions = [][]membuffers.FieldType{}
 
 func NewViewHeaderReader(buf []byte) *NewViewHeader {
@@ -2171,6 +4475,57 @@ func (x *NewViewHeader) StringViewChangeConfirmations() (res string) {
 	return
 }
 
+func (x *NewViewHeader) CompactConfirmationsIterator() *NewViewHeaderCompactConfirmationsIterator {
+	return &NewViewHeaderCompactConfirmationsIterator{iterator: x._message.GetMessageArrayIterator(5)}
+}
+
+type NewViewHeaderCompactConfirmationsIterator struct {
+	iterator *membuffers.Iterator
+}
+
+func (i *NewViewHeaderCompactConfirmationsIterator) HasNext() bool {
+	return i.iterator.HasNext()
+}
+
+func (i *NewViewHeaderCompactConfirmationsIterator) NextCompactConfirmations() *ViewChangeSummaryContent {
+	b, s := i.iterator.NextMessage()
+	return ViewChangeSummaryContentReader(b[:s])
+}
+
+func (x *NewViewHeader) RawCompactConfirmationsArray() []byte {
+	return x._message.RawBufferForField(5, 0)
+}
+
+func (x *NewViewHeader) RawCompactConfirmationsArrayWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(5, 0)
+}
+
+func (x *NewViewHeader) StringCompactConfirmations() (res string) {
+	res = "["
+	for i := x.CompactConfirmationsIterator(); i.HasNext(); {
+		res += i.NextCompactConfirmations().String() + ","
+	}
+	res += "]"
+	return
+}
+
+func (x *NewViewHeader) HighestPreparedProof() *PreparedProof {
+	b, s := x._message.GetMessage(6)
+	return PreparedProofReader(b[:s])
+}
+
+func (x *NewViewHeader) RawHighestPreparedProof() []byte {
+	return x._message.RawBufferForField(6, 0)
+}
+
+func (x *NewViewHeader) RawHighestPreparedProofWithHeader() []byte {
+	return x._message.RawBufferWithHeaderForField(6, 0)
+}
+
+func (x *NewViewHeader) StringHighestPreparedProof() string {
+	return x.HighestPreparedProof().String()
+}
+
 // builder
 
 type NewViewHeaderBuilder struct {
@@ -2179,6 +4534,8 @@ type NewViewHeaderBuilder struct {
 	BlockHeight             primitives.BlockHeight
 	View                    primitives.View
 	ViewChangeConfirmations []*ViewChangeMessageContentBuilder
+	CompactConfirmations    []*ViewChangeSummaryContentBuilder
+	HighestPreparedProof    *PreparedProofBuilder
 
 	// internal
 	// implements membuffers.Builder
@@ -2194,6 +4551,14 @@ func (w *NewViewHeaderBuilder) arrayOfViewChangeConfirmations() []membuffers.Mes
 	return res
 }
 
+func (w *NewViewHeaderBuilder) arrayOfCompactConfirmations() []membuffers.MessageWriter {
+	res := make([]membuffers.MessageWriter, len(w.CompactConfirmations))
+	for i, v := range w.CompactConfirmations {
+		res[i] = v
+	}
+	return res
+}
+
 func (w *NewViewHeaderBuilder) Write(buf []byte) (err error) {
 	if w == nil {
 		return
@@ -2217,6 +4582,14 @@ func (w *NewViewHeaderBuilder) Write(buf []byte) (err error) {
 	if err != nil {
 		return
 	}
+	err = w._builder.WriteMessageArray(buf, w.arrayOfCompactConfirmations())
+	if err != nil {
+		return
+	}
+	err = w._builder.WriteMessage(buf, w.HighestPreparedProof)
+	if err != nil {
+		return
+	}
 	return nil
 }
 
@@ -2238,6 +4611,14 @@ func (w *NewViewHeaderBuilder) HexDump(prefix string, offsetFromStart membuffers
 	if err != nil {
 		return
 	}
+	err = w._builder.HexDumpMessageArray(prefix, offsetFromStart, "NewViewHeader.CompactConfirmations", w.arrayOfCompactConfirmations())
+	if err != nil {
+		return
+	}
+	err = w._builder.HexDumpMessage(prefix, offsetFromStart, "NewViewHeader.HighestPreparedProof", w.HighestPreparedProof)
+	if err != nil {
+		return
+	}
 	return nil
 }
 
@@ -2491,12 +4872,19 @@ func BlockProofBuilderFromRaw(raw []byte) *BlockProofBuilder {
 type MessageType uint16
 
 const (
-	LEAN_HELIX_RESERVED    MessageType = 0
-	LEAN_HELIX_PREPREPARE  MessageType = 1
-	LEAN_HELIX_PREPARE     MessageType = 2
-	LEAN_HELIX_COMMIT      MessageType = 3
-	LEAN_HELIX_NEW_VIEW    MessageType = 4
-	LEAN_HELIX_VIEW_CHANGE MessageType = 5
+	LEAN_HELIX_RESERVED            MessageType = 0
+	LEAN_HELIX_PREPREPARE          MessageType = 1
+	LEAN_HELIX_PREPARE             MessageType = 2
+	LEAN_HELIX_COMMIT              MessageType = 3
+	LEAN_HELIX_NEW_VIEW            MessageType = 4
+	LEAN_HELIX_VIEW_CHANGE         MessageType = 5
+	LEAN_HELIX_MESSAGES_REQUEST    MessageType = 6
+	LEAN_HELIX_MESSAGES_RESPONSE   MessageType = 7
+	LEAN_HELIX_COMMIT_CERTIFICATE  MessageType = 8
+	LEAN_HELIX_BLOCK_REQUEST       MessageType = 9
+	LEAN_HELIX_BLOCK_RESPONSE      MessageType = 10
+	LEAN_HELIX_BLOCK_CHUNK         MessageType = 11
+	LEAN_HELIX_VIEW_CHANGE_SUMMARY MessageType = 12
 )
 
 func (n MessageType) String() string {
@@ -2513,6 +4901,20 @@ func (n MessageType) String() string {
 		return "LEAN_HELIX_NEW_VIEW"
 	case LEAN_HELIX_VIEW_CHANGE:
 		return "LEAN_HELIX_VIEW_CHANGE"
+	case LEAN_HELIX_MESSAGES_REQUEST:
+		return "LEAN_HELIX_MESSAGES_REQUEST"
+	case LEAN_HELIX_MESSAGES_RESPONSE:
+		return "LEAN_HELIX_MESSAGES_RESPONSE"
+	case LEAN_HELIX_COMMIT_CERTIFICATE:
+		return "LEAN_HELIX_COMMIT_CERTIFICATE"
+	case LEAN_HELIX_BLOCK_REQUEST:
+		return "LEAN_HELIX_BLOCK_REQUEST"
+	case LEAN_HELIX_BLOCK_RESPONSE:
+		return "LEAN_HELIX_BLOCK_RESPONSE"
+	case LEAN_HELIX_BLOCK_CHUNK:
+		return "LEAN_HELIX_BLOCK_CHUNK"
+	case LEAN_HELIX_VIEW_CHANGE_SUMMARY:
+		return "LEAN_HELIX_VIEW_CHANGE_SUMMARY"
 	}
 	return "UNKNOWN"
 }
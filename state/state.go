@@ -13,6 +13,7 @@ type State struct {
 	sync.RWMutex
 	height   primitives.BlockHeight
 	view     primitives.View
+	paused   bool
 	Contexts *ViewContexts
 }
 
@@ -78,6 +79,21 @@ func (s *State) HeightView() *HeightView {
 	return NewHeightView(s.height, s.view)
 }
 
+// A paused node keeps following heights without signing or proposing
+func (s *State) SetPaused(paused bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.paused = paused
+}
+
+func (s *State) Paused() bool {
+	s.RLock()
+	defer s.RUnlock()
+
+	return s.paused
+}
+
 func (s *State) GcOldContexts() {
 	s.Contexts.CancelOlderThan(NewHeightView(s.Height(), 0))
 }
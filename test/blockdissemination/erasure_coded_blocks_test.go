@@ -0,0 +1,75 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package blockdissemination
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
+	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"github.com/orbs-network/lean-helix-go/test/network"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestErasureCodedBlocksAreRebuiltAndCommitted(t *testing.T) {
+	test.WithContextWithTimeout(t, 15*time.Second, func(ctx context.Context) {
+		block1 := mocks.ABlock(interfaces.GenesisBlock)
+		block2 := mocks.ABlock(block1)
+
+		net := network.ATestNetworkBuilder(4, block1, block2).
+			WithErasureCodedBlocks().
+			Build(ctx)
+		leader := net.Nodes[0]
+		net.SetNodesToPauseOnRequestNewBlock()
+
+		net.StartConsensus(ctx)
+
+		net.ReturnWhenNodeIsPausedOnRequestNewBlock(ctx, leader)
+		net.ResumeRequestNewBlockOnNodes(ctx, leader)
+		net.WaitUntilNodesEventuallyCommitASpecificBlock(ctx, t, 0, block1)
+
+		// hang the leader before the next round
+		net.ReturnWhenNodeIsPausedOnRequestNewBlock(ctx, leader)
+
+		preprepares := leader.Communication.GetSentMessages(protocol.LEAN_HELIX_PREPREPARE)
+		require.Len(t, preprepares, 1)
+		require.Nil(t, preprepares[0].Block, "the PREPREPARE should be sent without the block")
+
+		require.Equal(t, 3, leader.Communication.CountSentMessages(protocol.LEAN_HELIX_BLOCK_CHUNK), "the leader should send one chunk to each member")
+		for _, node := range net.Nodes[1:] {
+			require.Equal(t, 1, node.Communication.CountSentMessages(protocol.LEAN_HELIX_BLOCK_CHUNK), "each member should forward its own chunk once")
+		}
+
+		for _, node := range net.Nodes[1:] {
+			require.True(t, node.Communication.CountSentMessages(protocol.LEAN_HELIX_BLOCK_REQUEST) <= 1, "each member should request the block at most once as a fallback to the chunks")
+		}
+	})
+}
+
+func TestErasureCodedBlocksAreRebuiltWithASilentMember(t *testing.T) {
+	test.WithContextWithTimeout(t, 15*time.Second, func(ctx context.Context) {
+		block1 := mocks.ABlock(interfaces.GenesisBlock)
+		block2 := mocks.ABlock(block1)
+
+		net := network.ATestNetworkBuilder(4, block1, block2).
+			WithErasureCodedBlocks().
+			Build(ctx)
+		leader := net.Nodes[0]
+		silent := net.Nodes[3]
+		silent.Communication.DisableOutgoingCommunication() // never forwards its chunk
+		net.SetNodesToPauseOnRequestNewBlock()
+
+		net.StartConsensus(ctx)
+
+		net.ReturnWhenNodeIsPausedOnRequestNewBlock(ctx, leader)
+		net.ResumeRequestNewBlockOnNodes(ctx, leader)
+		net.WaitUntilNodesEventuallyCommitASpecificBlock(ctx, t, 0, block1, net.Nodes[:3]...)
+	})
+}
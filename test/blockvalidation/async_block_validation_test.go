@@ -0,0 +1,54 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package blockvalidation
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"github.com/orbs-network/lean-helix-go/test/network"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestViewChangesWhileBlocksAreValidatedAsynchronously(t *testing.T) {
+	test.WithContextWithTimeout(t, 15*time.Second, func(ctx context.Context) {
+		block1 := mocks.ABlock(interfaces.GenesisBlock)
+		block2 := mocks.ABlock(block1)
+
+		net := network.ATestNetworkBuilder(4, block1, block2).
+			WithAsyncBlockValidation().
+			Build(ctx)
+		members := net.Nodes[1:]
+		net.SetNodesToPauseOnValidateBlock(members...)
+		for _, node := range members {
+			node.BlockUtils.(*mocks.PausableBlockUtils).PausedValidationIgnoresContext = true
+		}
+
+		net.StartConsensus(ctx)
+		net.ReturnWhenNodesPauseOnValidateBlock(ctx, members...)
+		for _, node := range members { // only the validations of V=0 hang
+			node.BlockUtils.(*mocks.PausableBlockUtils).PauseOnValidateBlock = false
+		}
+
+		// the members are stuck validating the proposal of V=0, even though it is canceled, and still move to V=1
+		net.TriggerElectionsOnAllNodes(ctx)
+		require.True(t, test.Eventually(time.Second, func() bool {
+			for _, node := range members {
+				if hv := node.State().HeightView(); hv.Height() == 1 && hv.View() == 0 {
+					return false
+				}
+			}
+			return true
+		}))
+
+		net.WaitUntilQuorumOfNodesEventuallyReachASpecificHeight(ctx, 2)
+		net.ResumeValidateBlockOnNodes(ctx, members...)
+	})
+}
@@ -9,6 +9,7 @@ package builders
 import (
 	"github.com/orbs-network/lean-helix-go/services/blockproof"
 	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/services/randomseed"
 	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
 	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
 	"github.com/orbs-network/lean-helix-go/test/mocks"
@@ -36,7 +37,7 @@ func AMockBlockProof() *protocol.BlockProof {
 
 	commitMessages := []*interfaces.CommitMessage{cm0, cm1, cm2, cm3}
 
-	blockProof := blockproof.GenerateLeanHelixBlockProof(node1KeyManager, commitMessages)
+	blockProof := blockproof.GenerateLeanHelixBlockProof(randomseed.NewKeyManagerRandomnessBeacon(node1KeyManager), 0, commitMessages)
 
 	return blockProof
 
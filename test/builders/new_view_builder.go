@@ -24,6 +24,9 @@ type NewViewBuilder struct {
 	block            interfaces.Block
 	blockHeight      primitives.BlockHeight
 	view             primitives.View
+	compact          bool
+	customProof      *protocol.PreparedProofBuilder
+	hasCustomProof   bool
 }
 
 func (builder *NewViewBuilder) Build() *interfaces.NewViewMessage {
@@ -34,6 +37,20 @@ func (builder *NewViewBuilder) Build() *interfaces.NewViewMessage {
 		ppmCB = messageFactory.CreatePreprepareMessageContentBuilder(builder.blockHeight, builder.view, builder.block, mocks.CalculateBlockHash(builder.block))
 	}
 
+	if builder.compact {
+		vcms := make([]*interfaces.ViewChangeMessage, len(builder.votes))
+		for i, vote := range builder.votes {
+			vcms[i] = interfaces.NewViewChangeMessage(vote.Build(), nil)
+		}
+		highestPreparedProof := interfaces.ExtractHighestPreparedProofFromViewChangeMessages(vcms)
+		if builder.hasCustomProof {
+			highestPreparedProof = builder.customProof
+		}
+		compactConfirmations := interfaces.ExtractCompactConfirmationsFromViewChangeMessages(vcms)
+		nvcb := messageFactory.CreateCompactNewViewMessageContentBuilder(builder.blockHeight, builder.view, ppmCB, compactConfirmations, highestPreparedProof)
+		return interfaces.NewNewViewMessage(nvcb.Build(), builder.block)
+	}
+
 	nvcb := messageFactory.CreateNewViewMessageContentBuilder(builder.blockHeight, builder.view, ppmCB, builder.votes)
 	return interfaces.NewNewViewMessage(nvcb.Build(), builder.block)
 }
@@ -55,6 +72,20 @@ func (builder *NewViewBuilder) WithViewChangeVotes(votes []*protocol.ViewChangeM
 	return builder
 }
 
+// Builds a NEW_VIEW with compact confirmations and the highest prepared proof among the votes
+func (builder *NewViewBuilder) Compact() *NewViewBuilder {
+	builder.compact = true
+	return builder
+}
+
+// Replaces the highest prepared proof of a compact NEW_VIEW, nil for none
+func (builder *NewViewBuilder) WithHighestPreparedProof(proof *protocol.PreparedProofBuilder) *NewViewBuilder {
+	builder.compact = true
+	builder.customProof = proof
+	builder.hasCustomProof = true
+	return builder
+}
+
 func (builder *NewViewBuilder) OnBlockHeight(blockHeight primitives.BlockHeight) *NewViewBuilder {
 	builder.blockHeight = blockHeight
 	return builder
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/orbs-network/lean-helix-go/services/blockproof"
 	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/services/randomseed"
 	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
 	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
 	"github.com/orbs-network/lean-helix-go/test/builders"
@@ -17,24 +18,27 @@ func GenerateBlocksWithProofsForTest(blocks []interfaces.Block, nodes []*network
 
 	var genesisProof []byte = nil
 	bc.AppendBlockToChain(interfaces.GenesisBlock, genesisProof)
+	prevProof := protocol.BlockProofReader(genesisProof)
 	for _, b := range blocks {
-		proof := generateProof(b, nodes)
+		proof := generateProof(b, prevProof, nodes)
 		bc.AppendBlockToChain(b, proof.Raw())
+		prevProof = proof
 	}
 
 	return bc, nil
 
 }
 
-func generateProof(block interfaces.Block, nodes []*network.Node) *protocol.BlockProof {
+func generateProof(block interfaces.Block, prevProof *protocol.BlockProof, nodes []*network.Node) *protocol.BlockProof {
 
 	var instanceId primitives.InstanceId = 0
 	commits := make([]*interfaces.CommitMessage, 0)
+	randomSeed := randomseed.CalculateRandomSeed(prevProof.RandomSeedSignature())
 
 	for _, node := range nodes {
-		commits = append(commits, builders.ACommitMessage(instanceId, node.KeyManager, node.MemberId, block.Height(), 0, block, 0))
+		commits = append(commits, builders.ACommitMessage(instanceId, node.KeyManager, node.MemberId, block.Height(), 0, block, randomSeed))
 	}
-	blockProof := blockproof.GenerateLeanHelixBlockProof(nodes[0].KeyManager, commits)
+	blockProof := blockproof.GenerateLeanHelixBlockProof(randomseed.NewKeyManagerRandomnessBeacon(nodes[0].KeyManager), randomSeed, commits)
 
 	return blockProof
 }
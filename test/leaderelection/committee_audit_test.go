@@ -0,0 +1,69 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package leaderelection
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/services/randomseed"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
+	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/orbs-network/lean-helix-go/test/network"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestCommitteeAuditExplainsLeaderOfCommittedHeight(t *testing.T) {
+	test.WithContextWithTimeout(t, 15*time.Second, func(ctx context.Context) {
+		net := network.ATestNetworkBuilder(4).
+			OrderCommitteeByHeight().
+			WithEd25519KeyManagers().
+			Build(ctx).
+			StartConsensus(ctx)
+		net.WaitUntilNodesEventuallyReachASpecificHeight(ctx, 4)
+
+		node0 := net.Nodes[0]
+		block1, proof1 := node0.Blockchain().BlockAndProofAt(1)
+		_, proof2 := node0.Blockchain().BlockAndProofAt(2)
+
+		audit, err := node0.AuditCommittee(ctx, proof2, block1, proof1)
+		require.NoError(t, err)
+		require.Equal(t, primitives.BlockHeight(2), audit.BlockHeight)
+		require.Equal(t, randomseed.CalculateRandomSeed(protocol.BlockProofReader(proof1).RandomSeedSignature()), audit.RandomSeed)
+		require.Equal(t, randomseed.CalculateRandomSeed(protocol.BlockProofReader(proof2).RandomSeedSignature()), audit.NextRandomSeed)
+		require.Len(t, audit.Committee, 4)
+		require.Equal(t, primitives.View(0), audit.CommittedView)
+		require.Equal(t, []primitives.MemberId{net.Nodes[2].MemberId}, audit.Leaders())
+		require.Equal(t, net.Nodes[2].MemberId, audit.CommittedLeader())
+		require.Equal(t, net.Nodes[3].MemberId, audit.LeaderOfView(1))
+	})
+}
+
+func TestCommitteeAuditRejectsInconsistentProofs(t *testing.T) {
+	test.WithContextWithTimeout(t, 15*time.Second, func(ctx context.Context) {
+		net := network.ATestNetworkBuilder(4).
+			WithEd25519KeyManagers().
+			Build(ctx).
+			StartConsensus(ctx)
+		net.WaitUntilNodesEventuallyReachASpecificHeight(ctx, 4)
+
+		node0 := net.Nodes[0]
+		block1, proof1 := node0.Blockchain().BlockAndProofAt(1)
+		block2, proof2 := node0.Blockchain().BlockAndProofAt(2)
+		_, proof3 := node0.Blockchain().BlockAndProofAt(3)
+
+		_, err := node0.AuditCommittee(ctx, proof3, block1, proof1)
+		require.Error(t, err, "audited proof must be of the height following prevBlock")
+
+		_, err = node0.AuditCommittee(ctx, proof3, block2, proof1)
+		require.Error(t, err, "random seed signature must match the seed derived from the previous proof")
+
+		_, err = node0.AuditCommittee(ctx, nil, block2, proof2)
+		require.Error(t, err)
+	})
+}
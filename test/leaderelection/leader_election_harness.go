@@ -19,11 +19,16 @@ type harness struct {
 }
 
 func NewStartedHarness(ctx context.Context, t *testing.T, logsToConsole bool, blocksPool ...interfaces.Block) *harness {
-	return newHarness(ctx, t, logsToConsole, false, true, blocksPool...)
+	return newHarness(ctx, t, logsToConsole, false, true, false, blocksPool...)
+}
+
+// Nodes broadcast VIEW_CHANGE to the whole committee, which enables view synchronization
+func NewStartedHarnessWithBroadcastViewChange(ctx context.Context, t *testing.T, logsToConsole bool, blocksPool ...interfaces.Block) *harness {
+	return newHarness(ctx, t, logsToConsole, false, true, true, blocksPool...)
 }
 
 func NewStartedHarnessDontPauseOnRequestNewBlock(ctx context.Context, t *testing.T, logsToConsole bool, blocksPool ...interfaces.Block) *harness {
-	return newHarness(ctx, t, logsToConsole, false, false, blocksPool...)
+	return newHarness(ctx, t, logsToConsole, false, false, false, blocksPool...)
 }
 
 // This might not be a good idea but it is needed outside this package
@@ -31,14 +36,17 @@ func Net(h *harness) *network.TestNetwork {
 	return h.net
 }
 func NewStartedHarnessWithFailingBlockProposalValidations(ctx context.Context, t *testing.T, logsToConsole bool) *harness {
-	return newHarness(ctx, t, logsToConsole, true, true)
+	return newHarness(ctx, t, logsToConsole, true, true, false)
 }
 
-func newHarness(ctx context.Context, t *testing.T, logsToConsole bool, withFailingBlockProposalValidations bool, pauseOnRequestNewBlock bool, blocksPool ...interfaces.Block) *harness {
+func newHarness(ctx context.Context, t *testing.T, logsToConsole bool, withFailingBlockProposalValidations bool, pauseOnRequestNewBlock bool, broadcastViewChange bool, blocksPool ...interfaces.Block) *harness {
 	networkBuilder := network.ATestNetworkBuilder(4)
 	if logsToConsole {
 		networkBuilder = networkBuilder.LogToConsole(t)
 	}
+	if broadcastViewChange {
+		networkBuilder = networkBuilder.WithBroadcastViewChange()
+	}
 	net := networkBuilder.
 		WithMaybeFailingBlockProposalValidations(withFailingBlockProposalValidations, blocksPool...).
 		Build(ctx)
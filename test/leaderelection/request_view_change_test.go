@@ -0,0 +1,51 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package leaderelection
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"github.com/orbs-network/lean-helix-go/test/network"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestRequestedViewChangeReplacesAStuckLeaderBeforeTheElectionTimeout(t *testing.T) {
+	test.WithContextWithTimeout(t, 15*time.Second, func(ctx context.Context) {
+		block1 := mocks.ABlock(interfaces.GenesisBlock)
+		block2 := mocks.ABlock(block1)
+
+		net := network.ATestNetworkBuilder(4, block1, block2).
+			WithTimeBasedElectionTrigger(time.Hour).
+			Build(ctx)
+		node0 := net.Nodes[0]
+		net.SetNodesToPauseOnRequestNewBlock(node0)
+		net.StartConsensus(ctx)
+		net.ReturnWhenNodeIsPausedOnRequestNewBlock(ctx, node0)
+
+		for _, node := range net.Nodes {
+			require.NoError(t, node.RequestViewChange(ctx, 1, 0))
+		}
+
+		net.WaitUntilNodesEventuallyReachASpecificHeight(ctx, 2, net.Nodes[1:]...)
+	})
+}
+
+func TestStaleViewChangeRequestIsRejected(t *testing.T) {
+	test.WithContextWithTimeout(t, 15*time.Second, func(ctx context.Context) {
+		net := network.ABasicTestNetwork(ctx)
+		node := net.Nodes[1]
+		require.Error(t, node.RequestViewChange(ctx, 1, 0), "consensus has not started")
+
+		net.StartConsensus(ctx)
+		net.WaitUntilNodesEventuallyReachASpecificHeight(ctx, 2, node)
+		require.Error(t, node.RequestViewChange(ctx, 1, 0))
+	})
+}
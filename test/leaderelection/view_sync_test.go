@@ -0,0 +1,61 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package leaderelection
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
+	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestNodeWithSilentTimerJoinsTheViewOfFPlus1ViewChanges(t *testing.T) {
+	test.WithContextWithTimeout(t, 15*time.Second, func(ctx context.Context) {
+		h := NewStartedHarnessWithBroadcastViewChange(ctx, t, LOG_TO_CONSOLE)
+		node0 := h.net.Nodes[0]
+		node2 := h.net.Nodes[2]
+		node3 := h.net.Nodes[3]
+
+		h.net.ReturnWhenNodeIsPausedOnRequestNewBlock(ctx, node0)
+
+		// only node0 and node2 time out, node3 is not the leader of V=1 and its timer never fires
+		h.net.TriggerElectionsOnNodes(ctx, node0, node2)
+
+		require.True(t, test.Eventually(time.Second, func() bool {
+			return node3.Communication.CountSentMessages(protocol.LEAN_HELIX_VIEW_CHANGE) == 1
+		}), "node3 should join V=1 with its own VIEW_CHANGE once it sees VIEW_CHANGE of more than f")
+	})
+}
+
+func TestViewSyncCompletesTheQuorumOfAViewWhenOnlyFPlus1TimersFire(t *testing.T) {
+	test.WithContextWithTimeout(t, 15*time.Second, func(ctx context.Context) {
+		h := NewStartedHarnessWithBroadcastViewChange(ctx, t, LOG_TO_CONSOLE)
+		node0 := h.net.Nodes[0]
+		node1 := h.net.Nodes[1]
+		node2 := h.net.Nodes[2]
+		node3 := h.net.Nodes[3]
+
+		h.net.ReturnWhenNodeIsPausedOnRequestNewBlock(ctx, node0)
+		node1.Communication.DisableOutgoingCommunication() // the leader of V=1 is down as well
+
+		// only node0 and node2 time out, twice, while node3's timer never fires
+		h.net.TriggerElectionsOnNodes(ctx, node0, node2)
+		require.True(t, test.Eventually(time.Second, func() bool {
+			return node0.State().View() == 1 && node2.State().View() == 1 && node3.State().View() == 1
+		}), "node3 should join V=1")
+		h.net.TriggerElectionsOnNodes(ctx, node0, node2)
+
+		// node2, the leader of V=2, is elected only with the VIEW_CHANGE node3 sent when it joined V=2
+		h.net.ReturnWhenNodeIsPausedOnRequestNewBlock(ctx, node2)
+		h.net.ResumeRequestNewBlockOnNodes(ctx, node2)
+		h.net.WaitUntilNodesEventuallyReachASpecificHeight(ctx, 2, node0, node2, node3)
+
+		require.Equal(t, 1, node2.Communication.CountSentMessages(protocol.LEAN_HELIX_NEW_VIEW))
+	})
+}
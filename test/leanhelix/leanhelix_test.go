@@ -10,6 +10,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
 	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
 	"github.com/orbs-network/lean-helix-go/test"
 	"github.com/orbs-network/lean-helix-go/test/matchers"
@@ -70,6 +71,39 @@ func TestConsensusFor2Blocks(t *testing.T) {
 	})
 }
 
+func TestConsensusWithEd25519KeyManagers(t *testing.T) {
+	test.WithContextWithTimeout(t, 15*time.Second, func(ctx context.Context) {
+		net := network.ATestNetworkBuilder(4).
+			WithEd25519KeyManagers().
+			WithTimeBasedElectionTrigger(100 * time.Millisecond).
+			Build(ctx).
+			StartConsensus(ctx)
+		net.WaitUntilSubsetOfNodesEventuallyReachASpecificHeight(ctx, 3, 3)
+	})
+}
+
+func TestConsensusWithPluggableRandomnessBeacon(t *testing.T) {
+	test.WithContextWithTimeout(t, 15*time.Second, func(ctx context.Context) {
+		net := network.ATestNetworkBuilder(4).
+			WithMockRandomnessBeacons().
+			Build(ctx).
+			StartConsensus(ctx)
+		net.WaitUntilNodesEventuallyReachASpecificHeight(ctx, 3)
+
+		node0 := net.Nodes[0]
+		block2, proof2 := node0.Blockchain().BlockAndProofAt(2)
+		block1, proof1 := node0.Blockchain().BlockAndProofAt(1)
+		beacon := node0.RandomnessBeacon.(*mocks.MockRandomnessBeacon)
+		randomSeed := beacon.DeriveRandomSeed(2, protocol.BlockProofReader(proof1))
+		require.Equal(t, mocks.MockRandomnessBeaconAggregate(2, randomSeed), primitives.RandomSeedSignature(protocol.BlockProofReader(proof2).RandomSeedSignature()))
+		require.NoError(t, node0.ValidateBlockConsensus(ctx, block2, proof2, block1, proof1))
+
+		for _, node := range net.Nodes {
+			require.True(t, node.RandomnessBeacon.(*mocks.MockRandomnessBeacon).CallCount("ProduceShare") > 0)
+		}
+	})
+}
+
 func TestHangingNode(t *testing.T) {
 	test.WithContextWithTimeout(t, 15*time.Second, func(ctx context.Context) {
 		block1 := mocks.ABlock(interfaces.GenesisBlock)
@@ -13,6 +13,7 @@ import (
 	"github.com/orbs-network/lean-helix-go/test/leaderelection"
 	"github.com/orbs-network/lean-helix-go/test/mocks"
 	"github.com/orbs-network/lean-helix-go/test/network"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"testing"
 	"time"
@@ -143,15 +144,59 @@ func TestVerifyWorkerContextNotCancelledIfNodeSyncBlockIsIgnored(t *testing.T) {
 			return
 		}
 
-		blockToSync, blockProofToSync := bc.BlockAndProofAt(1)
-		prevBlockToSync, prevBlockProofToSync := bc.BlockAndProofAt(0)
+		// node0 is held in its commit callback of block2 before it moves to H=3, so block2 passes the stale height check
+		// of UpdateState and is only ignored by the worker loop, which handles it once block2 is committed
+		committed := make(chan *network.NodeState)
+		node0.CommittedBlockChannel = committed
+		net.ResumeRequestNewBlockOnNodes(ctx, node0)
+		require.True(t, test.Eventually(time.Second, func() bool {
+			return node0.GetLatestBlock() == block2
+		}), "node0 should be committing block2")
+		net.SetNodesToNotPauseOnRequestNewBlock()
+
+		blockToSync, blockProofToSync := bc.BlockAndProofAt(2)
+		prevBlockToSync, prevBlockProofToSync := bc.BlockAndProofAt(1)
 
 		if err := node0.Sync(ctx, blockToSync, blockProofToSync, prevBlockToSync, prevBlockProofToSync); err != nil {
 			t.Fatalf("Sync failed for node %s - %s", node0.MemberId, err)
 		}
+		go func() { // releases the commit callbacks of node0 from here on
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-committed:
+				}
+			}
+		}()
+
+		// the context of H=3 survives the ignored block, so node0 goes on to commit block3
+		net.WaitUntilNodesEventuallyReachASpecificHeight(ctx, 4, node0)
+		require.True(t, node0.GetLatestBlock().Height() >= block3.Height(), "node0 should have committed the block of H=3")
+	})
+}
+
+func TestUpdateStateRejectsABlockBelowTheCurrentHeight(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		block1 := mocks.ABlock(interfaces.GenesisBlock)
+		block2 := mocks.ABlock(block1)
+
+		net := network.ATestNetworkBuilder(4, block1, block2).Build(ctx)
+		node0 := net.Nodes[0]
+		net.SetNodesToPauseOnRequestNewBlock(node0)
+		net.StartConsensus(ctx)
+		net.ReturnWhenNodeIsPausedOnRequestNewBlock(ctx, node0)
+		net.ResumeRequestNewBlockOnNodes(ctx, node0)
+		net.WaitUntilNodesEventuallyCommitASpecificBlock(ctx, t, 0, block1)
+		net.ReturnWhenNodeIsPausedOnRequestNewBlock(ctx, node0) // pause when proposing block2
 
-		time.Sleep(100 * time.Millisecond) // let the above go func run
+		bc, err := leaderelection.GenerateBlocksWithProofsForTest([]interfaces.Block{block1, block2}, net.Nodes)
+		require.NoError(t, err)
+		blockToSync, blockProofToSync := bc.BlockAndProofAt(1)
+		prevBlockToSync, prevBlockProofToSync := bc.BlockAndProofAt(0)
 
+		err = node0.Sync(ctx, blockToSync, blockProofToSync, prevBlockToSync, prevBlockProofToSync)
+		require.Equal(t, interfaces.ErrStaleHeight, errors.Cause(err), "a block below the current height should be rejected as stale")
 		require.Equal(t, primitives.BlockHeight(2), node0.GetCurrentHeight())
 	})
 }
@@ -0,0 +1,45 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package mocks
+
+import (
+	"encoding/binary"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/pkg/errors"
+)
+
+const mockBlockHeaderSize = 16
+
+type MockBlockCodec struct {
+}
+
+func NewMockBlockCodec() *MockBlockCodec {
+	return &MockBlockCodec{}
+}
+
+func (c *MockBlockCodec) EncodeBlock(block interfaces.Block) ([]byte, error) {
+	mockBlock, ok := block.(*MockBlock)
+	if !ok {
+		return nil, errors.Errorf("MockBlockCodec cannot encode %T", block)
+	}
+	encoded := make([]byte, mockBlockHeaderSize, mockBlockHeaderSize+len(mockBlock.body))
+	binary.BigEndian.PutUint64(encoded[0:8], uint64(mockBlock.height))
+	binary.BigEndian.PutUint64(encoded[8:16], uint64(mockBlock.refTime))
+	return append(encoded, mockBlock.body...), nil
+}
+
+func (c *MockBlockCodec) DecodeBlock(encoded []byte) (interfaces.Block, error) {
+	if len(encoded) < mockBlockHeaderSize {
+		return nil, errors.Errorf("MockBlockCodec cannot decode %d bytes", len(encoded))
+	}
+	return &MockBlock{
+		height:  primitives.BlockHeight(binary.BigEndian.Uint64(encoded[0:8])),
+		refTime: primitives.TimestampSeconds(binary.BigEndian.Uint64(encoded[8:16])),
+		body:    string(encoded[mockBlockHeaderSize:]),
+	}, nil
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"github.com/orbs-network/lean-helix-go/services/interfaces"
 	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"strings"
 	"sync/atomic"
 )
 
@@ -54,6 +55,18 @@ func ABlock(previousBlock interfaces.Block) interfaces.Block {
 	return block
 }
 
+// A block without transactions, proposed when the leader does not get a block in time
+func AnEmptyBlock(previousBlock interfaces.Block) interfaces.Block {
+	block := ABlock(previousBlock).(*MockBlock)
+	block.body = block.body + " (Empty)"
+	return block
+}
+
+func IsEmptyBlock(block interfaces.Block) bool {
+	mockBlock, ok := block.(*MockBlock)
+	return ok && strings.HasSuffix(mockBlock.body, " (Empty)")
+}
+
 var blocksCounter uint64 = 0
 
 func genBody(height primitives.BlockHeight) string {
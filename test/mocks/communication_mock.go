@@ -32,6 +32,11 @@ type outgoingMessage struct {
 	message *interfaces.ConsensusRawMessage
 }
 
+type sentMessage struct {
+	targets []primitives.MemberId
+	message *interfaces.ConsensusRawMessage
+}
+
 type messageProps struct {
 	messageType protocol.MessageType
 	height      primitives.BlockHeight
@@ -59,7 +64,7 @@ type CommunicationMock struct {
 	incomingWhiteListMemberIds []primitives.MemberId
 
 	statsSentMessagesMutex sync.RWMutex
-	statsSentMessages      []*interfaces.ConsensusRawMessage
+	statsSentMessages      []*sentMessage
 	maxDelayDuration       time.Duration
 	messagesHistoryLock    sync.Mutex
 	messagesHistory        []*messageProps
@@ -80,7 +85,7 @@ func NewCommunication(memberId primitives.MemberId, discovery *Discovery, log in
 		subscriptions:              make(map[int]*SubscriptionValue),
 		outgoingWhitelistMemberIds: nil,
 		incomingWhiteListMemberIds: nil,
-		statsSentMessages:          []*interfaces.ConsensusRawMessage{},
+		statsSentMessages:          []*sentMessage{},
 		maxDelayDuration:           time.Duration(0),
 		messagesHistory:            []*messageProps{},
 	}
@@ -90,7 +95,7 @@ func (g *CommunicationMock) SendConsensusMessage(ctx context.Context, targets []
 	g.statsSentMessagesMutex.Lock()
 	defer g.statsSentMessagesMutex.Unlock()
 
-	g.statsSentMessages = append(g.statsSentMessages, message)
+	g.statsSentMessages = append(g.statsSentMessages, &sentMessage{targets, message})
 	for _, target := range targets {
 		channel := g.ReturnOutgoingChannelByTarget(target)
 		select {
@@ -306,8 +311,8 @@ func (g *CommunicationMock) CountSentMessages(messageType protocol.MessageType)
 	defer g.statsSentMessagesMutex.RUnlock()
 
 	res := 0
-	for _, msg := range g.statsSentMessages {
-		if interfaces.ToConsensusMessage(msg).MessageType() == messageType {
+	for _, sent := range g.statsSentMessages {
+		if interfaces.ToConsensusMessage(sent.message).MessageType() == messageType {
 			res++
 		}
 	}
@@ -320,9 +325,29 @@ func (g *CommunicationMock) GetSentMessages(messageType protocol.MessageType) []
 	defer g.statsSentMessagesMutex.RUnlock()
 
 	var res []*interfaces.ConsensusRawMessage
-	for _, msg := range g.statsSentMessages {
-		if interfaces.ToConsensusMessage(msg).MessageType() == messageType {
-			res = append(res, msg)
+	for _, sent := range g.statsSentMessages {
+		if interfaces.ToConsensusMessage(sent.message).MessageType() == messageType {
+			res = append(res, sent.message)
+		}
+	}
+	return res
+}
+
+// Counts messages handed to SendConsensusMessage for the target, regardless of their delivery
+func (g *CommunicationMock) CountSentMessagesTo(messageType protocol.MessageType, view primitives.View, target primitives.MemberId) int {
+	g.statsSentMessagesMutex.RLock()
+	defer g.statsSentMessagesMutex.RUnlock()
+
+	res := 0
+	for _, sent := range g.statsSentMessages {
+		msg := interfaces.ToConsensusMessage(sent.message)
+		if msg.MessageType() != messageType || msg.View() != view {
+			continue
+		}
+		for _, sentTarget := range sent.targets {
+			if sentTarget.Equal(target) {
+				res++
+			}
 		}
 	}
 	return res
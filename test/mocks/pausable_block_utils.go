@@ -15,6 +15,7 @@ import (
 	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
 	"github.com/orbs-network/lean-helix-go/test"
 	"math"
+	"time"
 )
 
 func CalculateBlockHash(block interfaces.Block) primitives.BlockHash {
@@ -36,7 +37,9 @@ type PausableBlockUtils struct {
 	RequestNewBlockLatch                                   *test.Latch
 	ValidationLatch                                        *test.Latch
 	PauseOnValidateBlock                                   bool
+	PausedValidationIgnoresContext                         bool // a paused validation waits for Resume even after its context is done
 	failBlockProposalValidations                           bool
+	BlockProductionDelay                                   time.Duration
 }
 
 func NewMockBlockUtils(memberId primitives.MemberId, blocksPool *BlocksPool, logger interfaces.Logger) *PausableBlockUtils {
@@ -65,18 +68,34 @@ func (b *PausableBlockUtils) RequestNewBlockProposal(ctx context.Context, blockH
 		b.RequestNewBlockCallsLeftUntilItPausesWhenCounterIsZero--
 	}
 
+	if b.BlockProductionDelay > 0 {
+		select {
+		case <-time.After(b.BlockProductionDelay):
+		case <-ctx.Done():
+		}
+	}
+
 	block := b.blocksPool.PopBlock(prevBlock)
 	blockHash := CalculateBlockHash(block)
 	return block, blockHash
 }
 
+func (b *PausableBlockUtils) RequestEmptyBlockProposal(ctx context.Context, blockHeight primitives.BlockHeight, _ primitives.MemberId, prevBlock interfaces.Block) (interfaces.Block, primitives.BlockHash) {
+	block := AnEmptyBlock(prevBlock)
+	return block, CalculateBlockHash(block)
+}
+
 func (b *PausableBlockUtils) ValidateBlockCommitment(blockHeight primitives.BlockHeight, block interfaces.Block, blockHash primitives.BlockHash) bool {
 	return CalculateBlockHash(block).Equal(blockHash)
 }
 
 func (b *PausableBlockUtils) ValidateBlockProposal(ctx context.Context, blockHeight primitives.BlockHeight, _ primitives.MemberId, block interfaces.Block, blockHash primitives.BlockHash, prevBlock interfaces.Block) error {
 	if b.PauseOnValidateBlock {
-		b.ValidationLatch.WaitOnPauseThenWaitOnResume(ctx, b.memberId)
+		latchCtx := ctx
+		if b.PausedValidationIgnoresContext {
+			latchCtx = context.Background()
+		}
+		b.ValidationLatch.WaitOnPauseThenWaitOnResume(latchCtx, b.memberId)
 	}
 
 	if b.failBlockProposalValidations {
@@ -0,0 +1,85 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package mocks
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
+	"github.com/pkg/errors"
+	"sync"
+)
+
+// A RandomnessBeacon independent of the KeyManager, with readable shares and aggregates
+type MockRandomnessBeacon struct {
+	myMemberId primitives.MemberId
+	lock       sync.Mutex
+	calls      map[string]int
+}
+
+func NewMockRandomnessBeacon(memberId primitives.MemberId) *MockRandomnessBeacon {
+	return &MockRandomnessBeacon{
+		myMemberId: memberId,
+		calls:      make(map[string]int),
+	}
+}
+
+func (b *MockRandomnessBeacon) countCall(method string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.calls[method]++
+}
+
+func (b *MockRandomnessBeacon) CallCount(method string) int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.calls[method]
+}
+
+func MockRandomnessBeaconAggregate(blockHeight primitives.BlockHeight, randomSeed uint64) primitives.RandomSeedSignature {
+	return []byte(fmt.Sprintf("BEACON_AGG|%s|%d", blockHeight, randomSeed))
+}
+
+func mockRandomnessBeaconShare(blockHeight primitives.BlockHeight, randomSeed uint64, memberId primitives.MemberId) primitives.RandomSeedSignature {
+	return []byte(fmt.Sprintf("BEACON_SHARE|%s|%d|%s", blockHeight, randomSeed, memberId.KeyForMap()))
+}
+
+func (b *MockRandomnessBeacon) DeriveRandomSeed(blockHeight primitives.BlockHeight, prevBlockProof *protocol.BlockProof) uint64 {
+	b.countCall("DeriveRandomSeed")
+	hash := sha256.Sum256(prevBlockProof.RandomSeedSignature())
+	return binary.BigEndian.Uint64(hash[:8])
+}
+
+func (b *MockRandomnessBeacon) ProduceShare(ctx context.Context, blockHeight primitives.BlockHeight, randomSeed uint64) primitives.RandomSeedSignature {
+	b.countCall("ProduceShare")
+	return mockRandomnessBeaconShare(blockHeight, randomSeed, b.myMemberId)
+}
+
+func (b *MockRandomnessBeacon) VerifyShare(blockHeight primitives.BlockHeight, randomSeed uint64, share *protocol.SenderSignature) error {
+	b.countCall("VerifyShare")
+	if !bytes.Equal(share.Signature(), mockRandomnessBeaconShare(blockHeight, randomSeed, share.MemberId())) {
+		return errors.Errorf("invalid beacon share of %s", share.MemberId())
+	}
+	return nil
+}
+
+func (b *MockRandomnessBeacon) AggregateShares(blockHeight primitives.BlockHeight, randomSeed uint64, shares []*protocol.SenderSignature) primitives.RandomSeedSignature {
+	b.countCall("AggregateShares")
+	return MockRandomnessBeaconAggregate(blockHeight, randomSeed)
+}
+
+func (b *MockRandomnessBeacon) VerifyAggregate(blockHeight primitives.BlockHeight, randomSeed uint64, aggregate primitives.RandomSeedSignature) error {
+	b.countCall("VerifyAggregate")
+	if !bytes.Equal(aggregate, MockRandomnessBeaconAggregate(blockHeight, randomSeed)) {
+		return errors.New("invalid beacon aggregate")
+	}
+	return nil
+}
@@ -11,11 +11,15 @@ import (
 )
 
 type TermMessagesHandlerMock struct {
-	HistoryPP []*interfaces.PreprepareMessage
-	HistoryP  []*interfaces.PrepareMessage
-	HistoryC  []*interfaces.CommitMessage
-	HistoryNV []*interfaces.NewViewMessage
-	HistoryVC []*interfaces.ViewChangeMessage
+	HistoryPP  []*interfaces.PreprepareMessage
+	HistoryP   []*interfaces.PrepareMessage
+	HistoryC   []*interfaces.CommitMessage
+	HistoryNV  []*interfaces.NewViewMessage
+	HistoryVC  []*interfaces.ViewChangeMessage
+	HistoryMR  []*interfaces.MessagesRequestMessage
+	HistoryBRQ []*interfaces.BlockRequestMessage
+	HistoryBRS []*interfaces.BlockResponseMessage
+	HistoryBC  []*interfaces.BlockChunkMessage
 }
 
 func NewTermMessagesHandlerMock() *TermMessagesHandlerMock {
@@ -41,3 +45,19 @@ func (tmh *TermMessagesHandlerMock) HandleNewView(nvm *interfaces.NewViewMessage
 func (tmh *TermMessagesHandlerMock) HandleViewChange(vcm *interfaces.ViewChangeMessage) {
 	tmh.HistoryVC = append(tmh.HistoryVC, vcm)
 }
+
+func (tmh *TermMessagesHandlerMock) HandleMessagesRequest(mrm *interfaces.MessagesRequestMessage) {
+	tmh.HistoryMR = append(tmh.HistoryMR, mrm)
+}
+
+func (tmh *TermMessagesHandlerMock) HandleBlockRequest(brq *interfaces.BlockRequestMessage) {
+	tmh.HistoryBRQ = append(tmh.HistoryBRQ, brq)
+}
+
+func (tmh *TermMessagesHandlerMock) HandleBlockResponse(brs *interfaces.BlockResponseMessage) {
+	tmh.HistoryBRS = append(tmh.HistoryBRS, brs)
+}
+
+func (tmh *TermMessagesHandlerMock) HandleBlockChunk(bcm *interfaces.BlockChunkMessage) {
+	tmh.HistoryBC = append(tmh.HistoryBC, bcm)
+}
@@ -0,0 +1,58 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package multiplexer
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/services/logger"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/orbs-network/lean-helix-go/test/builders"
+	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"github.com/orbs-network/lean-helix-go/test/network"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const STALLED_INSTANCE_ID = primitives.InstanceId(99)
+
+func TestAStalledInstanceDoesNotBlockTheOthersSharingItsTransport(t *testing.T) {
+	test.WithContextWithTimeout(t, 15*time.Second, func(ctx context.Context) {
+		net := network.ATestNetworkBuilder(4).
+			WithMultiplexers().
+			Build(ctx)
+
+		for _, node := range net.Nodes {
+			config := node.BuildConfig(logger.NewSilentLogger())
+			config.InstanceId = STALLED_INSTANCE_ID
+			config.MsgChanBufLen = 10
+			stalled := leanhelix.NewLeanHelix(config, nil, nil) // never run, so it never drains its queue
+			require.NoError(t, node.Multiplexer.Add(ctx, stalled))
+
+			// the transport delivers to its subscribers one at a time, a blocking delivery would stall the node
+			sender := net.Nodes[0].MemberId
+			prepare := builders.APrepareMessage(STALLED_INSTANCE_ID, mocks.NewMockKeyManager(sender), sender, 1, 0, mocks.ABlock(interfaces.GenesisBlock))
+			for i := 0; i < 100; i++ {
+				node.Communication.OnIncomingMessage(ctx, prepare.ToConsensusRawMessage())
+			}
+		}
+
+		net.StartConsensus(ctx)
+		net.WaitUntilNodesEventuallyReachASpecificHeight(ctx, 3)
+		require.NoError(t, ctx.Err(), "the network should keep committing while the stalled instance drops its messages")
+
+		for _, node := range net.Nodes {
+			metrics := node.Multiplexer.Metrics()
+			require.True(t, metrics.Dropped(STALLED_INSTANCE_ID) > 0, "node %s should drop the messages of the stalled instance", node.MemberId)
+			require.True(t, metrics.Routed(net.InstanceId) > 0, "node %s should route the messages of the running instance", node.MemberId)
+			require.Zero(t, metrics.Dropped(net.InstanceId))
+		}
+	})
+}
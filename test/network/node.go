@@ -10,7 +10,10 @@ import (
 	"context"
 	"fmt"
 	"github.com/orbs-network/lean-helix-go"
+	"github.com/orbs-network/lean-helix-go/instrumentation/metrics"
+	"github.com/orbs-network/lean-helix-go/services/events"
 	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/services/leanhelixterm"
 	"github.com/orbs-network/lean-helix-go/services/storage"
 	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
 	"github.com/orbs-network/lean-helix-go/state"
@@ -29,10 +32,17 @@ type NodeState struct {
 type Node struct {
 	instanceId                 primitives.InstanceId
 	leanHelix                  *leanhelix.MainLoop
+	Multiplexer                *leanhelix.Multiplexer // nil unless messages are routed through a Multiplexer
 	blockChain                 *mocks.InMemoryBlockchain
 	ElectionTrigger            interfaces.ElectionScheduler
 	BlockUtils                 interfaces.BlockUtils
-	KeyManager                 *mocks.MockKeyManager
+	KeyManager                 interfaces.KeyManager
+	RandomnessBeacon           interfaces.RandomnessBeacon
+	BlockCodec                 interfaces.BlockCodec
+	PipelinedProposals         bool
+	ProposalTimeout            time.Duration
+	AsyncBlockValidation       bool
+	BroadcastViewChange        bool
 	Storage                    interfaces.Storage
 	Communication              *mocks.CommunicationMock
 	Membership                 interfaces.Membership
@@ -47,6 +57,39 @@ type Node struct {
 	OnElectionCallback         interface{}
 }
 
+// Returns nil when the node has no proposal timeout
+func (node *Node) BlockProposalMetrics() metrics.BlockProposalMetrics {
+	return node.leanHelix.BlockProposalMetrics()
+}
+
+func (node *Node) MessageQueueMetrics() metrics.MessageQueueMetrics {
+	return node.leanHelix.MessageQueueMetrics()
+}
+
+func (node *Node) ParticipationMetrics() metrics.ParticipationMetrics {
+	return node.leanHelix.ParticipationMetrics()
+}
+
+func (node *Node) Pause() {
+	node.leanHelix.Pause()
+}
+
+func (node *Node) Resume() {
+	node.leanHelix.Resume()
+}
+
+func (node *Node) Subscribe(bufLen int) *events.Subscription {
+	return node.leanHelix.Subscribe(bufLen)
+}
+
+func (node *Node) Unsubscribe(subscription *events.Subscription) {
+	node.leanHelix.Unsubscribe(subscription)
+}
+
+func (node *Node) Status() *leanhelix.Status {
+	return node.leanHelix.Status()
+}
+
 func (node *Node) State() *state.State {
 	return node.leanHelix.State()
 }
@@ -169,6 +212,21 @@ func (node *Node) ValidateBlockConsensusSoft(ctx context.Context, block interfac
 	return node.leanHelix.ValidateBlockConsensus(ctx, block, blockProof, prevBlock, prevBlockProof, true)
 }
 
+func (node *Node) AuditCommittee(ctx context.Context, blockProof []byte, prevBlock interfaces.Block, prevBlockProof []byte) (*leanhelixterm.CommitteeAudit, error) {
+	if node.leanHelix == nil {
+		panic("AuditCommittee(): leanhelix is nil")
+	}
+	return node.leanHelix.AuditCommittee(ctx, blockProof, prevBlock, prevBlockProof)
+}
+
+func (node *Node) UpdateConfig(ctx context.Context, update *interfaces.ConfigUpdate) (*interfaces.ConfigUpdateReport, error) {
+	return node.leanHelix.UpdateConfig(ctx, update)
+}
+
+func (node *Node) RequestViewChange(ctx context.Context, height primitives.BlockHeight, view primitives.View) error {
+	return node.leanHelix.RequestViewChange(ctx, height, view)
+}
+
 func (node *Node) Sync(ctx context.Context, block interfaces.Block, blockProofBytes []byte, prevBlock interfaces.Block, prevBlockProofBytes []byte) error {
 	if node.leanHelix == nil {
 		panic("Sync(): leanhelix is nil")
@@ -190,13 +248,21 @@ func (node *Node) BuildConfig(logger interfaces.Logger) *interfaces.Config {
 		Membership:            node.Membership,
 		BlockUtils:            node.BlockUtils,
 		KeyManager:            node.KeyManager,
+		RandomnessBeacon:      node.RandomnessBeacon,
 		ElectionTimeoutOnV0:   10 * time.Millisecond,
 		OnElectionCB:          nil,
 		Storage:               node.Storage,
 		Logger:                logger,
-		MsgChanBufLen:         10,
-		UpdateStateChanBufLen: 10,
-		ElectionChanBufLen:    0,
+		MsgChanBufLen:         1000,
+		UpdateStateChanBufLen: 1,
+		ElectionChanBufLen:    1,
+		// erasure coded chunks are only sent for proposals without a block
+		BlockDisseminationByHash: node.BlockCodec != nil,
+		BlockCodec:               node.BlockCodec,
+		PipelinedProposals:       node.PipelinedProposals,
+		ProposalTimeout:          node.ProposalTimeout,
+		AsyncBlockValidation:     node.AsyncBlockValidation,
+		BroadcastViewChange:      node.BroadcastViewChange,
 	}
 
 }
@@ -215,19 +281,36 @@ func NewNode(
 	communication *mocks.CommunicationMock,
 	blockUtils interfaces.BlockUtils,
 	electionTrigger interfaces.ElectionScheduler,
+	keyManager interfaces.KeyManager,
+	randomnessBeacon interfaces.RandomnessBeacon,
+	blockCodec interfaces.BlockCodec,
+	pipelinedProposals bool,
+	proposalTimeout time.Duration,
+	asyncBlockValidation bool,
+	multiplexed bool,
+	broadcastViewChange bool,
 	logger interfaces.Logger) *Node {
 
 	if electionTrigger == nil {
 		electionTrigger = mocks.NewMockElectionTrigger()
 	}
 	memberId := membership.MyMemberId()
+	if keyManager == nil {
+		keyManager = mocks.NewMockKeyManager(memberId)
+	}
 
 	node := &Node{
 		instanceId:                 instanceId,
 		blockChain:                 mocks.NewInMemoryBlockchain().WithMemberId(memberId),
 		ElectionTrigger:            electionTrigger,
 		BlockUtils:                 blockUtils,
-		KeyManager:                 mocks.NewMockKeyManager(memberId),
+		KeyManager:                 keyManager,
+		RandomnessBeacon:           randomnessBeacon,
+		BlockCodec:                 blockCodec,
+		PipelinedProposals:         pipelinedProposals,
+		ProposalTimeout:            proposalTimeout,
+		AsyncBlockValidation:       asyncBlockValidation,
+		BroadcastViewChange:        broadcastViewChange,
 		Storage:                    storage.NewInMemoryStorage(),
 		Communication:              communication,
 		Membership:                 membership,
@@ -242,7 +325,12 @@ func NewNode(
 	config.OverrideElectionTrigger = node.ElectionTrigger
 
 	leanHelix := leanhelix.NewLeanHelix(config, node.onCommittedBlock, node.onNewConsensusRound)
-	communication.RegisterIncomingMessageHandler(leanHelix.HandleConsensusMessage)
+	if multiplexed { // the MainLoop is added to the Multiplexer once the network is built
+		node.Multiplexer = leanhelix.NewMultiplexer()
+		communication.RegisterIncomingMessageHandler(node.Multiplexer.HandleConsensusMessage)
+	} else {
+		communication.RegisterIncomingMessageHandler(leanHelix.HandleConsensusMessage)
+	}
 
 	node.leanHelix = leanHelix
 	return node
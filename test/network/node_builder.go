@@ -12,6 +12,7 @@ import (
 	"github.com/orbs-network/lean-helix-go/services/logger"
 	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
 	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"time"
 )
 
 type NodeBuilder struct {
@@ -21,6 +22,14 @@ type NodeBuilder struct {
 	memberId        primitives.MemberId
 	electionTrigger interfaces.ElectionScheduler
 	blockUtils      interfaces.BlockUtils
+	keyManager      interfaces.KeyManager
+	beacon          interfaces.RandomnessBeacon
+	blockCodec      interfaces.BlockCodec
+	pipelined       bool
+	proposalTimeout time.Duration
+	asyncValidation bool
+	multiplexed     bool
+	broadcastVC     bool
 	l               interfaces.Logger
 }
 
@@ -71,6 +80,46 @@ func (builder *NodeBuilder) WithBlockUtils(utils interfaces.BlockUtils) *NodeBui
 	return builder
 }
 
+func (builder *NodeBuilder) WithKeyManager(keyManager interfaces.KeyManager) *NodeBuilder {
+	builder.keyManager = keyManager
+	return builder
+}
+
+func (builder *NodeBuilder) WithRandomnessBeacon(beacon interfaces.RandomnessBeacon) *NodeBuilder {
+	builder.beacon = beacon
+	return builder
+}
+
+func (builder *NodeBuilder) WithBlockCodec(blockCodec interfaces.BlockCodec) *NodeBuilder {
+	builder.blockCodec = blockCodec
+	return builder
+}
+
+func (builder *NodeBuilder) WithPipelinedProposals() *NodeBuilder {
+	builder.pipelined = true
+	return builder
+}
+
+func (builder *NodeBuilder) WithProposalTimeout(timeout time.Duration) *NodeBuilder {
+	builder.proposalTimeout = timeout
+	return builder
+}
+
+func (builder *NodeBuilder) WithAsyncBlockValidation() *NodeBuilder {
+	builder.asyncValidation = true
+	return builder
+}
+
+func (builder *NodeBuilder) WithMultiplexer() *NodeBuilder {
+	builder.multiplexed = true
+	return builder
+}
+
+func (builder *NodeBuilder) WithBroadcastViewChange() *NodeBuilder {
+	builder.broadcastVC = true
+	return builder
+}
+
 func (builder *NodeBuilder) Build() *Node {
 	memberId := builder.memberId
 	if memberId == nil {
@@ -91,6 +140,14 @@ func (builder *NodeBuilder) Build() *Node {
 		builder.communication,
 		builder.blockUtils,
 		builder.electionTrigger,
+		builder.keyManager,
+		builder.beacon,
+		builder.blockCodec,
+		builder.pipelined,
+		builder.proposalTimeout,
+		builder.asyncValidation,
+		builder.multiplexed,
+		builder.broadcastVC,
 		builder.l,
 	)
 }
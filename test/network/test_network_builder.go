@@ -11,14 +11,22 @@ import (
 	"fmt"
 	"github.com/orbs-network/lean-helix-go/services/electiontrigger"
 	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/services/keymanager"
 	"github.com/orbs-network/lean-helix-go/services/logger"
+	"github.com/orbs-network/lean-helix-go/services/quorum"
 	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
 	"github.com/orbs-network/lean-helix-go/test"
 	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"os"
+	"sort"
 	"testing"
 	"time"
 )
 
+// Set to KEY_MANAGER_ED25519 to run test networks with keymanager.Ed25519BLSKeyManager instead of mock key managers
+const KEY_MANAGER_ENV_VAR = "LH_TEST_KEY_MANAGER"
+const KEY_MANAGER_ED25519 = "ed25519"
+
 type TestNetworkBuilder struct {
 	instanceId                          primitives.InstanceId
 	NodeCount                           int
@@ -33,6 +41,15 @@ type TestNetworkBuilder struct {
 	electionTriggerTimeout              time.Duration
 	useTimeBasedElectionTrigger         bool
 	withFailingBlockProposalValidations bool
+	useEd25519KeyManagers               bool
+	useMockRandomnessBeacons            bool
+	useErasureCodedBlocks               bool
+	usePipelinedProposals               bool
+	blockProductionDelay                time.Duration
+	proposalTimeout                     time.Duration
+	useAsyncBlockValidation             bool
+	useMultiplexers                     bool
+	broadcastViewChange                 bool
 }
 
 func (tb *TestNetworkBuilder) WithNodeCount(nodeCount int) *TestNetworkBuilder {
@@ -78,6 +95,63 @@ func (tb *TestNetworkBuilder) OrderCommitteeByHeight() *TestNetworkBuilder {
 	return tb
 }
 
+func (tb *TestNetworkBuilder) WithEd25519KeyManagers() *TestNetworkBuilder {
+	tb.useEd25519KeyManagers = true
+	return tb
+}
+
+func (tb *TestNetworkBuilder) WithMockRandomnessBeacons() *TestNetworkBuilder {
+	tb.useMockRandomnessBeacons = true
+	return tb
+}
+
+// Leaders send proposals without the block, and members rebuild it from erasure coded BLOCK_CHUNK messages
+func (tb *TestNetworkBuilder) WithErasureCodedBlocks() *TestNetworkBuilder {
+	tb.useErasureCodedBlocks = true
+	return tb
+}
+
+// Nodes build the block of the next height once the current one is prepared
+func (tb *TestNetworkBuilder) WithPipelinedProposals() *TestNetworkBuilder {
+	tb.usePipelinedProposals = true
+	return tb
+}
+
+// Leaders propose an empty block when their block is not produced within the timeout
+func (tb *TestNetworkBuilder) WithProposalTimeout(timeout time.Duration) *TestNetworkBuilder {
+	tb.proposalTimeout = timeout
+	return tb
+}
+
+// Nodes validate proposed blocks in the background while handling other messages and elections
+func (tb *TestNetworkBuilder) WithAsyncBlockValidation() *TestNetworkBuilder {
+	tb.useAsyncBlockValidation = true
+	return tb
+}
+
+// Nodes send VIEW_CHANGE to the whole committee instead of only to the next leader, which enables view synchronization
+func (tb *TestNetworkBuilder) WithBroadcastViewChange() *TestNetworkBuilder {
+	tb.broadcastViewChange = true
+	return tb
+}
+
+// Nodes receive their messages through a leanhelix.Multiplexer, to which tests may add other instances
+func (tb *TestNetworkBuilder) WithMultiplexers() *TestNetworkBuilder {
+	tb.useMultiplexers = true
+	return tb
+}
+
+func (tb *TestNetworkBuilder) WithBlockProductionDelay(delay time.Duration) *TestNetworkBuilder {
+	tb.blockProductionDelay = delay
+	return tb
+}
+
+// For tests that depend on mock key manager behavior regardless of KEY_MANAGER_ENV_VAR
+func (tb *TestNetworkBuilder) WithMockKeyManagers() *TestNetworkBuilder {
+	tb.useEd25519KeyManagers = false
+	return tb
+}
+
 func (tb *TestNetworkBuilder) Build(ctx context.Context) *TestNetwork {
 
 	if tb.logger == nil {
@@ -89,6 +163,13 @@ func (tb *TestNetworkBuilder) Build(ctx context.Context) *TestNetwork {
 	nodes := tb.createNodes(discovery, blocksPool)
 	testNetwork := NewTestNetwork(tb.instanceId, discovery, tb.logger)
 	testNetwork.RegisterNodes(nodes)
+	for _, node := range nodes {
+		if node.Multiplexer != nil {
+			if err := node.Multiplexer.Add(ctx, node.leanHelix); err != nil {
+				panic(err)
+			}
+		}
+	}
 
 	tb.setupCommChannels(ctx, testNetwork)
 
@@ -123,6 +204,7 @@ func (tb *TestNetworkBuilder) buildNode(
 	memberWeights map[string]primitives.MemberWeight,
 	discovery *mocks.Discovery,
 	blockUtils interfaces.BlockUtils,
+	keyManager interfaces.KeyManager,
 ) *Node {
 
 	communicationInstance := mocks.NewCommunication(memberId, discovery, tb.logger)
@@ -138,12 +220,34 @@ func (tb *TestNetworkBuilder) buildNode(
 		ThatIsPartOf(membership).
 		WithBlockUtils(blockUtils).
 		WithMemberId(memberId).
+		WithKeyManager(keyManager).
 		WithLogger(tb.logger)
 
 	if tb.useTimeBasedElectionTrigger {
 		et := Electiontrigger.NewTimerBasedElectionTrigger(tb.electionTriggerTimeout, nil)
 		b.WithElectionTrigger(et)
 	}
+	if tb.useMockRandomnessBeacons {
+		b.WithRandomnessBeacon(mocks.NewMockRandomnessBeacon(memberId))
+	}
+	if tb.useErasureCodedBlocks {
+		b.WithBlockCodec(mocks.NewMockBlockCodec())
+	}
+	if tb.usePipelinedProposals {
+		b.WithPipelinedProposals()
+	}
+	if tb.proposalTimeout > 0 {
+		b.WithProposalTimeout(tb.proposalTimeout)
+	}
+	if tb.useAsyncBlockValidation {
+		b.WithAsyncBlockValidation()
+	}
+	if tb.useMultiplexers {
+		b.WithMultiplexer()
+	}
+	if tb.broadcastViewChange {
+		b.WithBroadcastViewChange()
+	}
 	return b.Build()
 }
 
@@ -161,9 +265,29 @@ func (tb *TestNetworkBuilder) createNodes(discovery *mocks.Discovery, blocksPool
 		}
 	}
 
+	var committeeKeys *keymanager.CommitteeKeys
+	if tb.useEd25519KeyManagers {
+		memberIds := make([]primitives.MemberId, tb.NodeCount)
+		for i := range memberIds {
+			memberIds[i] = buildId(i)
+		}
+		var err error
+		if committeeKeys, err = keymanager.GenerateCommitteeKeys(tb.NodeCount, minQuorumSize(memberWeights), memberIds...); err != nil {
+			panic(err)
+		}
+	}
+
 	var nodes []*Node
 	for i := 0; i < tb.NodeCount; i++ {
 		memberId := buildId(i)
+		var keyManager interfaces.KeyManager
+		if committeeKeys != nil {
+			member := committeeKeys.Members[i]
+			var err error
+			if keyManager, err = keymanager.NewEd25519BLSKeyManager(member.ConsensusKey.PrivateKey, member.RandomSeedKeyShare, committeeKeys.Directory); err != nil {
+				panic(err)
+			}
+		}
 		var blockUtils interfaces.BlockUtils
 		if i < len(tb.blockUtils) {
 			blockUtils = tb.blockUtils[i]
@@ -172,17 +296,38 @@ func (tb *TestNetworkBuilder) createNodes(discovery *mocks.Discovery, blocksPool
 			if tb.withFailingBlockProposalValidations {
 				pausableBlockUtils.WithFailingBlockProposalValidations()
 			}
+			pausableBlockUtils.BlockProductionDelay = tb.blockProductionDelay
 			blockUtils = pausableBlockUtils
 		}
 
 		nodeBuilder := NewNodeBuilder()
-		node := tb.buildNode(nodeBuilder, memberId, memberWeights, discovery, blockUtils)
+		node := tb.buildNode(nodeBuilder, memberId, memberWeights, discovery, blockUtils, keyManager)
 		nodes = append(nodes, node)
 	}
 
 	return nodes
 }
 
+// The smallest number of members whose weights form a quorum, used as the random seed threshold
+func minQuorumSize(memberWeights map[string]primitives.MemberWeight) int {
+	committee := make([]interfaces.CommitteeMember, 0, len(memberWeights))
+	for id, weight := range memberWeights {
+		committee = append(committee, interfaces.CommitteeMember{Id: primitives.MemberId(id), Weight: weight})
+	}
+	sort.Slice(committee, func(i, j int) bool {
+		return committee[i].Weight > committee[j].Weight
+	})
+
+	heaviest := make([]primitives.MemberId, 0, len(committee))
+	for _, member := range committee {
+		heaviest = append(heaviest, member.Id)
+		if isQuorum, _, _ := quorum.IsQuorum(heaviest, committee); isQuorum {
+			break
+		}
+	}
+	return len(heaviest)
+}
+
 func (tb *TestNetworkBuilder) WithCommunication(communication interfaces.Communication) *TestNetworkBuilder {
 	tb.communication = communication
 	return tb
@@ -206,8 +351,9 @@ func (tb *TestNetworkBuilder) WithMaybeFailingBlockProposalValidations(b bool, b
 
 func NewTestNetworkBuilder() *TestNetworkBuilder {
 	return &TestNetworkBuilder{
-		NodeCount:      0,
-		upcomingBlocks: nil,
+		NodeCount:             0,
+		upcomingBlocks:        nil,
+		useEd25519KeyManagers: os.Getenv(KEY_MANAGER_ENV_VAR) == KEY_MANAGER_ED25519,
 	}
 }
 
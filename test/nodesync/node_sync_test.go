@@ -9,12 +9,17 @@ package test
 import (
 	"context"
 	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/services/messagesfactory"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
 	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/orbs-network/lean-helix-go/test/builders"
 	"github.com/orbs-network/lean-helix-go/test/leaderelection"
 	"github.com/orbs-network/lean-helix-go/test/mocks"
 	"github.com/orbs-network/lean-helix-go/test/network"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestNodeSync_AllNodesReachSameHeight(t *testing.T) {
@@ -74,3 +79,113 @@ func TestNodeSync_AllNodesReachSameHeight(t *testing.T) {
 		net.WaitUntilNodesEventuallyReachASpecificHeight(ctx, block2.Height(), node0, node1, node2, node3)
 	})
 }
+
+func TestNodeSync_LaggingNodeCatchesUpFromCommitCertificate(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		block1 := mocks.ABlock(interfaces.GenesisBlock)
+		block2 := mocks.ABlock(block1)
+
+		net := network.ATestNetworkBuilder(4, block1, block2).
+			//LogToConsole(t).
+			Build(ctx)
+		node0 := net.Nodes[0]
+		node1 := net.Nodes[1]
+		node2 := net.Nodes[2]
+		node3 := net.Nodes[3]
+
+		net.SetNodesToPauseOnRequestNewBlock()
+		net.StartConsensus(ctx)
+
+		// closing node3's network to messages (To make it out of sync)
+		node3.Communication.DisableIncomingCommunication()
+
+		// node0, node1, and node2 are closing block1, then wait for block2 to be proposed
+		net.ReturnWhenNodeIsPausedOnRequestNewBlock(ctx, node0)
+		net.ResumeRequestNewBlockOnNodes(ctx, node0)
+		net.WaitUntilNodesEventuallyCommitASpecificBlock(ctx, t, 0, block1, node0, node1, node2)
+		net.ReturnWhenNodeIsPausedOnRequestNewBlock(ctx, node0)
+		require.True(t, node3.GetLatestBlock() == interfaces.GenesisBlock, "node3 should still be on genesis")
+
+		// node3's VIEW_CHANGE of H=1 reveals it is lagging, and the new leader pushes it the commit certificate of block1
+		node3.Communication.EnableIncomingCommunication()
+		<-node3.TriggerElectionOnNode(ctx)
+		<-node3.TriggerElectionOnNode(ctx)
+
+		net.WaitUntilNodesEventuallyReachASpecificHeight(ctx, 2, node3)
+		require.True(t, node3.GetLatestBlock().Height() == block1.Height(), "node3 should have committed block1 from the commit certificate")
+		_, proof := node3.Blockchain().BlockAndProofAt(1)
+		require.NotEmpty(t, proof, "block1 should be appended to node3's chain with its proof")
+	})
+}
+
+func TestNodeSync_CommitCertificateIsOnlyPushedToVerifiedCommitteeMembers(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		block1 := mocks.ABlock(interfaces.GenesisBlock)
+		block2 := mocks.ABlock(block1)
+
+		net := network.ATestNetworkBuilder(4, block1, block2).
+			Build(ctx)
+		node0 := net.Nodes[0]
+		node1 := net.Nodes[1]
+		node3 := net.Nodes[3]
+
+		// node1 never hears from node3 while committing block1, so it has not pushed node3 a certificate yet
+		node3.Communication.DisableOutgoingCommunication()
+		net.SetNodesToPauseOnRequestNewBlock()
+		net.StartConsensus(ctx)
+		net.ReturnWhenNodeIsPausedOnRequestNewBlock(ctx, node0)
+		net.ResumeRequestNewBlockOnNodes(ctx, node0)
+		net.WaitUntilNodesEventuallyCommitASpecificBlock(ctx, t, 0, block1, net.Nodes[0], net.Nodes[1], net.Nodes[2])
+		net.ReturnWhenNodeIsPausedOnRequestNewBlock(ctx, node0)
+
+		const view = primitives.View(5)
+		outsider := primitives.MemberId("outsider")
+		forgedByOutsider := builders.AViewChangeMessage(net.InstanceId, mocks.NewMockKeyManager(outsider), node3.MemberId, 1, view, nil)
+		signedByOutsider := builders.AViewChangeMessage(net.InstanceId, mocks.NewMockKeyManager(outsider), outsider, 1, view, nil)
+		node1.Communication.OnIncomingMessage(ctx, forgedByOutsider.ToConsensusRawMessage())
+		node1.Communication.OnIncomingMessage(ctx, signedByOutsider.ToConsensusRawMessage())
+		require.True(t, test.Consistently(100*time.Millisecond, func() bool {
+			return node1.Communication.CountSentMessagesTo(protocol.LEAN_HELIX_COMMIT_CERTIFICATE, view, node3.MemberId) == 0 &&
+				node1.Communication.CountSentMessagesTo(protocol.LEAN_HELIX_COMMIT_CERTIFICATE, view, outsider) == 0
+		}), "a forged sender or a non-member should not be sent the certificate")
+
+		signedByNode3 := builders.AViewChangeMessage(net.InstanceId, node3.KeyManager, node3.MemberId, 1, view, nil)
+		node1.Communication.OnIncomingMessage(ctx, signedByNode3.ToConsensusRawMessage())
+		require.True(t, test.Eventually(time.Second, func() bool {
+			return node1.Communication.CountSentMessagesTo(protocol.LEAN_HELIX_COMMIT_CERTIFICATE, view, node3.MemberId) == 1
+		}), "a lagging committee member should be sent the certificate")
+	})
+}
+
+func TestNodeSync_CommitCertificateWithABlockOfAnotherHeightIsIgnored(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		block1 := mocks.ABlock(interfaces.GenesisBlock)
+		block2 := mocks.ABlock(block1)
+
+		net := network.ATestNetworkBuilder(4, block1, block2).
+			Build(ctx)
+		node1 := net.Nodes[1]
+		node3 := net.Nodes[3]
+
+		// the leader is paused proposing block1, so all nodes stay at H=1
+		net.SetNodesToPauseOnRequestNewBlock()
+		net.StartConsensus(ctx)
+
+		bc, err := leaderelection.GenerateBlocksWithProofsForTest([]interfaces.Block{block1, block2}, net.Nodes)
+		require.NoError(t, err)
+		factory := messagesfactory.NewMessageFactory(net.InstanceId, node1.KeyManager, node1.MemberId, 0)
+		certificateOf := func(height primitives.BlockHeight) *interfaces.ConsensusRawMessage {
+			block, proof := bc.BlockAndProofAt(height)
+			return interfaces.CreateConsensusRawMessage(factory.CreateCommitCertificateMessage(1, 0, block, proof))
+		}
+
+		node3.Communication.OnIncomingMessage(ctx, certificateOf(2))
+		require.True(t, test.Consistently(100*time.Millisecond, func() bool {
+			return node3.GetCurrentHeight() == 1 && node3.GetLatestBlock() == interfaces.GenesisBlock
+		}), "a certificate of H=1 carrying block2 should not be committed")
+
+		node3.Communication.OnIncomingMessage(ctx, certificateOf(1))
+		net.WaitUntilNodesEventuallyReachASpecificHeight(ctx, 2, node3)
+		require.True(t, node3.GetLatestBlock().Height() == block1.Height(), "node3 should have committed block1 from the commit certificate")
+	})
+}
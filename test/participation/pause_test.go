@@ -0,0 +1,58 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package participation
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
+	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/orbs-network/lean-helix-go/test/network"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func votesSentBy(net *network.TestNetwork, node *network.Node) int {
+	communication := net.GetNodeCommunication(node.MemberId)
+	return communication.CountSentMessages(protocol.LEAN_HELIX_PREPARE) + communication.CountSentMessages(protocol.LEAN_HELIX_COMMIT)
+}
+
+func TestPausedMemberFollowsHeightsWithoutVoting(t *testing.T) {
+	test.WithContextWithTimeout(t, 15*time.Second, func(ctx context.Context) {
+		net := network.ABasicTestNetwork(ctx)
+		member := net.Nodes[3]
+		member.Pause()
+		require.True(t, member.Status().Paused)
+
+		net.StartConsensus(ctx)
+		net.WaitUntilNodesEventuallyReachASpecificHeight(ctx, 4, net.Nodes...)
+		require.Zero(t, votesSentBy(net, member), "a paused member should not sign PREPARE or COMMIT")
+
+		metrics := member.ParticipationMetrics()
+		require.True(t, metrics.Paused())
+		require.EqualValues(t, 1, metrics.Pauses())
+	})
+}
+
+func TestResumedMemberVotesAgainWithoutARestart(t *testing.T) {
+	test.WithContextWithTimeout(t, 15*time.Second, func(ctx context.Context) {
+		net := network.ABasicTestNetwork(ctx)
+		member := net.Nodes[3]
+		member.Pause()
+
+		net.StartConsensus(ctx)
+		net.WaitUntilNodesEventuallyReachASpecificHeight(ctx, 3, net.Nodes...)
+		member.Resume()
+		status := member.Status()
+		require.False(t, status.Paused)
+
+		net.WaitUntilNodesEventuallyReachASpecificHeight(ctx, status.Height+2, net.Nodes...)
+		require.NotZero(t, votesSentBy(net, member), "a resumed member should vote on the next heights")
+		require.False(t, member.ParticipationMetrics().Paused())
+		require.NotZero(t, member.ParticipationMetrics().TimePaused())
+	})
+}
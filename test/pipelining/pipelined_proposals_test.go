@@ -0,0 +1,87 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package pipelining
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"github.com/orbs-network/lean-helix-go/test/network"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const BLOCK_PRODUCTION_DELAY = 5 * time.Millisecond
+const MESSAGES_MAX_DELAY = 5 * time.Millisecond
+const ELECTION_TIMEOUT = 500 * time.Millisecond
+
+// Every node builds the next block on the prepared one, only one block per height may be committed
+func aPipelinedNetwork(ctx context.Context, pipelined bool) *network.TestNetwork {
+	builder := network.ATestNetworkBuilder(4, mocks.ABlock(interfaces.GenesisBlock)).
+		WithBlockProductionDelay(BLOCK_PRODUCTION_DELAY).
+		GossipMessagesMaxDelay(MESSAGES_MAX_DELAY).
+		WithTimeBasedElectionTrigger(ELECTION_TIMEOUT)
+	if pipelined {
+		builder.WithPipelinedProposals()
+	}
+	net := builder.Build(ctx)
+	for _, node := range net.Nodes {
+		node.WriteToStateChannel = false
+	}
+	return net
+}
+
+func TestPipelinedNetworkCommitsTheSameChainOnAllNodes(t *testing.T) {
+	test.WithContextWithTimeout(t, 15*time.Second, func(ctx context.Context) {
+		net := aPipelinedNetwork(ctx, true)
+		net.StartConsensus(ctx)
+
+		const height = primitives.BlockHeight(10)
+		net.WaitUntilQuorumOfNodesEventuallyReachASpecificHeight(ctx, height+1)
+		require.NoError(t, ctx.Err(), "network did not reach H=%d", height)
+
+		committed := make(map[primitives.BlockHeight]primitives.BlockHash)
+		for _, node := range net.Nodes {
+			chain := node.Blockchain()
+			for i := chain.Count() - 1; i > 0; i-- { // skips the genesis block
+				block, _ := chain.BlockAndProofAt(primitives.BlockHeight(i))
+				blockHash := mocks.CalculateBlockHash(block)
+				if expected, ok := committed[block.Height()]; ok {
+					require.True(t, expected.Equal(blockHash), "node %s committed a different block on H=%d", node.MemberId, block.Height())
+				} else {
+					committed[block.Height()] = blockHash
+				}
+			}
+		}
+		for h := primitives.BlockHeight(1); h <= height; h++ {
+			require.Contains(t, committed, h)
+		}
+	})
+}
+
+// A lagging node does not hold back the committee, so throughput is measured on a quorum of nodes
+func benchmarkThroughput(b *testing.B, pipelined bool) {
+	test.WithContext(func(ctx context.Context) {
+		net := aPipelinedNetwork(ctx, pipelined)
+		net.StartConsensus(ctx)
+		net.WaitUntilQuorumOfNodesEventuallyReachASpecificHeight(ctx, 2)
+
+		b.ResetTimer()
+		net.WaitUntilQuorumOfNodesEventuallyReachASpecificHeight(ctx, primitives.BlockHeight(b.N+2))
+	})
+}
+
+func BenchmarkSequentialProposalsThroughput(b *testing.B) {
+	benchmarkThroughput(b, false)
+}
+
+func BenchmarkPipelinedProposalsThroughput(b *testing.B) {
+	benchmarkThroughput(b, true)
+}
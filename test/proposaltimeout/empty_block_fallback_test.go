@@ -0,0 +1,43 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package proposaltimeout
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"github.com/orbs-network/lean-helix-go/test/network"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestEmptyBlocksAreCommittedWhenBlockProductionHangs(t *testing.T) {
+	test.WithContextWithTimeout(t, 15*time.Second, func(ctx context.Context) {
+		net := network.ATestNetworkBuilder(4).
+			WithBlockProductionDelay(time.Hour).
+			WithProposalTimeout(20 * time.Millisecond).
+			Build(ctx)
+
+		net.StartConsensus(ctx)
+		net.WaitUntilNodesEventuallyReachASpecificHeight(ctx, 3)
+		require.NoError(t, ctx.Err(), "the network should keep committing empty blocks")
+
+		for _, node := range net.Nodes {
+			block, _ := node.Blockchain().BlockAndProofAt(1)
+			require.True(t, mocks.IsEmptyBlock(block), "node %s should have committed an empty block", node.MemberId)
+		}
+
+		fallbacks := uint64(0)
+		for _, node := range net.Nodes {
+			metrics := node.BlockProposalMetrics()
+			require.Equal(t, metrics.Timeouts(), metrics.EmptyBlockFallbacks())
+			fallbacks += metrics.EmptyBlockFallbacks()
+		}
+		require.True(t, fallbacks >= 2, "each committed block should have been proposed by a fallback")
+	})
+}
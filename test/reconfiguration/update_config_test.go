@@ -0,0 +1,89 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package reconfiguration
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/services/logger"
+	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"github.com/orbs-network/lean-helix-go/test/network"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func updateConfigInBackground(ctx context.Context, node *network.Node, update *interfaces.ConfigUpdate) <-chan *interfaces.ConfigUpdateReport {
+	reports := make(chan *interfaces.ConfigUpdateReport, 1)
+	go func() {
+		report, err := node.UpdateConfig(ctx, update)
+		if err == nil {
+			reports <- report
+		}
+		close(reports)
+	}()
+	return reports
+}
+
+func TestConfigUpdateTakesEffectWhenTheNextHeightStarts(t *testing.T) {
+	test.WithContextWithTimeout(t, 15*time.Second, func(ctx context.Context) {
+		block1 := mocks.ABlock(interfaces.GenesisBlock)
+		block2 := mocks.ABlock(block1)
+
+		net := network.ATestNetworkBuilder(4, block1, block2).
+			WithTimeBasedElectionTrigger(time.Second).
+			Build(ctx)
+		leader := net.Nodes[0]
+		node := net.Nodes[1]
+		net.SetNodesToPauseOnRequestNewBlock()
+		net.StartConsensus(ctx)
+		net.ReturnWhenNodeIsPausedOnRequestNewBlock(ctx, leader)
+
+		electionTimeout := 2 * time.Second
+		expBase := 3.0
+		logLevel := logger.LOG_LEVEL_INFO
+		rebroadcastInterval := 50 * time.Millisecond
+		cacheSize := uint64(100)
+		proposalTimeout := time.Second
+		reports := updateConfigInBackground(ctx, node, &interfaces.ConfigUpdate{
+			ElectionTimeoutOnV0:    &electionTimeout,
+			ElectionTimeoutExpBase: &expBase,
+			LogLevel:               &logLevel,
+			RebroadcastInterval:    &rebroadcastInterval,
+			VerificationCacheSize:  &cacheSize,
+			ProposalTimeout:        &proposalTimeout,
+		})
+		require.True(t, test.Consistently(100*time.Millisecond, func() bool {
+			return node.ElectionTrigger.CalcTimeout(0) == time.Second
+		}), "the update should wait for the current height to end")
+
+		net.ResumeRequestNewBlockOnNodes(ctx, leader)
+		report := <-reports
+		require.NotNil(t, report)
+		require.ElementsMatch(t, []string{"ElectionTimeoutOnV0", "ElectionTimeoutExpBase", "LogLevel", "RebroadcastInterval"}, report.Applied)
+		require.Contains(t, report.Skipped, "VerificationCacheSize", "the node runs without a verification cache")
+		require.Contains(t, report.Skipped, "ProposalTimeout", "the node runs without a proposal deadline")
+		require.Equal(t, electionTimeout, node.ElectionTrigger.CalcTimeout(0))
+		require.Equal(t, 3*electionTimeout, node.ElectionTrigger.CalcTimeout(1))
+	})
+}
+
+func TestInvalidConfigUpdateIsRejected(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		net := network.ABasicTestNetwork(ctx)
+		net.StartConsensus(ctx)
+
+		electionTimeout := time.Duration(0)
+		logLevel := logger.LOG_LEVEL_ERROR
+		_, err := net.Nodes[0].UpdateConfig(ctx, &interfaces.ConfigUpdate{
+			ElectionTimeoutOnV0: &electionTimeout,
+			LogLevel:            &logLevel,
+		})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,145 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package subscription
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/services/events"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/orbs-network/lean-helix-go/test/leaderelection"
+	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"github.com/orbs-network/lean-helix-go/test/network"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// Returns the types of the events received so far at height
+func receivedEventTypes(subscription *events.Subscription, height primitives.BlockHeight) []events.EventType {
+	var types []events.EventType
+	for {
+		select {
+		case event := <-subscription.Events():
+			if event.Height == height {
+				types = append(types, event.Type)
+			}
+		default:
+			return types
+		}
+	}
+}
+
+// Waits for an event of eventType at height and returns the types of all the events received at height until then
+func waitForEventType(subscription *events.Subscription, height primitives.BlockHeight, eventType events.EventType) []events.EventType {
+	var types []events.EventType
+	test.Eventually(time.Second, func() bool {
+		types = append(types, receivedEventTypes(subscription, height)...)
+		for _, received := range types {
+			if received == eventType {
+				return true
+			}
+		}
+		return false
+	})
+	return types
+}
+
+func TestLeaderAndMemberPublishTheEventsOfAHeight(t *testing.T) {
+	test.WithContextWithTimeout(t, 15*time.Second, func(ctx context.Context) {
+		net := network.ABasicTestNetwork(ctx)
+		leader := net.Nodes[0]
+		member := net.Nodes[1]
+		leaderEvents := leader.Subscribe(100)
+		memberEvents := member.Subscribe(100)
+
+		net.StartConsensus(ctx)
+		net.WaitUntilNodesEventuallyReachASpecificHeight(ctx, 2, leader, member)
+
+		require.Subset(t, receivedEventTypes(leaderEvents, 1), []events.EventType{events.TERM_STARTED, events.PROPOSAL_SENT, events.PREPARED_LOCALLY, events.COMMITTED})
+		require.Subset(t, receivedEventTypes(memberEvents, 1), []events.EventType{events.TERM_STARTED, events.PROPOSAL_RECEIVED, events.PREPARED_LOCALLY, events.COMMITTED})
+		require.Zero(t, leaderEvents.Dropped())
+	})
+}
+
+func TestElectionPublishesViewChangeAndNewViewEvents(t *testing.T) {
+	test.WithContextWithTimeout(t, 15*time.Second, func(ctx context.Context) {
+		net := network.ABasicTestNetwork(ctx)
+		node0 := net.Nodes[0]
+		node1 := net.Nodes[1]
+		node2 := net.Nodes[2]
+		newLeaderEvents := node1.Subscribe(100)
+		memberEvents := node2.Subscribe(100)
+
+		net.SetNodesToPauseOnRequestNewBlock()
+		net.StartConsensus(ctx)
+		net.ReturnWhenNodeIsPausedOnRequestNewBlock(ctx, node0)
+		node0.Communication.DisableOutgoingCommunication()
+
+		net.TriggerElectionsOnAllNodes(ctx) // elects node1
+		net.ReturnWhenNodeIsPausedOnRequestNewBlock(ctx, node1)
+		net.ResumeRequestNewBlockOnNodes(ctx, node1)
+		net.WaitUntilNodesEventuallyReachASpecificHeight(ctx, 2, node1, node2)
+
+		require.Subset(t, receivedEventTypes(newLeaderEvents, 1), []events.EventType{events.ELECTED_AS_LEADER, events.PROPOSAL_SENT, events.COMMITTED})
+		require.Subset(t, receivedEventTypes(memberEvents, 1), []events.EventType{events.VIEW_CHANGE_SENT, events.NEW_VIEW_ACCEPTED, events.COMMITTED})
+	})
+}
+
+func TestSyncedBlockPublishesSynced(t *testing.T) {
+	test.WithContextWithTimeout(t, 15*time.Second, func(ctx context.Context) {
+		block1 := mocks.ABlock(interfaces.GenesisBlock)
+		block2 := mocks.ABlock(block1)
+
+		net := network.ATestNetworkBuilder(4, block1, block2).Build(ctx)
+		node3 := net.Nodes[3]
+		node3Events := node3.Subscribe(100)
+
+		net.SetNodesToPauseOnRequestNewBlock()
+		net.StartConsensus(ctx)
+		node3.Communication.DisableIncomingCommunication()
+
+		bc, err := leaderelection.GenerateBlocksWithProofsForTest([]interfaces.Block{block1, block2}, net.Nodes)
+		require.NoError(t, err)
+		block, blockProof := bc.BlockAndProofAt(2)
+		prevBlock, prevBlockProof := bc.BlockAndProofAt(1)
+		require.NoError(t, node3.Sync(ctx, block, blockProof, prevBlock, prevBlockProof))
+
+		require.Contains(t, waitForEventType(node3Events, 3, events.SYNCED), events.SYNCED)
+	})
+}
+
+func TestCommitCertificatePublishesItsOwnEventInsteadOfSynced(t *testing.T) {
+	test.WithContextWithTimeout(t, 15*time.Second, func(ctx context.Context) {
+		block1 := mocks.ABlock(interfaces.GenesisBlock)
+		block2 := mocks.ABlock(block1)
+
+		net := network.ATestNetworkBuilder(4, block1, block2).Build(ctx)
+		node0 := net.Nodes[0]
+		node3 := net.Nodes[3]
+		node3Events := node3.Subscribe(100)
+
+		net.SetNodesToPauseOnRequestNewBlock()
+		net.StartConsensus(ctx)
+		node3.Communication.DisableIncomingCommunication()
+
+		net.ReturnWhenNodeIsPausedOnRequestNewBlock(ctx, node0)
+		net.ResumeRequestNewBlockOnNodes(ctx, node0)
+		net.WaitUntilNodesEventuallyCommitASpecificBlock(ctx, t, 0, block1, net.Nodes[:3]...)
+		net.ReturnWhenNodeIsPausedOnRequestNewBlock(ctx, node0)
+
+		// node3's VIEW_CHANGE of H=1 reveals it is lagging, and the new leader pushes it the commit certificate of block1
+		node3.Communication.EnableIncomingCommunication()
+		<-node3.TriggerElectionOnNode(ctx)
+		<-node3.TriggerElectionOnNode(ctx)
+
+		types := waitForEventType(node3Events, 2, events.COMMIT_CERTIFICATE_APPLIED)
+		require.Contains(t, types, events.COMMIT_CERTIFICATE_APPLIED)
+		require.NotContains(t, types, events.SYNCED)
+	})
+}
@@ -0,0 +1,253 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package leanhelix
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/instrumentation/metrics"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	L "github.com/orbs-network/lean-helix-go/services/logger"
+	"github.com/orbs-network/lean-helix-go/services/messagequeue"
+	"github.com/orbs-network/lean-helix-go/services/randomseed"
+	"github.com/orbs-network/lean-helix-go/services/verificationcache"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
+	"github.com/pkg/errors"
+	"hash/fnv"
+	"sync"
+)
+
+const VERIFICATION_SHARD_CHAN_BUF_LEN = 1000
+
+// VerificationPipeline sits between MainLoop and WorkerLoop and verifies message signatures on a pool of goroutines.
+// A message is marked as authenticated by recording its verifications in the shared VerificationCache,
+// so the checks the worker still performs become cache hits. Messages whose sender signature fails are dropped here.
+// Messages are sharded by sender so that messages of a single sender reach the worker in the order they arrived.
+type VerificationPipeline struct {
+	shards     []chan *interfaces.ConsensusRawMessage
+	output     *messagequeue.MessageQueue
+	cache      *verificationcache.VerificationCache
+	beacon     interfaces.RandomnessBeacon
+	instanceId primitives.InstanceId
+	myMemberId primitives.MemberId
+	logger     L.LHLogger
+	onRejected interfaces.OnMessageRejectedCallback
+
+	seedLock       sync.RWMutex
+	termHeight     primitives.BlockHeight
+	termRandomSeed uint64
+
+	droppedLock sync.Mutex
+	dropped     map[protocol.MessageType]uint64
+}
+
+func NewVerificationPipeline(workers int, cache *verificationcache.VerificationCache, config *interfaces.Config, logger L.LHLogger, output *messagequeue.MessageQueue) *VerificationPipeline {
+	if workers < 1 {
+		panic("verification pipeline must have at least one worker")
+	}
+	shards := make([]chan *interfaces.ConsensusRawMessage, workers)
+	for i := range shards {
+		shards[i] = make(chan *interfaces.ConsensusRawMessage, VERIFICATION_SHARD_CHAN_BUF_LEN)
+	}
+	return &VerificationPipeline{
+		shards:     shards,
+		output:     output,
+		cache:      cache,
+		beacon:     randomseed.RandomnessBeaconOf(config),
+		instanceId: config.InstanceId,
+		myMemberId: config.Membership.MyMemberId(),
+		logger:     logger,
+		onRejected: config.OnMessageRejectedCB,
+		dropped:    make(map[protocol.MessageType]uint64),
+	}
+}
+
+func (p *VerificationPipeline) Workers() int {
+	return len(p.shards)
+}
+
+// Submit never blocks; the message is dropped if its shard is full, counted in Metrics and reported with ErrQueueFull
+func (p *VerificationPipeline) Submit(ctx context.Context, message *interfaces.ConsensusRawMessage, sender primitives.MemberId) {
+	select {
+	default:
+		p.drop(message, sender)
+	case <-ctx.Done():
+	case p.shards[p.shardOf(sender)] <- message:
+	}
+}
+
+func (p *VerificationPipeline) drop(message *interfaces.ConsensusRawMessage, sender primitives.MemberId) {
+	var messageType protocol.MessageType
+	if parsedMessage := interfaces.ToConsensusMessage(message); parsedMessage != nil {
+		messageType = parsedMessage.MessageType()
+	}
+	p.droppedLock.Lock()
+	p.dropped[messageType]++
+	p.droppedLock.Unlock()
+
+	p.logger.Debug("LHFLOW LHMSG VERIFICATION PIPELINE - SHARD FULL, DROPPING %v from %v", messageType, sender)
+	p.onRejected.Reject(message, errors.Wrapf(interfaces.ErrQueueFull, "verification pipeline shard of %v is full, dropped %v", sender, messageType))
+}
+
+func (p *VerificationPipeline) Metrics() metrics.VerificationPipelineMetrics {
+	p.droppedLock.Lock()
+	defer p.droppedLock.Unlock()
+	dropped := make(map[protocol.MessageType]uint64, len(p.dropped))
+	for messageType, count := range p.dropped {
+		dropped[messageType] = count
+	}
+	return metrics.NewVerificationPipelineMetrics(len(p.shards), dropped)
+}
+
+func (p *VerificationPipeline) shardOf(sender primitives.MemberId) int {
+	hash := fnv.New32a()
+	hash.Write(sender)
+	return int(hash.Sum32() % uint32(len(p.shards)))
+}
+
+// Called by the worker when a new term starts, enabling verification of random seed shares of that term's COMMITs
+func (p *VerificationPipeline) setTermRandomSeed(height primitives.BlockHeight, randomSeed uint64) {
+	p.seedLock.Lock()
+	defer p.seedLock.Unlock()
+	p.termHeight = height
+	p.termRandomSeed = randomSeed
+}
+
+func (p *VerificationPipeline) termSeedFor(height primitives.BlockHeight) (uint64, bool) {
+	p.seedLock.RLock()
+	defer p.seedLock.RUnlock()
+	return p.termRandomSeed, p.termHeight == height
+}
+
+func (p *VerificationPipeline) runShard(ctx context.Context, shard int) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case message := <-p.shards[shard]:
+			if err := p.verify(interfaces.ToConsensusMessage(message)); err != nil {
+				p.logger.Debug("LHFLOW LHMSG VERIFICATION PIPELINE - DROPPING MESSAGE: %s", err)
+				p.onRejected.Reject(message, errors.Wrap(interfaces.ErrInvalidSignature, err.Error()))
+				continue
+			}
+			p.output.Push(message)
+		}
+	}
+}
+
+// Only a failure of the message's own signature is an error; nested proofs are only pre-verified here and judged by the worker
+func (p *VerificationPipeline) verify(message interfaces.ConsensusMessage) error {
+	if message.InstanceId() != p.instanceId || message.SenderMemberId().Equal(p.myMemberId) {
+		return nil // the worker's filter drops these without verifying
+	}
+
+	switch message := message.(type) {
+	case *interfaces.PreprepareMessage:
+		return p.verifyBlockRef(message.Content().SignedHeader(), message.Content().Sender())
+
+	case *interfaces.PrepareMessage:
+		return p.verifyBlockRef(message.Content().SignedHeader(), message.Content().Sender())
+
+	case *interfaces.CommitMessage:
+		content := message.Content()
+		if err := p.verifyBlockRef(content.SignedHeader(), content.Sender()); err != nil {
+			return err
+		}
+		if randomSeed, ok := p.termSeedFor(message.BlockHeight()); ok {
+			share := (&protocol.SenderSignatureBuilder{
+				MemberId:  content.Sender().MemberId(),
+				Signature: primitives.Signature(content.Share()),
+			}).Build()
+			if err := p.beacon.VerifyShare(message.BlockHeight(), randomSeed, share); err != nil {
+				return errors.Wrap(err, "COMMIT random seed share verification failed")
+			}
+		}
+		return nil
+
+	case *interfaces.ViewChangeMessage:
+		header := message.Content().SignedHeader()
+		if err := p.cache.VerifyConsensusMessage(header.BlockHeight(), header.Raw(), message.Content().Sender()); err != nil {
+			return errors.Wrap(err, "VIEW_CHANGE signature verification failed")
+		}
+		p.preVerifyPreparedProof(header.PreparedProof())
+		if summarySender := message.Content().SummarySender(); summarySender != nil && len(summarySender.Signature()) > 0 {
+			p.cache.VerifyConsensusMessage(header.BlockHeight(), interfaces.ViewChangeSummaryHeaderFor(header).Build().Raw(), summarySender)
+		}
+		return nil
+
+	case *interfaces.NewViewMessage:
+		header := message.Content().SignedHeader()
+		if err := p.cache.VerifyConsensusMessage(header.BlockHeight(), header.Raw(), message.Content().Sender()); err != nil {
+			return errors.Wrap(err, "NEW_VIEW signature verification failed")
+		}
+		ppm := message.Content().Message()
+		p.verifyBlockRef(ppm.SignedHeader(), ppm.Sender())
+		confirmations := header.ViewChangeConfirmationsIterator()
+		for confirmations.HasNext() {
+			confirmation := confirmations.NextViewChangeConfirmations()
+			confirmationHeader := confirmation.SignedHeader()
+			p.cache.VerifyConsensusMessage(confirmationHeader.BlockHeight(), confirmationHeader.Raw(), confirmation.Sender())
+			p.preVerifyPreparedProof(confirmationHeader.PreparedProof())
+		}
+		compactConfirmations := header.CompactConfirmationsIterator()
+		for compactConfirmations.HasNext() {
+			confirmation := compactConfirmations.NextCompactConfirmations()
+			p.cache.VerifyConsensusMessage(confirmation.SignedHeader().BlockHeight(), confirmation.SignedHeader().Raw(), confirmation.Sender())
+		}
+		p.preVerifyPreparedProof(header.HighestPreparedProof())
+		return nil
+
+	case *interfaces.MessagesRequestMessage:
+		header := message.Content().SignedHeader()
+		if err := p.cache.VerifyConsensusMessage(header.BlockHeight(), header.Raw(), message.Content().Sender()); err != nil {
+			return errors.Wrap(err, "MESSAGES_REQUEST signature verification failed")
+		}
+		return nil
+
+	case *interfaces.BlockRequestMessage:
+		return p.verifyBlockRef(message.Content().SignedHeader(), message.Content().Sender())
+
+	case *interfaces.BlockResponseMessage:
+		return p.verifyBlockRef(message.Content().SignedHeader(), message.Content().Sender())
+
+	case *interfaces.BlockChunkMessage:
+		header := message.Content().SignedHeader()
+		if err := p.cache.VerifyConsensusMessage(header.BlockHeight(), header.Raw(), message.Content().Sender()); err != nil {
+			return errors.Wrap(err, "BLOCK_CHUNK signature verification failed")
+		}
+		return nil
+
+	case *interfaces.MessagesResponseMessage:
+		for _, pm := range message.PrepareMessages() {
+			p.verifyBlockRef(pm.Content().SignedHeader(), pm.Content().Sender())
+		}
+		for _, cm := range message.CommitMessages() {
+			p.verifyBlockRef(cm.Content().SignedHeader(), cm.Content().Sender())
+		}
+		return nil
+	}
+
+	return nil
+}
+
+func (p *VerificationPipeline) verifyBlockRef(blockRef *protocol.BlockRef, sender *protocol.SenderSignature) error {
+	if err := p.cache.VerifyConsensusMessage(blockRef.BlockHeight(), blockRef.Raw(), sender); err != nil {
+		return errors.Wrapf(err, "%v signature verification failed", blockRef.MessageType())
+	}
+	return nil
+}
+
+func (p *VerificationPipeline) preVerifyPreparedProof(proof *protocol.PreparedProof) {
+	if proof == nil || len(proof.Raw()) == 0 {
+		return
+	}
+	p.verifyBlockRef(proof.PreprepareBlockRef(), proof.PreprepareSender())
+	prepareSenders := proof.PrepareSendersIterator()
+	for prepareSenders.HasNext() {
+		p.verifyBlockRef(proof.PrepareBlockRef(), prepareSenders.NextPrepareSenders())
+	}
+}
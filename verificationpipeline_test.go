@@ -0,0 +1,151 @@
+// Copyright 2019 the lean-helix-go authors
+// This file is part of the lean-helix-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package leanhelix
+
+import (
+	"context"
+	"github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/services/logger"
+	"github.com/orbs-network/lean-helix-go/services/messagequeue"
+	"github.com/orbs-network/lean-helix-go/services/verificationcache"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
+	"github.com/orbs-network/lean-helix-go/state"
+	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/orbs-network/lean-helix-go/test/builders"
+	"github.com/orbs-network/lean-helix-go/test/mocks"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func runTestPipeline(ctx context.Context, workers int, output *messagequeue.MessageQueue) (*VerificationPipeline, *verificationcache.VerificationCache) {
+	cfg := DummyWorkerConfig()
+	cache := verificationcache.NewVerificationCache(mocks.NewMockKeyManager(cfg.Membership.MyMemberId()), 100)
+	cfg.KeyManager = cache
+	s := state.NewState()
+	pipeline := NewVerificationPipeline(workers, cache, cfg, logger.NewLhLogger(cfg, s), output)
+	for i := 0; i < workers; i++ {
+		go pipeline.runShard(ctx, i)
+	}
+	return pipeline, cache
+}
+
+func aPipelineOutput() *messagequeue.MessageQueue {
+	return messagequeue.NewMessageQueue(100, func() primitives.BlockHeight { return 1 })
+}
+
+func receiveMessage(t *testing.T, output *messagequeue.MessageQueue) interfaces.ConsensusMessage {
+	select {
+	case <-output.Ready():
+		return interfaces.ToConsensusMessage(output.Pop())
+	case <-time.After(1 * time.Second):
+		t.Fatal("message was not forwarded by the verification pipeline")
+		return nil
+	}
+}
+
+func TestVerificationPipelineForwardsAuthenticatedMessagesInSenderOrder(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		output := aPipelineOutput()
+		pipeline, cache := runTestPipeline(ctx, 4, output)
+
+		sender := primitives.MemberId("sender")
+		block := mocks.ABlock(interfaces.GenesisBlock)
+		km := mocks.NewMockKeyManager(sender)
+		for view := primitives.View(0); view < 10; view++ {
+			pipeline.Submit(ctx, builders.APrepareMessage(123, km, sender, 1, view, block).ToConsensusRawMessage(), sender)
+		}
+
+		for view := primitives.View(0); view < 10; view++ {
+			require.Equal(t, view, receiveMessage(t, output).View())
+		}
+		require.Equal(t, uint64(10), cache.Metrics().Misses())
+
+		prepare := builders.APrepareMessage(123, km, sender, 1, 5, block)
+		require.NoError(t, cache.VerifyConsensusMessage(1, prepare.Content().SignedHeader().Raw(), prepare.Content().Sender()))
+		require.Equal(t, uint64(1), cache.Metrics().Hits(), "a forwarded message should already be marked as authenticated")
+	})
+}
+
+func TestVerificationPipelineDropsMessagesWithInvalidSignature(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		output := aPipelineOutput()
+		pipeline, _ := runTestPipeline(ctx, 2, output)
+
+		sender := primitives.MemberId("sender")
+		block := mocks.ABlock(interfaces.GenesisBlock)
+		impostor := mocks.NewMockKeyManager(primitives.MemberId("impostor"))
+		pipeline.Submit(ctx, builders.APrepareMessage(123, impostor, sender, 1, 0, block).ToConsensusRawMessage(), sender)
+		pipeline.Submit(ctx, builders.APrepareMessage(123, mocks.NewMockKeyManager(sender), sender, 1, 1, block).ToConsensusRawMessage(), sender)
+
+		require.Equal(t, primitives.View(1), receiveMessage(t, output).View())
+		select {
+		case <-output.Ready():
+			t.Fatalf("unexpected message forwarded: %v", interfaces.ToConsensusMessage(output.Pop()))
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+}
+
+func TestVerificationPipelineVerifiesRandomSeedShareOfCurrentTerm(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		output := aPipelineOutput()
+		pipeline, _ := runTestPipeline(ctx, 1, output)
+		pipeline.setTermRandomSeed(1, 777)
+
+		sender := primitives.MemberId("sender")
+		km := mocks.NewMockKeyManager(sender)
+		block := mocks.ABlock(interfaces.GenesisBlock)
+		pipeline.Submit(ctx, builders.ACommitMessage(123, km, sender, 1, 0, block, 666).ToConsensusRawMessage(), sender)
+		pipeline.Submit(ctx, builders.ACommitMessage(123, km, sender, 1, 1, block, 777).ToConsensusRawMessage(), sender)
+
+		require.Equal(t, primitives.View(1), receiveMessage(t, output).View())
+		select {
+		case <-output.Ready():
+			t.Fatalf("unexpected message forwarded: %v", interfaces.ToConsensusMessage(output.Pop()))
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+}
+
+func TestVerificationPipelineCountsAndReportsMessagesDroppedWhenAShardIsFull(t *testing.T) {
+	test.WithContext(func(ctx context.Context) {
+		cfg := DummyWorkerConfig()
+		var rejections []error
+		cfg.OnMessageRejectedCB = func(message *interfaces.ConsensusRawMessage, err error) {
+			rejections = append(rejections, err)
+		}
+		s := state.NewState()
+		// no shard is running, so the shard of the sender fills up
+		pipeline := NewVerificationPipeline(1, nil, cfg, logger.NewLhLogger(cfg, s), aPipelineOutput())
+
+		sender := primitives.MemberId("sender")
+		km := mocks.NewMockKeyManager(sender)
+		block := mocks.ABlock(interfaces.GenesisBlock)
+		for i := 0; i < VERIFICATION_SHARD_CHAN_BUF_LEN; i++ {
+			pipeline.Submit(ctx, builders.APrepareMessage(123, km, sender, 1, 0, block).ToConsensusRawMessage(), sender)
+		}
+		require.Zero(t, pipeline.Metrics().Dropped())
+
+		pipeline.Submit(ctx, builders.APrepareMessage(123, km, sender, 1, 1, block).ToConsensusRawMessage(), sender)
+		pipeline.Submit(ctx, builders.APrepareMessage(123, km, sender, 1, 2, block).ToConsensusRawMessage(), sender)
+		pipeline.Submit(ctx, builders.ACommitMessage(123, km, sender, 1, 2, block, 0).ToConsensusRawMessage(), sender)
+
+		pipelineMetrics := pipeline.Metrics()
+		require.Equal(t, uint64(3), pipelineMetrics.Dropped())
+		require.Equal(t, uint64(2), pipelineMetrics.DroppedOf(protocol.LEAN_HELIX_PREPARE))
+		require.Equal(t, uint64(1), pipelineMetrics.DroppedOf(protocol.LEAN_HELIX_COMMIT))
+
+		require.Len(t, rejections, 3)
+		for _, err := range rejections {
+			require.Equal(t, interfaces.ErrQueueFull, errors.Cause(err))
+			require.False(t, interfaces.IsPeerMisbehavior(err), "a full shard is not the sender's fault")
+		}
+	})
+}
@@ -10,19 +10,24 @@ import (
 	"context"
 	"github.com/orbs-network/lean-helix-go/services/blockheight"
 	"github.com/orbs-network/lean-helix-go/services/blockreferencetime"
+	"github.com/orbs-network/lean-helix-go/services/events"
 	"github.com/orbs-network/lean-helix-go/services/interfaces"
 	"github.com/orbs-network/lean-helix-go/services/leanhelixterm"
 	L "github.com/orbs-network/lean-helix-go/services/logger"
+	"github.com/orbs-network/lean-helix-go/services/messagequeue"
 	"github.com/orbs-network/lean-helix-go/services/proofsvalidator"
 	"github.com/orbs-network/lean-helix-go/services/quorum"
 	"github.com/orbs-network/lean-helix-go/services/randomseed"
 	"github.com/orbs-network/lean-helix-go/services/rawmessagesfilter"
 	"github.com/orbs-network/lean-helix-go/services/storage"
 	"github.com/orbs-network/lean-helix-go/services/termincommittee"
+	"github.com/orbs-network/lean-helix-go/services/verificationcache"
 	"github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
 	"github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
 	"github.com/orbs-network/lean-helix-go/state"
+	"github.com/orbs-network/scribe/log"
 	"github.com/pkg/errors"
+	"time"
 )
 
 type blockWithProof struct {
@@ -31,7 +36,7 @@ type blockWithProof struct {
 }
 
 type WorkerLoop struct {
-	MessagesChannel             chan *interfaces.ConsensusRawMessage
+	MessagesQueue               *messagequeue.MessageQueue
 	workerUpdateStateChannel    chan *blockWithProof
 	electionChannel             chan *interfaces.ElectionTrigger
 	electionTrigger             interfaces.ElectionScheduler
@@ -42,6 +47,19 @@ type WorkerLoop struct {
 	leanHelixTerm               *leanhelixterm.LeanHelixTerm
 	onCommitCallback            interfaces.OnCommitCallback
 	onNewConsensusRoundCallback interfaces.OnNewConsensusRoundCallback
+	verificationPipeline        *VerificationPipeline
+	asyncBlockValidation        *AsyncBlockValidation
+	verificationCache           *verificationcache.VerificationCache
+	blockProposalDeadline       *BlockProposalDeadline
+	retransmissionTicker        *time.Ticker
+	rebroadcastTicker           *time.Ticker
+	configUpdates               chan *configUpdateRequest
+	pendingConfigUpdates        []*configUpdateRequest
+	latestBlock                 interfaces.Block
+	latestBlockProofBytes       []byte
+	commitCertificatePushes     map[storage.MemberIdStr]primitives.BlockHeight
+	latestCommittee             []primitives.MemberId // committee of latestBlock, if we were a member of it
+	publisher                   *events.Publisher
 }
 
 func NewWorkerLoop(
@@ -53,11 +71,11 @@ func NewWorkerLoop(
 	onNewConsensusRoundCallback interfaces.OnNewConsensusRoundCallback) *WorkerLoop {
 
 	logger.Debug("LHFLOW NewWorkerLoop()")
-	filter := rawmessagesfilter.NewConsensusMessageFilter(config.InstanceId, config.Membership.MyMemberId(), logger, state)
+	filter := rawmessagesfilter.NewConsensusMessageFilter(config.InstanceId, config.Membership.MyMemberId(), logger, state, config.OnMessageRejectedCB)
 	return &WorkerLoop{
-		MessagesChannel:             make(chan *interfaces.ConsensusRawMessage, 1000), // TODO config.MsgChanBufLen
-		workerUpdateStateChannel:    make(chan *blockWithProof, 1),                    // must be at least 1 // TODO config.UpdateStateChanBufLen
-		electionChannel:             make(chan *interfaces.ElectionTrigger, 1),        // must be at least 1 // TODO config.ElectionChanBufLen
+		MessagesQueue:               messagequeue.NewMessageQueue(int(config.MsgChanBufLen), state.Height),
+		workerUpdateStateChannel:    make(chan *blockWithProof, atLeastOne(config.UpdateStateChanBufLen)),
+		electionChannel:             make(chan *interfaces.ElectionTrigger, atLeastOne(config.ElectionChanBufLen)),
 		electionTrigger:             electionTrigger,
 		state:                       state,
 		config:                      config,
@@ -65,11 +83,52 @@ func NewWorkerLoop(
 		filter:                      filter,
 		onCommitCallback:            onCommitCallback,
 		onNewConsensusRoundCallback: onNewConsensusRoundCallback,
+		commitCertificatePushes:     make(map[storage.MemberIdStr]primitives.BlockHeight),
+		configUpdates:               make(chan *configUpdateRequest),
 	}
 }
 
+// The update state and election channels must be at least 1, see MainLoop.sendUpdateMessageNonBlocking
+func atLeastOne(bufLen uint64) int {
+	if bufLen < 1 {
+		return 1
+	}
+	return int(bufLen)
+}
+
+// Stops ticker and returns a new one, or nil when interval is not positive
+func resetTicker(ticker *time.Ticker, interval time.Duration) *time.Ticker {
+	if ticker != nil {
+		ticker.Stop()
+	}
+	if interval <= 0 {
+		return nil
+	}
+	return time.NewTicker(interval)
+}
+
+// nil channel never fires when the ticker is disabled
+func ticks(ticker *time.Ticker) <-chan time.Time {
+	if ticker == nil {
+		return nil
+	}
+	return ticker.C
+}
+
 func (lh *WorkerLoop) Run(ctx context.Context) {
 	lh.logger.Debug("LHFLOW LHMSG WORKERLOOP START LISTENING NOW")
+	lh.retransmissionTicker = resetTicker(nil, lh.config.RetransmissionInterval)
+	lh.rebroadcastTicker = resetTicker(nil, lh.config.RebroadcastInterval)
+	defer func() {
+		resetTicker(lh.retransmissionTicker, 0)
+		resetTicker(lh.rebroadcastTicker, 0)
+	}()
+	var blockValidationResults <-chan func()
+	if lh.config.AsyncBlockValidation {
+		logger := log.GetLogger().WithTags(log.Node(lh.config.InstanceId.String()), log.String("event_loop", "LHBlockValidation"))
+		lh.asyncBlockValidation = NewAsyncBlockValidation(ctx, GovnrErrorer(logger))
+		blockValidationResults = lh.asyncBlockValidation.Results()
+	}
 	for {
 		select {
 		case <-ctx.Done(): // system shutdown
@@ -78,11 +137,23 @@ func (lh *WorkerLoop) Run(ctx context.Context) {
 			lh.logger.Info("LHFLOW WORKERLOOP DONE STOPPED LISTENING, SHUTDOWN END")
 			return
 
-		case msg := <-lh.MessagesChannel:
+		case <-lh.MessagesQueue.Ready():
+			msg := lh.MessagesQueue.Pop()
+			if msg == nil {
+				continue
+			}
 			parsedMessage := interfaces.ToConsensusMessage(msg)
 			lh.logger.Debug("LHFLOW LHMSG WORKERLOOP RECEIVED %v from %v for H=%d V=%d", parsedMessage.MessageType(), parsedMessage.SenderMemberId(), parsedMessage.BlockHeight(), parsedMessage.View())
+			if ccm, ok := parsedMessage.(*interfaces.CommitCertificateMessage); ok {
+				lh.handleCommitCertificate(ccm)
+				continue
+			}
+			lh.pushCommitCertificateIfLagging(parsedMessage)
 			lh.filter.HandleConsensusRawMessage(msg)
 
+		case onValidated := <-blockValidationResults:
+			onValidated()
+
 		case trigger := <-lh.electionChannel:
 			if trigger == nil {
 				// this cannot happen, ignore
@@ -99,6 +170,19 @@ func (lh *WorkerLoop) Run(ctx context.Context) {
 			lh.logger.Debug("LHFLOW WORKERLOOP ELECTION")
 			trigger.MoveToNextLeader()
 
+		case <-ticks(lh.retransmissionTicker):
+			if lh.leanHelixTerm != nil {
+				lh.leanHelixTerm.RequestMissingMessages()
+			}
+
+		case now := <-ticks(lh.rebroadcastTicker):
+			if lh.leanHelixTerm != nil {
+				lh.leanHelixTerm.RebroadcastOwnMessages(lh.config.RebroadcastInterval, now)
+			}
+
+		case request := <-lh.configUpdates:
+			lh.pendingConfigUpdates = append(lh.pendingConfigUpdates, request)
+
 		case receivedBlockWithProof := <-lh.workerUpdateStateChannel: // NodeSync
 			var height primitives.BlockHeight
 
@@ -106,13 +190,16 @@ func (lh *WorkerLoop) Run(ctx context.Context) {
 				height = receivedBlockWithProof.block.Height()
 			}
 			lh.logger.Debug("LHFLOW UPDATESTATE WORKERLOOP - Received block with H=%d", height)
-			lh.handleUpdateState(receivedBlockWithProof)
+			if lh.handleUpdateState(receivedBlockWithProof) {
+				lh.publisher.Publish(&events.Event{Type: events.SYNCED, Height: lh.state.Height()})
+			}
 			lh.logger.Debug("LHFLOW UPDATESTATE WORKERLOOP - Handled block with H=%d", height)
 		}
 	}
 }
 
-func (lh *WorkerLoop) handleUpdateState(receivedBlockWithProof *blockWithProof) {
+// Returns false when the block is ignored for being below the current height
+func (lh *WorkerLoop) handleUpdateState(receivedBlockWithProof *blockWithProof) bool {
 	receivedBlockHeight := blockheight.GetBlockHeight(receivedBlockWithProof.block)
 
 	if receivedBlockHeight >= lh.state.Height() {
@@ -120,9 +207,10 @@ func (lh *WorkerLoop) handleUpdateState(receivedBlockWithProof *blockWithProof)
 		// This block is received from external source
 		// Refuse to be leader on V=0 for a block received from block sync, because this block will usually be not be the latest block.
 		lh.onNewConsensusRound(receivedBlockWithProof.block, receivedBlockWithProof.prevBlockProofBytes, false)
-	} else {
-		lh.logger.Debug("LHFLOW UPDATESTATE WORKERLOOP IGNORE - Received block ignored because its height=%d is less than current height=%d", receivedBlockHeight, lh.state.Height())
+		return true
 	}
+	lh.logger.Debug("LHFLOW UPDATESTATE WORKERLOOP IGNORE - Received block ignored because its height=%d is less than current height=%d", receivedBlockHeight, lh.state.Height())
+	return false
 }
 
 func (lh *WorkerLoop) ValidateBlockConsensus(ctx context.Context, block interfaces.Block, blockProofBytes []byte, prevBlock interfaces.Block, maybePrevBlockProofBytes []byte, softVerify bool) error {
@@ -206,7 +294,7 @@ func (lh *WorkerLoop) ValidateBlockConsensus(ctx context.Context, block interfac
 	}
 
 	prevBlockProof := protocol.BlockProofReader(maybePrevBlockProofBytes)
-	if err := randomseed.ValidateRandomSeed(lh.config.KeyManager, blockHeight, blockProof, prevBlockProof); err != nil {
+	if err := randomseed.ValidateRandomSeed(randomseed.RandomnessBeaconOf(lh.config), blockHeight, blockProof, prevBlockProof); err != nil {
 		return errors.Wrapf(err, "ValidateBlockConsensus: ValidateRandomSeed() failed")
 	}
 	lh.logger.Debug("ValidateBlockConsensus PASSED for blockHeight=%s", block.Height())
@@ -239,6 +327,7 @@ func (lh *WorkerLoop) onNewConsensusRound(prevBlock interfaces.Block, prevBlockP
 		return
 	}
 
+	previousHeight := lh.state.Height()
 	current, err := lh.state.SetHeightAndResetView(hv.Height())
 	if err != nil {
 		lh.logger.Info("onNewConsensusRound() failed height increment %d: %s", current.Height(), err)
@@ -246,14 +335,25 @@ func (lh *WorkerLoop) onNewConsensusRound(prevBlock interfaces.Block, prevBlockP
 	}
 
 	lh.logger.Debug("onNewConsensusRound() INCREMENTED HEIGHT TO %d", current.Height())
+	lh.latestBlock = prevBlock
+	lh.latestBlockProofBytes = prevBlockProofBytes
+	lh.latestCommittee = nil
+	if lh.leanHelixTerm != nil && previousHeight == blockheight.GetBlockHeight(prevBlock) {
+		lh.latestCommittee = lh.leanHelixTerm.CommitteeMemberIds()
+	}
+	if lh.verificationPipeline != nil {
+		prevBlockProof := protocol.BlockProofReader(prevBlockProofBytes)
+		lh.verificationPipeline.setTermRandomSeed(current.Height(), randomseed.RandomnessBeaconOf(lh.config).DeriveRandomSeed(current.Height(), prevBlockProof))
+	}
 	if lh.leanHelixTerm != nil {
 		lh.leanHelixTerm.Dispose()
 		lh.leanHelixTerm = nil
 	}
 
+	lh.applyPendingConfigUpdates()
 	lh.logger.ConsensusTrace("starting a new consensus round", nil)
 
-	lh.leanHelixTerm = leanhelixterm.NewLeanHelixTerm(ctx, lh.logger, lh.config, lh.state, lh.electionTrigger, lh.onCommit, prevBlock, prevBlockProofBytes, canBeFirstLeader)
+	lh.leanHelixTerm = leanhelixterm.NewLeanHelixTerm(ctx, lh.logger, lh.config, lh.state, lh.electionTrigger, lh.onCommit, prevBlock, prevBlockProofBytes, canBeFirstLeader, lh.blockValidationScheduler(), lh.publisher)
 	lh.logger.Debug("onNewConsensusRound() Calling ConsumeCacheMessages for H=%d", lh.state.Height())
 	lh.filter.ConsumeCacheMessages(lh.leanHelixTerm)
 	if lh.onNewConsensusRoundCallback != nil {
@@ -261,6 +361,21 @@ func (lh *WorkerLoop) onNewConsensusRound(prevBlock interfaces.Block, prevBlockP
 	}
 }
 
+// Called by the election trigger of MainLoop.RequestViewChange, once the worker loop checked it is not stale
+func (lh *WorkerLoop) requestViewChange(hv *state.HeightView) {
+	if lh.leanHelixTerm != nil {
+		lh.leanHelixTerm.RequestViewChange(hv.Height(), hv.View(), lh.config.OnElectionCB)
+	}
+}
+
+// Returns nil when block proposals are validated synchronously
+func (lh *WorkerLoop) blockValidationScheduler() interfaces.BlockValidationScheduler {
+	if lh.asyncBlockValidation == nil {
+		return nil
+	}
+	return lh.asyncBlockValidation
+}
+
 func (lh *WorkerLoop) cleanupCurrentTerm() {
 	if lh.leanHelixTerm != nil {
 		lh.leanHelixTerm.Dispose()